@@ -4,6 +4,8 @@
 package config
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/configtls"
 )
 
@@ -12,11 +14,15 @@ type Supervisor struct {
 	Server       *OpAMPServer
 	Agent        *Agent
 	Capabilities *Capabilities `mapstructure:"capabilities"`
+	Storage      *Storage      `mapstructure:"storage"`
+	Packages     *Packages     `mapstructure:"packages"`
 }
 
 // Capabilities is the set of capabilities that the Supervisor supports.
 type Capabilities struct {
 	AcceptsRemoteConfig    *bool `mapstructure:"accepts_remote_config"`
+	AcceptsPackages        *bool `mapstructure:"accepts_packages"`
+	ReportsPackageStatuses *bool `mapstructure:"reports_package_statuses"`
 	ReportsEffectiveConfig *bool `mapstructure:"reports_effective_config"`
 	ReportsOwnMetrics      *bool `mapstructure:"reports_own_metrics"`
 	ReportsHealth          *bool `mapstructure:"reports_health"`
@@ -30,4 +36,23 @@ type OpAMPServer struct {
 
 type Agent struct {
 	Executable string
+	// ConfigApplyTimeout is the time the Agent is given to report healthy
+	// after a new config is applied, before rolling back to the last
+	// known-good config. Defaults to 30 seconds.
+	ConfigApplyTimeout time.Duration `mapstructure:"config_apply_timeout"`
+}
+
+// Storage is the location of the state the Supervisor persists across restarts.
+type Storage struct {
+	// Directory is where the Supervisor stores its persistent state, the
+	// remote configs and the Agent's effective config. Defaults to the
+	// current working directory.
+	Directory string `mapstructure:"directory"`
+}
+
+// Packages is the configuration of the packages offered by the OpAMP server.
+type Packages struct {
+	// PublicKeyFile is the path of the PEM encoded Ed25519 public key
+	// the signatures of the packages are verified with.
+	PublicKeyFile string `mapstructure:"public_key_file"`
 }
@@ -0,0 +1,506 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// packagesDir is the directory of the storage directory holding the packages.
+	packagesDir = "packages"
+	// packagesStateFile holds the packagesState.
+	packagesStateFile = "packages.yaml"
+	// packageStatusesFile holds the package statuses last reported to the OpAMP server.
+	packageStatusesFile = "last_reported_package_statuses.dat"
+	// configPackagesDir is the directory of the packages directory holding the config packages.
+	configPackagesDir = "config"
+	// stagingDir is the directory of the packages directory packages are downloaded to.
+	stagingDir = "staging"
+
+	// previousSuffix is the suffix of the copy of a package kept while a new version is installed.
+	previousSuffix = ".prev"
+)
+
+var _ types.PackagesStateProvider = (*packageManager)(nil)
+
+// packagesState is the state of the packages persisted by the packageManager.
+type packagesState struct {
+	AllPackagesHash []byte                  `yaml:"all_packages_hash"`
+	Packages        map[string]packageState `yaml:"packages"`
+}
+
+type packageState struct {
+	Type    protobufs.PackageType `yaml:"type"`
+	Hash    []byte                `yaml:"hash"`
+	Version string                `yaml:"version"`
+}
+
+// packageManager installs the packages offered by the OpAMP server, it is used by the
+// packages syncer of the OpAMP client. The top-level package is the Agent executable,
+// addon packages are named config files merged into the Agent's effective config.
+//
+// Downloaded packages are staged, then verified against their SHA-256 content hash and
+// their signature, which is the Ed25519 signature of the content hash. A verified package
+// is swapped with the installed version, which is restored if the Agent is not healthy
+// with the new package.
+type packageManager struct {
+	logger          *zap.Logger
+	dir             string
+	agentExecutable string
+	publicKey       ed25519.PublicKey
+
+	// apply restarts the Agent with the installed packages and returns an
+	// error if the Agent is not healthy with them.
+	apply func(ctx context.Context) error
+	// applyTimeout is the time the Agent is given to report healthy after it is restarted.
+	applyTimeout time.Duration
+
+	mu        sync.Mutex
+	state     packagesState
+	available *protobufs.PackagesAvailable
+}
+
+func newPackageManager(logger *zap.Logger, dir, agentExecutable, publicKeyFile string, apply func(ctx context.Context) error, applyTimeout time.Duration) (*packageManager, error) {
+	publicKey, err := loadPublicKey(publicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range []string{dir, filepath.Join(dir, configPackagesDir), filepath.Join(dir, stagingDir)} {
+		if err = os.MkdirAll(d, 0700); err != nil {
+			return nil, err
+		}
+	}
+
+	m := &packageManager{
+		logger:          logger,
+		dir:             dir,
+		agentExecutable: agentExecutable,
+		publicKey:       publicKey,
+		apply:           apply,
+		applyTimeout:    applyTimeout,
+		state:           packagesState{Packages: map[string]packageState{}},
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, packagesStateFile))
+	switch {
+	case err == nil:
+		if err = yaml.Unmarshal(b, &m.state); err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", packagesStateFile, err)
+		}
+		if m.state.Packages == nil {
+			m.state.Packages = map[string]packageState{}
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// loadPublicKey loads a PEM encoded Ed25519 public key.
+func loadPublicKey(path string) (ed25519.PublicKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read packages public key: %w", err)
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse packages public key: %w", err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("packages public key must be an Ed25519 key, got %T", key)
+	}
+
+	return publicKey, nil
+}
+
+// SetAvailable sets the packages offered by the OpAMP server before they are synced,
+// their signatures are verified when they are installed.
+func (m *packageManager) SetAvailable(available *protobufs.PackagesAvailable) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.available = available
+}
+
+func (m *packageManager) AllPackagesHash() ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.AllPackagesHash, nil
+}
+
+func (m *packageManager) SetAllPackagesHash(hash []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state.AllPackagesHash = hash
+	return m.writeState()
+}
+
+func (m *packageManager) Packages() (map[string]types.PackageState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	packages := make(map[string]types.PackageState, len(m.state.Packages))
+	for name, state := range m.state.Packages {
+		packages[name] = state.toPackageState()
+	}
+	return packages, nil
+}
+
+func (m *packageManager) PackageState(packageName string) (types.PackageState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.state.Packages[packageName]
+	if !ok {
+		return types.PackageState{}, nil
+	}
+	return state.toPackageState(), nil
+}
+
+func (m *packageManager) SetPackageState(packageName string, state types.PackageState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !state.Exists {
+		delete(m.state.Packages, packageName)
+	} else {
+		m.state.Packages[packageName] = packageState{
+			Type:    state.Type,
+			Hash:    state.Hash,
+			Version: state.Version,
+		}
+	}
+	return m.writeState()
+}
+
+func (m *packageManager) CreatePackage(packageName string, typ protobufs.PackageType) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch typ {
+	case protobufs.PackageType_PackageType_TopLevel:
+		for name, state := range m.state.Packages {
+			if name != packageName && state.Type == protobufs.PackageType_PackageType_TopLevel {
+				return fmt.Errorf("cannot create top-level package %q, package %q is already the agent", packageName, name)
+			}
+		}
+	case protobufs.PackageType_PackageType_Addon:
+		if packageName == "" {
+			return errors.New("config package name cannot be empty")
+		}
+	default:
+		return fmt.Errorf("unsupported type %s for package %q", typ, packageName)
+	}
+
+	m.state.Packages[packageName] = packageState{Type: typ}
+	return m.writeState()
+}
+
+func (m *packageManager) FileContentHash(packageName string) ([]byte, error) {
+	path, err := m.packagePath(packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// UpdateContent stages and verifies the new content of a package, then swaps it with the
+// installed content and restarts the Agent. The installed content is restored if the Agent
+// is not healthy with the new content, the returned error is reported as the package status.
+func (m *packageManager) UpdateContent(ctx context.Context, packageName string, data io.Reader, contentHash []byte) error {
+	path, err := m.packagePath(packageName)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	signature := m.available.GetPackages()[packageName].GetFile().GetSignature()
+	executable := m.state.Packages[packageName].Type == protobufs.PackageType_PackageType_TopLevel
+	m.mu.Unlock()
+
+	staged, err := m.stage(packageName, data, contentHash, signature)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(staged)
+
+	if err = swapFile(staged, path, executable); err != nil {
+		return fmt.Errorf("cannot install package %q: %w", packageName, err)
+	}
+
+	if err = m.apply(ctx); err != nil {
+		m.logger.Error("Agent is not healthy with the new package, rolling back", zap.String("package", packageName), zap.Error(err))
+		if restoreErr := restoreFile(path); restoreErr != nil {
+			return fmt.Errorf("cannot restore package %q: %w", packageName, errors.Join(err, restoreErr))
+		}
+		if applyErr := m.apply(ctx); applyErr != nil {
+			m.logger.Error("Agent is not healthy with the restored package", zap.String("package", packageName), zap.Error(applyErr))
+		}
+		return fmt.Errorf("agent is not healthy with package %q: %w", packageName, err)
+	}
+
+	if err = os.Remove(path + previousSuffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+		m.logger.Warn("Could not remove previous version of package", zap.String("package", packageName), zap.Error(err))
+	}
+	return nil
+}
+
+// DeletePackage removes a package which is no longer offered by the OpAMP server. Config
+// packages are removed from the Agent's effective config, the Agent executable is kept.
+func (m *packageManager) DeletePackage(packageName string) error {
+	m.mu.Lock()
+	state, ok := m.state.Packages[packageName]
+	delete(m.state.Packages, packageName)
+	err := m.writeState()
+	m.mu.Unlock()
+	if err != nil || !ok || state.Type != protobufs.PackageType_PackageType_Addon {
+		return err
+	}
+
+	path, err := m.configPackagePath(packageName)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// The package syncer gives no context to delete a package, so the wait is bounded: the Agent
+	// may be applying a remote config before it is restarted without the package.
+	ctx, cancel := context.WithTimeout(context.Background(), 2*m.applyTimeout)
+	defer cancel()
+	return m.apply(ctx)
+}
+
+func (m *packageManager) LastReportedStatuses() (*protobufs.PackageStatuses, error) {
+	b, err := os.ReadFile(filepath.Join(m.dir, packageStatusesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return &protobufs.PackageStatuses{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := &protobufs.PackageStatuses{}
+	if err = proto.Unmarshal(b, statuses); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", packageStatusesFile, err)
+	}
+	return statuses, nil
+}
+
+func (m *packageManager) SetLastReportedStatuses(statuses *protobufs.PackageStatuses) error {
+	b, err := proto.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(m.dir, packageStatusesFile), b)
+}
+
+// configs returns the content of the installed config packages, in the order of their names.
+func (m *packageManager) configs() ([][]byte, error) {
+	m.mu.Lock()
+	var names []string
+	for name, state := range m.state.Packages {
+		if state.Type == protobufs.PackageType_PackageType_Addon {
+			names = append(names, name)
+		}
+	}
+	m.mu.Unlock()
+	sort.Strings(names)
+
+	var configs [][]byte
+	for _, name := range names {
+		path, err := m.configPackagePath(name)
+		if err != nil {
+			return nil, err
+		}
+		b, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			// The package is created but its content is not installed yet.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, b)
+	}
+	return configs, nil
+}
+
+// stage writes the content of a package to the staging directory and verifies it.
+func (m *packageManager) stage(packageName string, data io.Reader, contentHash, signature []byte) (string, error) {
+	f, err := os.CreateTemp(filepath.Join(m.dir, stagingDir), "package-*")
+	if err != nil {
+		return "", err
+	}
+	staged := f.Name()
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = m.verify(packageName, h.Sum(nil), contentHash, signature)
+	}
+	if err != nil {
+		_ = os.Remove(staged)
+		return "", err
+	}
+
+	return staged, nil
+}
+
+func (m *packageManager) verify(packageName string, sum, contentHash, signature []byte) error {
+	if !bytes.Equal(sum, contentHash) {
+		return fmt.Errorf("content hash of package %q does not match, expected %x, got %x", packageName, contentHash, sum)
+	}
+	if len(signature) == 0 {
+		return fmt.Errorf("package %q is not signed", packageName)
+	}
+	if !ed25519.Verify(m.publicKey, sum, signature) {
+		return fmt.Errorf("invalid signature for package %q", packageName)
+	}
+	return nil
+}
+
+// packagePath returns the path the content of a package is installed at.
+func (m *packageManager) packagePath(packageName string) (string, error) {
+	m.mu.Lock()
+	state, ok := m.state.Packages[packageName]
+	m.mu.Unlock()
+	if !ok {
+		return "", fmt.Errorf("package %q does not exist", packageName)
+	}
+
+	if state.Type == protobufs.PackageType_PackageType_TopLevel {
+		return m.agentExecutable, nil
+	}
+	return m.configPackagePath(packageName)
+}
+
+func (m *packageManager) configPackagePath(packageName string) (string, error) {
+	if packageName == "" {
+		return "", errors.New("config package name cannot be empty")
+	}
+	return filepath.Join(m.dir, configPackagesDir, url.PathEscape(packageName)+".yaml"), nil
+}
+
+// writeState must be called with the mutex held.
+func (m *packageManager) writeState() error {
+	b, err := yaml.Marshal(&m.state)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(m.dir, packagesStateFile), b)
+}
+
+func (s packageState) toPackageState() types.PackageState {
+	return types.PackageState{
+		Exists:  true,
+		Type:    s.Type,
+		Hash:    s.Hash,
+		Version: s.Version,
+	}
+}
+
+// swapFile atomically replaces the file at path with the staged file. A copy of the replaced
+// file is kept next to it until it is removed, or restored by restoreFile.
+func swapFile(staged, path string, executable bool) error {
+	previous := path + previousSuffix
+	if err := os.Remove(previous); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := copyFile(path, previous); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// The staged file may be on another file system, it is copied next to path
+	// so that renaming it is atomic.
+	next := path + ".new"
+	if err := copyFile(staged, next); err != nil {
+		return err
+	}
+	if executable {
+		if err := os.Chmod(next, 0755); err != nil { // #nosec G302
+			return err
+		}
+	}
+	return os.Rename(next, path)
+}
+
+// restoreFile restores the copy of the file at path kept by swapFile, the file is
+// removed if it did not exist before being swapped.
+func restoreFile(path string) error {
+	err := os.Rename(path+previousSuffix, path)
+	if errors.Is(err, os.ErrNotExist) {
+		return os.Remove(path)
+	}
+	return err
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
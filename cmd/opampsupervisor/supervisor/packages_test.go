@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testPackages struct {
+	dir        string
+	executable string
+	privateKey ed25519.PrivateKey
+	applyErrs  []error
+	applied    int
+}
+
+func newTestPackages(t *testing.T) *testPackages {
+	dir := t.TempDir()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key.pem"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	executable := filepath.Join(dir, "otelcol")
+	require.NoError(t, os.WriteFile(executable, []byte("v1"), 0600))
+
+	return &testPackages{dir: dir, executable: executable, privateKey: privateKey}
+}
+
+func (p *testPackages) manager(t *testing.T) *packageManager {
+	m, err := newPackageManager(zap.NewNop(), filepath.Join(p.dir, packagesDir), p.executable, filepath.Join(p.dir, "key.pem"), func(context.Context) error {
+		p.applied++
+		if len(p.applyErrs) == 0 {
+			return nil
+		}
+		err := p.applyErrs[0]
+		p.applyErrs = p.applyErrs[1:]
+		return err
+	}, time.Second)
+	require.NoError(t, err)
+	return m
+}
+
+// offer signs the content of a package and makes it available to the manager,
+// returning its content hash.
+func (p *testPackages) offer(m *packageManager, name string, typ protobufs.PackageType, content []byte) []byte {
+	sum := sha256.Sum256(content)
+	m.SetAvailable(&protobufs.PackagesAvailable{
+		Packages: map[string]*protobufs.PackageAvailable{
+			name: {
+				Type: typ,
+				File: &protobufs.DownloadableFile{
+					ContentHash: sum[:],
+					Signature:   ed25519.Sign(p.privateKey, sum[:]),
+				},
+			},
+		},
+	})
+	return sum[:]
+}
+
+func TestPackageManager_configPackage(t *testing.T) {
+	p := newTestPackages(t)
+	m := p.manager(t)
+
+	require.NoError(t, m.CreatePackage("exporters", protobufs.PackageType_PackageType_Addon))
+	hash, err := m.FileContentHash("exporters")
+	require.NoError(t, err)
+	assert.Nil(t, hash)
+
+	content := []byte("exporters:\n  debug: {}\n")
+	contentHash := p.offer(m, "exporters", protobufs.PackageType_PackageType_Addon, content)
+	require.NoError(t, m.UpdateContent(context.Background(), "exporters", bytes.NewReader(content), contentHash))
+	assert.Equal(t, 1, p.applied)
+
+	configs, err := m.configs()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{content}, configs)
+	hash, err = m.FileContentHash("exporters")
+	require.NoError(t, err)
+	assert.Equal(t, contentHash, hash)
+
+	require.NoError(t, m.SetPackageState("exporters", types.PackageState{
+		Exists:  true,
+		Type:    protobufs.PackageType_PackageType_Addon,
+		Hash:    []byte("hash"),
+		Version: "1.0.0",
+	}))
+	require.NoError(t, m.SetAllPackagesHash([]byte("all")))
+
+	// The state is persisted across restarts.
+	m = p.manager(t)
+	state, err := m.PackageState("exporters")
+	require.NoError(t, err)
+	assert.Equal(t, types.PackageState{Exists: true, Type: protobufs.PackageType_PackageType_Addon, Hash: []byte("hash"), Version: "1.0.0"}, state)
+	allHash, err := m.AllPackagesHash()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("all"), allHash)
+
+	require.NoError(t, m.DeletePackage("exporters"))
+	assert.Equal(t, 2, p.applied)
+	configs, err = m.configs()
+	require.NoError(t, err)
+	assert.Empty(t, configs)
+	packages, err := m.Packages()
+	require.NoError(t, err)
+	assert.Empty(t, packages)
+}
+
+func TestPackageManager_deleteTimeout(t *testing.T) {
+	p := newTestPackages(t)
+	// The Agent process goroutine never picks up the installed packages.
+	m, err := newPackageManager(zap.NewNop(), filepath.Join(p.dir, packagesDir), p.executable, filepath.Join(p.dir, "key.pem"), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, 50*time.Millisecond)
+	require.NoError(t, err)
+	require.NoError(t, m.CreatePackage("exporters", protobufs.PackageType_PackageType_Addon))
+
+	assert.ErrorIs(t, m.DeletePackage("exporters"), context.DeadlineExceeded)
+}
+
+func TestPackageManager_agentPackage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not supported on Windows")
+	}
+	p := newTestPackages(t)
+	m := p.manager(t)
+
+	require.NoError(t, m.CreatePackage("", protobufs.PackageType_PackageType_TopLevel))
+	assert.EqualError(t, m.CreatePackage("other", protobufs.PackageType_PackageType_TopLevel),
+		`cannot create top-level package "other", package "" is already the agent`)
+
+	content := []byte("v2")
+	contentHash := p.offer(m, "", protobufs.PackageType_PackageType_TopLevel, content)
+	require.NoError(t, m.UpdateContent(context.Background(), "", bytes.NewReader(content), contentHash))
+
+	b, err := os.ReadFile(p.executable)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(b))
+	info, err := os.Stat(p.executable)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	_, err = os.Stat(p.executable + previousSuffix)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// The agent executable is kept when its package is deleted.
+	require.NoError(t, m.DeletePackage(""))
+	_, err = os.Stat(p.executable)
+	assert.NoError(t, err)
+}
+
+func TestPackageManager_rollback(t *testing.T) {
+	p := newTestPackages(t)
+	m := p.manager(t)
+	require.NoError(t, m.CreatePackage("", protobufs.PackageType_PackageType_TopLevel))
+
+	p.applyErrs = []error{errors.New("agent is not healthy")}
+	content := []byte("v2")
+	contentHash := p.offer(m, "", protobufs.PackageType_PackageType_TopLevel, content)
+	err := m.UpdateContent(context.Background(), "", bytes.NewReader(content), contentHash)
+	assert.EqualError(t, err, `agent is not healthy with package "": agent is not healthy`)
+	assert.Equal(t, 2, p.applied)
+
+	b, err := os.ReadFile(p.executable)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(b))
+}
+
+func TestPackageManager_verify(t *testing.T) {
+	p := newTestPackages(t)
+	m := p.manager(t)
+	require.NoError(t, m.CreatePackage("exporters", protobufs.PackageType_PackageType_Addon))
+	content := []byte("exporters: {}")
+
+	contentHash := p.offer(m, "exporters", protobufs.PackageType_PackageType_Addon, content)
+	err := m.UpdateContent(context.Background(), "exporters", bytes.NewReader([]byte("tampered")), contentHash)
+	assert.ErrorContains(t, err, `content hash of package "exporters" does not match`)
+
+	m.SetAvailable(&protobufs.PackagesAvailable{
+		Packages: map[string]*protobufs.PackageAvailable{
+			"exporters": {File: &protobufs.DownloadableFile{Signature: []byte("invalid")}},
+		},
+	})
+	err = m.UpdateContent(context.Background(), "exporters", bytes.NewReader(content), contentHash)
+	assert.EqualError(t, err, `invalid signature for package "exporters"`)
+
+	m.SetAvailable(nil)
+	err = m.UpdateContent(context.Background(), "exporters", bytes.NewReader(content), contentHash)
+	assert.EqualError(t, err, `package "exporters" is not signed`)
+
+	assert.Equal(t, 0, p.applied)
+	configs, err := m.configs()
+	require.NoError(t, err)
+	assert.Empty(t, configs)
+	staged, err := os.ReadDir(filepath.Join(p.dir, packagesDir, stagingDir))
+	require.NoError(t, err)
+	assert.Empty(t, staged)
+}
+
+func TestPackageManager_lastReportedStatuses(t *testing.T) {
+	p := newTestPackages(t)
+	m := p.manager(t)
+
+	statuses, err := m.LastReportedStatuses()
+	require.NoError(t, err)
+	assert.Empty(t, statuses.Packages)
+
+	require.NoError(t, m.SetLastReportedStatuses(&protobufs.PackageStatuses{
+		Packages: map[string]*protobufs.PackageStatus{
+			"exporters": {Name: "exporters", Status: protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed},
+		},
+	}))
+	statuses, err = m.LastReportedStatuses()
+	require.NoError(t, err)
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, statuses.Packages["exporters"].Status)
+}
+
+func TestNewPackageManager_invalidKey(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, []byte("not a key"), 0600))
+
+	_, err := newPackageManager(zap.NewNop(), dir, "otelcol", keyFile, nil, time.Second)
+	assert.EqualError(t, err, "no PEM data found in "+keyFile)
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// persistentStateFile holds the persistentState of the Supervisor.
+	persistentStateFile = "persistent_state.yaml"
+	// remoteConfigFile holds the last remote config received from the OpAMP server,
+	// which is the config the Agent is started with.
+	remoteConfigFile = "last_recv_remote_config.dat"
+	// lastGoodRemoteConfigFile holds the last remote config the Agent was healthy with,
+	// which the Supervisor rolls back to when the Agent is unhealthy with a new config.
+	lastGoodRemoteConfigFile = "last_good_remote_config.dat"
+	// effectiveConfigFile holds the effective config the Agent is started with.
+	effectiveConfigFile = "effective.yaml"
+)
+
+// persistentState is the state of the Supervisor kept across restarts.
+type persistentState struct {
+	// InstanceID is the instance ID of the Agent, reported to the OpAMP server.
+	InstanceID ulid.ULID `yaml:"instance_id"`
+
+	path string
+}
+
+// loadOrCreatePersistentState loads the state stored in the file at path, or creates
+// a new state with a random instance ID and stores it if the file does not exist.
+func loadOrCreatePersistentState(path string) (*persistentState, error) {
+	state := &persistentState{path: path}
+
+	b, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err = yaml.Unmarshal(b, state); err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", path, err)
+		}
+		return state, nil
+	case errors.Is(err, os.ErrNotExist):
+		if state.InstanceID, err = createInstanceID(); err != nil {
+			return nil, err
+		}
+		return state, state.write()
+	default:
+		return nil, err
+	}
+}
+
+// SetInstanceID sets and stores the instance ID of the Agent.
+func (p *persistentState) SetInstanceID(id ulid.ULID) error {
+	p.InstanceID = id
+	return p.write()
+}
+
+func (p *persistentState) write() error {
+	b, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(p.path, b)
+}
+
+// loadRemoteConfig loads the remote config stored in the file at path,
+// nil is returned if the file does not exist.
+func loadRemoteConfig(path string) (*protobufs.AgentRemoteConfig, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &protobufs.AgentRemoteConfig{}
+	if err = proto.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// saveRemoteConfig stores the remote config in the file at path,
+// the file is removed if the remote config is nil.
+func saveRemoteConfig(path string, cfg *protobufs.AgentRemoteConfig) error {
+	if cfg == nil {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	b, err := proto.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, b)
+}
+
+// writeFileAtomic writes data to a temporary file renamed to path, so that
+// the file at path is never partially written if the Supervisor stops.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+	return err
+}
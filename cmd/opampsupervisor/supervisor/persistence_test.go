@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLoadOrCreatePersistentState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), persistentStateFile)
+
+	state, err := loadOrCreatePersistentState(path)
+	require.NoError(t, err)
+	assert.NotEqual(t, ulid.ULID{}, state.InstanceID)
+
+	loaded, err := loadOrCreatePersistentState(path)
+	require.NoError(t, err)
+	assert.Equal(t, state.InstanceID, loaded.InstanceID)
+
+	newID := ulid.MustParse("01BX5ZZKBKACTAV9WEVGEMMVRZ")
+	require.NoError(t, loaded.SetInstanceID(newID))
+	loaded, err = loadOrCreatePersistentState(path)
+	require.NoError(t, err)
+	assert.Equal(t, newID, loaded.InstanceID)
+}
+
+func TestLoadOrCreatePersistentState_invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), persistentStateFile)
+	require.NoError(t, os.WriteFile(path, []byte("instance_id: not-a-ulid"), 0600))
+
+	_, err := loadOrCreatePersistentState(path)
+	assert.ErrorContains(t, err, "cannot parse")
+}
+
+func TestSaveRemoteConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), remoteConfigFile)
+
+	cfg, err := loadRemoteConfig(path)
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	remoteConfig := &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {Body: []byte("receivers: {}")},
+			},
+		},
+		ConfigHash: []byte("hash"),
+	}
+	require.NoError(t, saveRemoteConfig(path, remoteConfig))
+	cfg, err = loadRemoteConfig(path)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(remoteConfig, cfg))
+
+	require.NoError(t, saveRemoteConfig(path, nil))
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	require.NoError(t, saveRemoteConfig(path, nil))
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, effectiveConfigFile)
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0600))
+
+	require.NoError(t, writeFileAtomic(path, []byte("new")))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(b))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
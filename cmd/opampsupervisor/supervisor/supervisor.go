@@ -4,14 +4,17 @@
 package supervisor
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
-	"math/rand"
 	"net"
 	"os"
+	"path/filepath"
 	"runtime"
 	"sort"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -34,25 +37,48 @@ import (
 // This Supervisor is developed specifically for the OpenTelemetry Collector.
 const agentType = "io.opentelemetry.collector"
 
+const (
+	defaultConfigApplyTimeout = 30 * time.Second
+
+	// configApplyCheckInterval is the interval of the health checks of the Agent
+	// while waiting for it to be healthy with a new config.
+	configApplyCheckInterval = time.Second
+)
+
+// agentProcess starts and stops the Agent process, see commander.Commander.
+type agentProcess interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+	Done() <-chan struct{}
+	Pid() int
+	ExitCode() int
+	IsRunning() bool
+}
+
+// agentHealthChecker checks the health of the Agent, see healthchecker.HTTPHealthChecker.
+type agentHealthChecker interface {
+	Check(ctx context.Context) error
+}
+
 // Supervisor implements supervising of OpenTelemetry Collector and uses OpAMPClient
 // to work with an OpAMP Server.
 type Supervisor struct {
 	logger *zap.Logger
 
 	// Commander that starts/stops the Agent process.
-	commander *commander.Commander
+	commander agentProcess
 
 	startedAt time.Time
 
 	healthCheckTicker  *backoff.Ticker
-	healthChecker      *healthchecker.HTTPHealthChecker
+	healthChecker      agentHealthChecker
 	lastHealthCheckErr error
 
 	// Supervisor's own config.
 	config config.Supervisor
 
-	// Agent's instance id.
-	instanceID ulid.ULID
+	// State persisted across restarts of the Supervisor, including the Agent's instance id.
+	persistentState *persistentState
 
 	// The version of the agent.
 	agentVersion string
@@ -73,17 +99,37 @@ type Supervisor struct {
 	// Location of the effective config file.
 	effectiveConfigFilePath string
 
+	// Guards remoteConfig, which is set when receiving a message from the OpAMP
+	// Server and when rolling back to lastGoodRemoteConfig.
+	remoteConfigMu sync.Mutex
+
 	// Last received remote config.
 	remoteConfig *protobufs.AgentRemoteConfig
 
+	// Last remote config the Agent was healthy with, restored if the Agent is not
+	// healthy with a new config.
+	lastGoodRemoteConfig *protobufs.AgentRemoteConfig
+
 	// A channel to indicate there is a new config to apply.
 	hasNewConfig chan struct{}
 
+	// Installs the packages offered by the OpAMP Server, nil unless the
+	// AcceptsPackages capability is enabled.
+	packageManager *packageManager
+
+	// A channel to restart the Agent once a package is installed, the Agent
+	// process goroutine sends back whether the Agent is healthy with it.
+	packagesInstalled chan chan error
+
 	// The OpAMP client to connect to the OpAMP Server.
 	opampClient client.OpAMPClient
 
 	shuttingDown bool
 
+	// agentStopped is set when the Agent is stopped because no config could be applied,
+	// so that it is not restarted until a new config is received.
+	agentStopped bool
+
 	agentHasStarted               bool
 	agentStartHealthCheckAttempts int
 }
@@ -92,7 +138,7 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 	s := &Supervisor{
 		logger:                       logger,
 		hasNewConfig:                 make(chan struct{}, 1),
-		effectiveConfigFilePath:      "effective.yaml",
+		packagesInstalled:            make(chan chan error),
 		agentConfigOwnMetricsSection: &atomic.Value{},
 		effectiveConfig:              &atomic.Value{},
 	}
@@ -112,17 +158,28 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 	}
 
 	s.agentHealthCheckEndpoint = fmt.Sprintf("localhost:%d", port)
+	s.healthChecker = healthchecker.NewHTTPHealthChecker(fmt.Sprintf("http://%s", s.agentHealthCheckEndpoint))
 
-	id, err := s.createInstanceID()
-
-	if err != nil {
-		return nil, err
+	if err = s.loadPersistentState(); err != nil {
+		return nil, fmt.Errorf("error loading persistent state: %w", err)
 	}
 
-	s.instanceID = id
+	if c := s.config.Capabilities; c != nil && c.AcceptsPackages != nil && *c.AcceptsPackages {
+		s.packageManager, err = newPackageManager(
+			s.logger,
+			filepath.Join(s.config.Storage.Directory, packagesDir),
+			s.config.Agent.Executable,
+			s.config.Packages.PublicKeyFile,
+			s.applyPackages,
+			s.config.Agent.ConfigApplyTimeout,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error loading packages: %w", err)
+		}
+	}
 
 	logger.Debug("Supervisor starting",
-		zap.String("id", s.instanceID.String()), zap.String("type", agentType), zap.String("version", s.agentVersion))
+		zap.String("id", s.persistentState.InstanceID.String()), zap.String("type", agentType), zap.String("version", s.agentVersion))
 
 	s.loadAgentEffectiveConfig()
 
@@ -163,9 +220,50 @@ func (s *Supervisor) loadConfig(configFile string) error {
 		return fmt.Errorf("cannot parse %v: %w", configFile, err)
 	}
 
+	if s.config.Agent == nil {
+		s.config.Agent = &config.Agent{}
+	}
+	if s.config.Agent.ConfigApplyTimeout <= 0 {
+		s.config.Agent.ConfigApplyTimeout = defaultConfigApplyTimeout
+	}
+
+	if s.config.Storage == nil {
+		s.config.Storage = &config.Storage{}
+	}
+
+	if c := s.config.Capabilities; c != nil && c.AcceptsPackages != nil && *c.AcceptsPackages &&
+		(s.config.Packages == nil || s.config.Packages.PublicKeyFile == "") {
+		return errors.New("packages::public_key_file must be specified to accept packages")
+	}
+	if s.config.Storage.Directory == "" {
+		s.config.Storage.Directory = "."
+	}
+
 	return nil
 }
 
+// loadPersistentState loads the state of the Supervisor from its storage directory,
+// creating the directory and a new instance id if they do not exist yet.
+func (s *Supervisor) loadPersistentState() error {
+	dir := s.config.Storage.Directory
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	s.effectiveConfigFilePath = filepath.Join(dir, effectiveConfigFile)
+
+	var err error
+	if s.persistentState, err = loadOrCreatePersistentState(filepath.Join(dir, persistentStateFile)); err != nil {
+		return err
+	}
+
+	if s.remoteConfig, err = loadRemoteConfig(filepath.Join(dir, remoteConfigFile)); err != nil {
+		return err
+	}
+
+	s.lastGoodRemoteConfig, err = loadRemoteConfig(filepath.Join(dir, lastGoodRemoteConfigFile))
+	return err
+}
+
 // TODO: Implement bootstrapping https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/21071
 // nolint: unparam
 func (s *Supervisor) getBootstrapInfo() (err error) {
@@ -199,6 +297,14 @@ func (s *Supervisor) Capabilities() protobufs.AgentCapabilities {
 		if c.ReportsRemoteConfig != nil && *c.ReportsRemoteConfig {
 			supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig
 		}
+
+		if c.AcceptsPackages != nil && *c.AcceptsPackages {
+			supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages
+		}
+
+		if c.ReportsPackageStatuses != nil && *c.ReportsPackageStatuses {
+			supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses
+		}
 	}
 	return supportedCapabilities
 }
@@ -214,7 +320,7 @@ func (s *Supervisor) startOpAMP() error {
 	settings := types.StartSettings{
 		OpAMPServerURL: s.config.Server.Endpoint,
 		TLSConfig:      tlsConfig,
-		InstanceUid:    s.instanceID.String(),
+		InstanceUid:    s.persistentState.InstanceID.String(),
 		Callbacks: types.CallbacksStruct{
 			OnConnectFunc: func() {
 				s.logger.Debug("Connected to the server.")
@@ -252,6 +358,9 @@ func (s *Supervisor) startOpAMP() error {
 		},
 		Capabilities: s.Capabilities(),
 	}
+	if s.packageManager != nil {
+		settings.PackagesStateProvider = s.packageManager
+	}
 	err = s.opampClient.SetAgentDescription(s.createAgentDescription())
 	if err != nil {
 		return err
@@ -274,10 +383,8 @@ func (s *Supervisor) startOpAMP() error {
 	return nil
 }
 
-// TODO: Persist instance ID. https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/21073
-func (s *Supervisor) createInstanceID() (ulid.ULID, error) {
-	entropy := ulid.Monotonic(rand.New(rand.NewSource(0)), 0)
-	id, err := ulid.New(ulid.Timestamp(time.Now()), entropy)
+func createInstanceID() (ulid.ULID, error) {
+	id, err := ulid.New(ulid.Timestamp(time.Now()), rand.Reader)
 
 	if err != nil {
 		return ulid.ULID{}, err
@@ -303,7 +410,7 @@ func (s *Supervisor) createAgentDescription() *protobufs.AgentDescription {
 		IdentifyingAttributes: []*protobufs.KeyValue{
 			keyVal("service.name", agentType),
 			keyVal("service.version", s.agentVersion),
-			keyVal("service.instance.id", s.instanceID.String()),
+			keyVal("service.instance.id", s.persistentState.InstanceID.String()),
 		},
 		NonIdentifyingAttributes: []*protobufs.KeyValue{
 			keyVal("os.family", runtime.GOOS),
@@ -335,24 +442,21 @@ extensions:
 `,
 		agentType,
 		s.agentVersion,
-		s.instanceID.String(),
+		s.persistentState.InstanceID.String(),
 		s.agentHealthCheckEndpoint,
 	)
 }
 
 func (s *Supervisor) loadAgentEffectiveConfig() {
-	var effectiveConfigBytes []byte
+	// Begin with the initial config, then compose the effective config from the
+	// persisted remote config, if any. The Agent is started with it once healthy.
+	s.effectiveConfig.Store(s.composeExtraLocalConfig())
 
-	effFromFile, err := os.ReadFile(s.effectiveConfigFilePath)
-	if err == nil {
-		// We have an effective config file.
-		effectiveConfigBytes = effFromFile
-	} else {
-		// No effective config file, just use the initial config.
-		effectiveConfigBytes = []byte(s.composeExtraLocalConfig())
+	if s.remoteConfig != nil {
+		if _, err := s.composeEffectiveConfig(s.remoteConfig); err != nil {
+			s.logger.Error("Error composing effective config from persisted remote config", zap.Error(err))
+		}
 	}
-
-	s.effectiveConfig.Store(string(effectiveConfigBytes))
 }
 
 // createEffectiveConfigMsg create an EffectiveConfig with the content of the
@@ -445,7 +549,11 @@ func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig)
 
 	// Sort to make sure the order of merging is stable.
 	var names []string
-	for name := range config.Config.ConfigMap {
+	var configMap map[string]*protobufs.AgentConfigFile
+	if config != nil {
+		configMap = config.GetConfig().GetConfigMap()
+	}
+	for name := range configMap {
 		if name == "" {
 			// skip instance config
 			continue
@@ -455,12 +563,12 @@ func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig)
 
 	sort.Strings(names)
 
-	// Append instance config as the last item.
-	names = append(names, "")
-
-	// Merge received configs.
+	// Merge received configs, appending instance config as the last item.
+	if _, ok := configMap[""]; ok {
+		names = append(names, "")
+	}
 	for _, name := range names {
-		item := config.Config.ConfigMap[name]
+		item := configMap[name]
 		var k2 = koanf.New(".")
 		err = k2.Load(rawbytes.Provider(item.Body), yaml.Parser())
 		if err != nil {
@@ -472,6 +580,19 @@ func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig)
 		}
 	}
 
+	// Merge config packages.
+	if s.packageManager != nil {
+		packageConfigs, err := s.packageManager.configs()
+		if err != nil {
+			return false, fmt.Errorf("cannot read config packages: %w", err)
+		}
+		for _, packageConfig := range packageConfigs {
+			if err = k.Load(rawbytes.Provider(packageConfig), yaml.Parser()); err != nil {
+				return false, fmt.Errorf("cannot parse config package: %w", err)
+			}
+		}
+	}
+
 	// Merge own metrics config.
 	ownMetricsCfg, ok := s.agentConfigOwnMetricsSection.Load().(string)
 	if ok {
@@ -506,6 +627,9 @@ func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig)
 // Recalculate the Agent's effective config and if the config changes, signal to the
 // background goroutine that the config needs to be applied to the Agent.
 func (s *Supervisor) recalcEffectiveConfig() (configChanged bool, err error) {
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+
 	configChanged, err = s.composeEffectiveConfig(s.remoteConfig)
 	if err != nil {
 		s.logger.Error("Error composing effective config. Ignoring received config", zap.Error(err))
@@ -515,25 +639,63 @@ func (s *Supervisor) recalcEffectiveConfig() (configChanged bool, err error) {
 	return configChanged, nil
 }
 
-func (s *Supervisor) startAgent() {
+// setRemoteConfig composes the Agent's effective config with a remote config received
+// from the OpAMP Server and persists it. The previous remote config is kept if the
+// effective config cannot be composed.
+func (s *Supervisor) setRemoteConfig(cfg *protobufs.AgentRemoteConfig) (configChanged bool, err error) {
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+
+	configChanged, err = s.composeEffectiveConfig(cfg)
+	if err != nil {
+		s.logger.Error("Error composing effective config. Ignoring received config", zap.Error(err))
+		return false, err
+	}
+
+	s.remoteConfig = cfg
+	if err = saveRemoteConfig(filepath.Join(s.config.Storage.Directory, remoteConfigFile), cfg); err != nil {
+		s.logger.Error("Could not persist remote config", zap.Error(err))
+	}
+
+	return configChanged, nil
+}
+
+func (s *Supervisor) reportRemoteConfigStatus(cfg *protobufs.AgentRemoteConfig, status protobufs.RemoteConfigStatuses, err error) {
+	if cfg == nil {
+		return
+	}
+
+	remoteConfigStatus := &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: cfg.ConfigHash,
+		Status:               status,
+	}
+	if err != nil {
+		remoteConfigStatus.ErrorMessage = err.Error()
+	}
+
+	if err = s.opampClient.SetRemoteConfigStatus(remoteConfigStatus); err != nil {
+		s.logger.Error("Could not report OpAMP remote config status", zap.String("status", status.String()), zap.Error(err))
+	}
+}
+
+func (s *Supervisor) startAgent() error {
 	err := s.commander.Start(context.Background())
 	if err != nil {
 		s.logger.Error("Cannot start the agent", zap.Error(err))
-		err = s.opampClient.SetHealth(&protobufs.AgentHealth{Healthy: false, LastError: fmt.Sprintf("Cannot start the agent: %v", err)})
-
-		if err != nil {
-			s.logger.Error("Failed to report OpAMP client health", zap.Error(err))
+		if err2 := s.opampClient.SetHealth(&protobufs.AgentHealth{Healthy: false, LastError: fmt.Sprintf("Cannot start the agent: %v", err)}); err2 != nil {
+			s.logger.Error("Failed to report OpAMP client health", zap.Error(err2))
 		}
 
-		return
+		return fmt.Errorf("cannot start the agent: %w", err)
 	}
 
+	s.agentStopped = false
 	s.agentHasStarted = false
 	s.agentStartHealthCheckAttempts = 0
 	s.startedAt = time.Now()
 	s.startHealthCheckTicker()
 
-	s.healthChecker = healthchecker.NewHTTPHealthChecker(fmt.Sprintf("http://%s", s.agentHealthCheckEndpoint))
+	return nil
 }
 
 func (s *Supervisor) startHealthCheckTicker() {
@@ -592,23 +754,32 @@ func (s *Supervisor) healthCheck() {
 }
 
 func (s *Supervisor) runAgentProcess() {
-	if _, err := os.Stat(s.effectiveConfigFilePath); err == nil {
-		// We have an effective config file saved previously. Use it to start the agent.
-		s.startAgent()
+	if s.remoteConfig != nil {
+		// We have a remote config persisted previously. Use it to start the agent.
+		s.applyConfig()
 	}
 
 	restartTimer := time.NewTimer(0)
 	restartTimer.Stop()
 
+	// The Done channel of the last Agent process whose exit was handled, so that
+	// it is not handled again while the Agent is not restarted.
+	var exitedDone <-chan struct{}
+
 	for {
+		agentDone := s.commander.Done()
+		if agentDone == exitedDone {
+			agentDone = nil
+		}
+
 		select {
 		case <-s.hasNewConfig:
 			restartTimer.Stop()
-			s.stopAgentApplyConfig()
-			s.startAgent()
+			s.applyConfig()
 
-		case <-s.commander.Done():
-			if s.shuttingDown {
+		case <-agentDone:
+			exitedDone = agentDone
+			if s.shuttingDown || s.agentStopped {
 				break
 			}
 
@@ -631,7 +802,11 @@ func (s *Supervisor) runAgentProcess() {
 			restartTimer.Reset(5 * time.Second)
 
 		case <-restartTimer.C:
-			s.startAgent()
+			_ = s.startAgent()
+
+		case installed := <-s.packagesInstalled:
+			restartTimer.Stop()
+			installed <- s.restartAgentWithPackages()
 
 		case <-s.healthCheckTicker.C:
 			s.healthCheck()
@@ -652,17 +827,139 @@ func (s *Supervisor) stopAgentApplyConfig() {
 }
 
 func (s *Supervisor) writeEffectiveConfigToFile(cfg string, filePath string) {
-	f, err := os.Create(filePath)
-	if err != nil {
-		s.logger.Error("Cannot create effective config file", zap.Error(err))
+	if err := writeFileAtomic(filePath, []byte(cfg)); err != nil {
+		s.logger.Error("Cannot write effective config file", zap.Error(err))
+	}
+}
+
+// applyConfig restarts the Agent with its effective config and waits for the Agent to
+// be healthy. The remote config is then kept as the last known-good config, otherwise
+// it is reported as failed and the Agent is rolled back to the last known-good config.
+func (s *Supervisor) applyConfig() {
+	s.remoteConfigMu.Lock()
+	remoteConfig := s.remoteConfig
+	s.remoteConfigMu.Unlock()
+
+	s.stopAgentApplyConfig()
+	err := s.startAgent()
+	if err == nil {
+		err = s.waitAgentHealthy(s.config.Agent.ConfigApplyTimeout)
+	}
+
+	if err == nil {
+		if remoteConfig != nil {
+			s.lastGoodRemoteConfig = remoteConfig
+			if err = saveRemoteConfig(filepath.Join(s.config.Storage.Directory, lastGoodRemoteConfigFile), remoteConfig); err != nil {
+				s.logger.Error("Could not persist last known-good remote config", zap.Error(err))
+			}
+			s.reportRemoteConfigStatus(remoteConfig, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, nil)
+		}
+		return
+	}
+
+	if remoteConfig == nil || (s.lastGoodRemoteConfig != nil && bytes.Equal(remoteConfig.ConfigHash, s.lastGoodRemoteConfig.ConfigHash)) {
+		// There is no other config to roll back to, the Agent is restarted as usual.
+		s.logger.Error("Agent is not healthy with the last known-good config", zap.Error(err))
+		return
+	}
+
+	s.logger.Error("Agent is not healthy with the new config, rolling back to the last known-good config", zap.Error(err))
+	s.reportRemoteConfigStatus(remoteConfig, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, err)
+	s.rollbackConfig(remoteConfig)
+}
+
+// applyPackages is called by the package manager once a package is installed, the Agent
+// is restarted with it by the Agent process goroutine. An error is returned if the Agent
+// is not healthy with the package.
+func (s *Supervisor) applyPackages(ctx context.Context) error {
+	installed := make(chan error, 1)
+	select {
+	case s.packagesInstalled <- installed:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-installed:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// restartAgentWithPackages restarts the Agent with the installed packages, with the
+// effective config composed with the config packages, and waits for it to be healthy.
+func (s *Supervisor) restartAgentWithPackages() error {
+	if _, err := s.recalcEffectiveConfig(); err != nil {
+		return err
+	}
+
+	s.stopAgentApplyConfig()
+	if err := s.startAgent(); err != nil {
+		return err
 	}
-	defer f.Close()
+	return s.waitAgentHealthy(s.config.Agent.ConfigApplyTimeout)
+}
 
-	_, err = f.WriteString(cfg)
+// waitAgentHealthy checks the health of the Agent until it is healthy. An error is returned
+// if the Agent is still not healthy once the timeout is elapsed or if the Agent exits.
+func (s *Supervisor) waitAgentHealthy(timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(configApplyCheckInterval)
+	defer ticker.Stop()
 
+	err := errors.New("agent has not reported its health")
+	for {
+		select {
+		case <-timer.C:
+			return fmt.Errorf("agent is not healthy after %v: %w", timeout, err)
+		case <-s.commander.Done():
+			return fmt.Errorf("agent process exited with exit code %d", s.commander.ExitCode())
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), configApplyCheckInterval)
+			err = s.healthChecker.Check(ctx)
+			cancel()
+			if err == nil {
+				return nil
+			}
+		}
+	}
+}
+
+// rollbackConfig restores the last known-good remote config in place of the failed one and
+// restarts the Agent with it. The Agent is stopped if there is no known-good config yet.
+// Nothing is rolled back if a newer remote config was received while the failed one was
+// applied, the newer one is applied next instead.
+func (s *Supervisor) rollbackConfig(failed *protobufs.AgentRemoteConfig) {
+	s.remoteConfigMu.Lock()
+	if !bytes.Equal(s.remoteConfig.GetConfigHash(), failed.GetConfigHash()) {
+		s.remoteConfigMu.Unlock()
+		s.logger.Debug("A newer remote config was received, not rolling back")
+		return
+	}
+	s.remoteConfig = s.lastGoodRemoteConfig
+	_, err := s.composeEffectiveConfig(s.remoteConfig)
+	s.remoteConfigMu.Unlock()
 	if err != nil {
-		s.logger.Error("Cannot write effective config file", zap.Error(err))
+		s.logger.Error("Error composing effective config from the last known-good config", zap.Error(err))
 	}
+
+	if err = saveRemoteConfig(filepath.Join(s.config.Storage.Directory, remoteConfigFile), s.lastGoodRemoteConfig); err != nil {
+		s.logger.Error("Could not persist remote config", zap.Error(err))
+	}
+
+	if s.lastGoodRemoteConfig == nil {
+		s.logger.Debug("No known-good config to roll back to, stopping the agent")
+		s.agentStopped = true
+		if err = s.commander.Stop(context.Background()); err != nil {
+			s.logger.Error("Could not stop agent process", zap.Error(err))
+		}
+		return
+	}
+
+	s.stopAgentApplyConfig()
+	_ = s.startAgent()
 }
 
 func (s *Supervisor) Shutdown() {
@@ -698,28 +995,18 @@ func (s *Supervisor) Shutdown() {
 func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 	configChanged := false
 	if msg.RemoteConfig != nil {
-		s.remoteConfig = msg.RemoteConfig
-		s.logger.Debug("Received remote config from server", zap.String("hash", fmt.Sprintf("%x", s.remoteConfig.ConfigHash)))
+		s.logger.Debug("Received remote config from server", zap.String("hash", fmt.Sprintf("%x", msg.RemoteConfig.ConfigHash)))
 
 		var err error
-		configChanged, err = s.recalcEffectiveConfig()
-		if err != nil {
-			err = s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
-				LastRemoteConfigHash: msg.RemoteConfig.ConfigHash,
-				Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
-				ErrorMessage:         err.Error(),
-			})
-			if err != nil {
-				s.logger.Error("Could not report failed OpAMP remote config status", zap.Error(err))
-			}
-		} else {
-			err = s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
-				LastRemoteConfigHash: msg.RemoteConfig.ConfigHash,
-				Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
-			})
-			if err != nil {
-				s.logger.Error("Could not report applied OpAMP remote config status", zap.Error(err))
-			}
+		configChanged, err = s.setRemoteConfig(msg.RemoteConfig)
+		switch {
+		case err != nil:
+			s.reportRemoteConfigStatus(msg.RemoteConfig, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, err)
+		case configChanged:
+			// The config is reported as applied once the Agent is healthy with it.
+			s.reportRemoteConfigStatus(msg.RemoteConfig, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, nil)
+		default:
+			s.reportRemoteConfigStatus(msg.RemoteConfig, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, nil)
 		}
 	}
 
@@ -727,6 +1014,14 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 		configChanged = s.setupOwnMetrics(ctx, msg.OwnMetricsConnSettings) || configChanged
 	}
 
+	if msg.PackagesAvailable != nil && msg.PackageSyncer != nil && s.packageManager != nil {
+		s.logger.Debug("Received packages from server", zap.Int("count", len(msg.PackagesAvailable.Packages)))
+		s.packageManager.SetAvailable(msg.PackagesAvailable)
+		if err := msg.PackageSyncer.Sync(ctx); err != nil {
+			s.logger.Error("Could not sync packages", zap.Error(err))
+		}
+	}
+
 	if msg.AgentIdentification != nil {
 		newInstanceID, err := ulid.Parse(msg.AgentIdentification.NewInstanceUid)
 		if err != nil {
@@ -734,9 +1029,11 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 		}
 
 		s.logger.Debug("Agent identity is changing",
-			zap.String("old_id", s.instanceID.String()),
+			zap.String("old_id", s.persistentState.InstanceID.String()),
 			zap.String("new_id", newInstanceID.String()))
-		s.instanceID = newInstanceID
+		if err = s.persistentState.SetInstanceID(newInstanceID); err != nil {
+			s.logger.Error("Could not persist instance ID", zap.Error(err))
+		}
 		err = s.opampClient.SetAgentDescription(s.createAgentDescription())
 		if err != nil {
 			s.logger.Error("Failed to send agent description to OpAMP server")
@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/open-telemetry/opamp-go/client"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+// fakeAgentProcess stands in for the Agent process started by the commander.
+type fakeAgentProcess struct {
+	running bool
+	done    chan struct{}
+	starts  int
+	// exitOnStart makes the Agent exit right after it is started.
+	exitOnStart bool
+}
+
+func (p *fakeAgentProcess) Start(context.Context) error {
+	p.starts++
+	p.done = make(chan struct{})
+	p.running = true
+	if p.exitOnStart {
+		p.running = false
+		close(p.done)
+	}
+	return nil
+}
+
+func (p *fakeAgentProcess) Stop(context.Context) error {
+	if p.running {
+		p.running = false
+		close(p.done)
+	}
+	return nil
+}
+
+func (p *fakeAgentProcess) Done() <-chan struct{} {
+	return p.done
+}
+
+func (p *fakeAgentProcess) Pid() int {
+	return 0
+}
+
+func (p *fakeAgentProcess) ExitCode() int {
+	if p.exitOnStart {
+		return 1
+	}
+	return 0
+}
+
+func (p *fakeAgentProcess) IsRunning() bool {
+	return p.running
+}
+
+type healthCheckFunc func(ctx context.Context) error
+
+func (f healthCheckFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// fakeOpAMPClient records the remote config statuses reported to the OpAMP Server.
+type fakeOpAMPClient struct {
+	client.OpAMPClient
+
+	statuses []*protobufs.RemoteConfigStatus
+}
+
+func (c *fakeOpAMPClient) SetRemoteConfigStatus(status *protobufs.RemoteConfigStatus) error {
+	c.statuses = append(c.statuses, status)
+	return nil
+}
+
+func (c *fakeOpAMPClient) SetHealth(*protobufs.AgentHealth) error {
+	return nil
+}
+
+// newTestSupervisor returns a Supervisor with a fake Agent process, which is healthy
+// unless it was started with an effective config with a "bad" receiver.
+func newTestSupervisor(t *testing.T, configApplyTimeout time.Duration) (*Supervisor, *fakeAgentProcess, *fakeOpAMPClient) {
+	process := &fakeAgentProcess{}
+	opampClient := &fakeOpAMPClient{}
+	s := &Supervisor{
+		logger:    zap.NewNop(),
+		commander: process,
+		config: config.Supervisor{
+			Agent:   &config.Agent{ConfigApplyTimeout: configApplyTimeout},
+			Storage: &config.Storage{Directory: t.TempDir()},
+		},
+		persistentState:              &persistentState{InstanceID: ulid.MustParse("01BX5ZZKBKACTAV9WEVGEMMVRZ")},
+		agentConfigOwnMetricsSection: &atomic.Value{},
+		effectiveConfig:              &atomic.Value{},
+		hasNewConfig:                 make(chan struct{}, 1),
+		opampClient:                  opampClient,
+	}
+	s.healthChecker = healthCheckFunc(func(context.Context) error {
+		// The Agent runs with the effective config written when it was started.
+		effectiveConfig, err := os.ReadFile(s.effectiveConfigFilePath)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(string(effectiveConfig), "bad") {
+			return errors.New("agent is not healthy")
+		}
+		return nil
+	})
+	s.effectiveConfigFilePath = filepath.Join(s.config.Storage.Directory, "effective.yaml")
+	s.effectiveConfig.Store(s.composeExtraLocalConfig())
+	t.Cleanup(func() {
+		if s.healthCheckTicker != nil {
+			s.healthCheckTicker.Stop()
+		}
+	})
+	return s, process, opampClient
+}
+
+func testRemoteConfig(receiver string) *protobufs.AgentRemoteConfig {
+	return &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"collector.yaml": {Body: []byte("receivers:\n  " + receiver + ": {}\n")},
+			},
+		},
+		ConfigHash: []byte(receiver),
+	}
+}
+
+func TestApplyConfig_healthy(t *testing.T) {
+	s, process, opampClient := newTestSupervisor(t, 5*time.Second)
+	good := testRemoteConfig("good")
+	_, err := s.setRemoteConfig(good)
+	require.NoError(t, err)
+
+	s.applyConfig()
+
+	assert.True(t, process.IsRunning())
+	assert.Equal(t, good, s.lastGoodRemoteConfig)
+	saved, err := loadRemoteConfig(filepath.Join(s.config.Storage.Directory, lastGoodRemoteConfigFile))
+	require.NoError(t, err)
+	assert.Equal(t, good.ConfigHash, saved.ConfigHash)
+	require.Len(t, opampClient.statuses, 1)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, opampClient.statuses[0].Status)
+	assert.Equal(t, good.ConfigHash, opampClient.statuses[0].LastRemoteConfigHash)
+}
+
+func TestApplyConfig_unhealthyRollsBack(t *testing.T) {
+	s, process, opampClient := newTestSupervisor(t, 100*time.Millisecond)
+	good := testRemoteConfig("good")
+	s.lastGoodRemoteConfig = good
+	bad := testRemoteConfig("bad")
+	_, err := s.setRemoteConfig(bad)
+	require.NoError(t, err)
+
+	s.applyConfig()
+
+	require.Len(t, opampClient.statuses, 1)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, opampClient.statuses[0].Status)
+	assert.Equal(t, bad.ConfigHash, opampClient.statuses[0].LastRemoteConfigHash)
+
+	// The Agent is restarted with the last known-good config.
+	assert.Equal(t, good, s.remoteConfig)
+	assert.Equal(t, 2, process.starts)
+	assert.True(t, process.IsRunning())
+	effectiveConfig, err := os.ReadFile(s.effectiveConfigFilePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(effectiveConfig), "good")
+	assert.NotContains(t, string(effectiveConfig), "bad")
+	saved, err := loadRemoteConfig(filepath.Join(s.config.Storage.Directory, remoteConfigFile))
+	require.NoError(t, err)
+	assert.Equal(t, good.ConfigHash, saved.ConfigHash)
+}
+
+func TestApplyConfig_noLastGoodStopsAgent(t *testing.T) {
+	s, process, opampClient := newTestSupervisor(t, 100*time.Millisecond)
+	bad := testRemoteConfig("bad")
+	_, err := s.setRemoteConfig(bad)
+	require.NoError(t, err)
+
+	s.applyConfig()
+
+	require.Len(t, opampClient.statuses, 1)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, opampClient.statuses[0].Status)
+	assert.Nil(t, s.remoteConfig)
+	assert.True(t, s.agentStopped)
+	assert.False(t, process.IsRunning())
+	assert.Equal(t, 1, process.starts)
+}
+
+func TestApplyConfig_agentExits(t *testing.T) {
+	s, process, opampClient := newTestSupervisor(t, time.Minute)
+	process.exitOnStart = true
+	good := testRemoteConfig("good")
+	s.lastGoodRemoteConfig = good
+	_, err := s.setRemoteConfig(testRemoteConfig("crashing"))
+	require.NoError(t, err)
+
+	start := time.Now()
+	s.applyConfig()
+
+	assert.Less(t, time.Since(start), time.Minute)
+	require.Len(t, opampClient.statuses, 1)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, opampClient.statuses[0].Status)
+	assert.Contains(t, opampClient.statuses[0].ErrorMessage, "exited")
+	assert.Equal(t, good, s.remoteConfig)
+	assert.Equal(t, 2, process.starts)
+}
+
+func TestApplyConfig_newerConfigReceivedDuringApply(t *testing.T) {
+	s, process, opampClient := newTestSupervisor(t, 1500*time.Millisecond)
+	s.lastGoodRemoteConfig = testRemoteConfig("good")
+	bad := testRemoteConfig("bad")
+	_, err := s.setRemoteConfig(bad)
+	require.NoError(t, err)
+
+	// The OpAMP Server sends a newer config while the Agent is checked with the bad one.
+	newer := testRemoteConfig("newer")
+	checker := s.healthChecker
+	var once sync.Once
+	s.healthChecker = healthCheckFunc(func(ctx context.Context) error {
+		once.Do(func() {
+			_, setErr := s.setRemoteConfig(newer)
+			assert.NoError(t, setErr)
+		})
+		return checker.Check(ctx)
+	})
+
+	s.applyConfig()
+
+	require.Len(t, opampClient.statuses, 1)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, opampClient.statuses[0].Status)
+	assert.Equal(t, bad.ConfigHash, opampClient.statuses[0].LastRemoteConfigHash)
+
+	// The newer config is kept to be applied next instead of the last known-good one.
+	assert.Equal(t, newer, s.remoteConfig)
+	assert.Equal(t, 1, process.starts)
+	assert.Contains(t, s.effectiveConfig.Load().(string), "newer")
+}
@@ -171,6 +171,10 @@ func TestDefaultExtensions(t *testing.T) {
 			extension:     "oidc",
 			skipLifecycle: true, // Requires a running OIDC server in order to complete life cycle testing
 		},
+		{
+			extension:     "opamp",
+			skipLifecycle: true, // Requires a running OpAMP server in order to complete life cycle testing
+		},
 		{
 			extension: "db_storage",
 			getConfigFn: func() component.Config {
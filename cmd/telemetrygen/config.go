@@ -15,13 +15,17 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/replay"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/scenario"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/traces"
 )
 
 var (
-	tracesCfg  *traces.Config
-	metricsCfg *metrics.Config
-	logsCfg    *logs.Config
+	tracesCfg   *traces.Config
+	metricsCfg  *metrics.Config
+	logsCfg     *logs.Config
+	scenarioCfg *scenario.Config
+	replayCfg   *replay.Config
 )
 
 // rootCmd is the root command on which will be run children commands
@@ -61,8 +65,28 @@ var logsCmd = &cobra.Command{
 	},
 }
 
+// scenarioCmd is the command responsible for sending the telemetry of a scenario file
+var scenarioCmd = &cobra.Command{
+	Use:     "scenario",
+	Short:   "Simulates services generating the traces, metrics and logs described by a scenario file.",
+	Example: "telemetrygen scenario --file scenario.yaml --duration 1m",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scenario.Start(scenarioCfg)
+	},
+}
+
+// replayCmd is the command responsible for replaying captured telemetry
+var replayCmd = &cobra.Command{
+	Use:     "replay",
+	Short:   "Replays the OTLP JSON requests of files written by the file exporter.",
+	Example: "telemetrygen replay --file traces.json --speed 2",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return replay.Start(replayCfg)
+	},
+}
+
 func init() {
-	rootCmd.AddCommand(tracesCmd, metricsCmd, logsCmd)
+	rootCmd.AddCommand(tracesCmd, metricsCmd, logsCmd, scenarioCmd, replayCmd)
 
 	tracesCfg = new(traces.Config)
 	tracesCfg.Flags(tracesCmd.Flags())
@@ -73,6 +97,12 @@ func init() {
 	logsCfg = new(logs.Config)
 	logsCfg.Flags(logsCmd.Flags())
 
+	scenarioCfg = new(scenario.Config)
+	scenarioCfg.Flags(scenarioCmd.Flags())
+
+	replayCfg = new(replay.Config)
+	replayCfg.Flags(replayCmd.Flags())
+
 	// Disabling completion command for end user
 	// https://github.com/spf13/cobra/blob/master/shell_completions.md
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// Exporter sends traces, metrics and logs built with pdata to the OTLP endpoint of the config,
+// over gRPC or over HTTP with protobuf payloads sent to the default OTLP paths.
+type Exporter struct {
+	cfg *Config
+
+	conn    *grpc.ClientConn
+	traces  ptraceotlp.GRPCClient
+	metrics pmetricotlp.GRPCClient
+	logs    plogotlp.GRPCClient
+
+	httpClient *http.Client
+}
+
+// NewExporter creates an Exporter for the OTLP endpoint of the config.
+func NewExporter(cfg *Config) (*Exporter, error) {
+	e := &Exporter{cfg: cfg}
+	if cfg.UseHTTP {
+		e.httpClient = &http.Client{}
+		return e, nil
+	}
+
+	creds := insecure.NewCredentials()
+	if !cfg.Insecure {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+	conn, err := grpc.DialContext(context.Background(), cfg.Endpoint(), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	e.conn = conn
+	e.traces = ptraceotlp.NewGRPCClient(conn)
+	e.metrics = pmetricotlp.NewGRPCClient(conn)
+	e.logs = plogotlp.NewGRPCClient(conn)
+	return e, nil
+}
+
+// ExportTraces sends the traces to the endpoint.
+func (e *Exporter) ExportTraces(ctx context.Context, td ptrace.Traces) error {
+	req := ptraceotlp.NewExportRequestFromTraces(td)
+	if e.httpClient != nil {
+		body, err := req.MarshalProto()
+		if err != nil {
+			return err
+		}
+		return e.post(ctx, "/v1/traces", body)
+	}
+	_, err := e.traces.Export(e.outgoingContext(ctx), req)
+	return err
+}
+
+// ExportMetrics sends the metrics to the endpoint.
+func (e *Exporter) ExportMetrics(ctx context.Context, md pmetric.Metrics) error {
+	req := pmetricotlp.NewExportRequestFromMetrics(md)
+	if e.httpClient != nil {
+		body, err := req.MarshalProto()
+		if err != nil {
+			return err
+		}
+		return e.post(ctx, "/v1/metrics", body)
+	}
+	_, err := e.metrics.Export(e.outgoingContext(ctx), req)
+	return err
+}
+
+// ExportLogs sends the logs to the endpoint.
+func (e *Exporter) ExportLogs(ctx context.Context, ld plog.Logs) error {
+	req := plogotlp.NewExportRequestFromLogs(ld)
+	if e.httpClient != nil {
+		body, err := req.MarshalProto()
+		if err != nil {
+			return err
+		}
+		return e.post(ctx, "/v1/logs", body)
+	}
+	_, err := e.logs.Export(e.outgoingContext(ctx), req)
+	return err
+}
+
+// Shutdown closes the connection to the endpoint.
+func (e *Exporter) Shutdown() error {
+	if e.conn == nil {
+		return nil
+	}
+	return e.conn.Close()
+}
+
+func (e *Exporter) outgoingContext(ctx context.Context) context.Context {
+	if len(e.cfg.Headers) == 0 {
+		return ctx
+	}
+	return metadata.NewOutgoingContext(ctx, metadata.New(e.cfg.Headers))
+}
+
+func (e *Exporter) post(ctx context.Context, path string, body []byte) error {
+	scheme := "https"
+	if e.cfg.Insecure {
+		scheme = "http"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, scheme+"://"+e.cfg.Endpoint()+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range e.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("export to %s failed with status %s", req.URL, resp.Status)
+	}
+	return nil
+}
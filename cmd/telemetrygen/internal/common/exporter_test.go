@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestExporter_http(t *testing.T) {
+	var paths []string
+	var logs plog.Logs
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		if r.URL.Path == "/v1/logs" {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			req := plogotlp.NewExportRequest()
+			assert.NoError(t, req.UnmarshalProto(body))
+			logs = req.Logs()
+		}
+		if r.URL.Path == "/v1/metrics" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	exp, err := NewExporter(&Config{
+		CustomEndpoint: strings.TrimPrefix(server.URL, "http://"),
+		Insecure:       true,
+		UseHTTP:        true,
+		Headers:        KeyValue{"Authorization": "secret"},
+	})
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exp.Shutdown())
+	}()
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("the message")
+	require.NoError(t, exp.ExportLogs(context.Background(), ld))
+	require.Equal(t, 1, logs.LogRecordCount())
+	assert.Equal(t, "the message", logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+
+	require.NoError(t, exp.ExportTraces(context.Background(), ptrace.NewTraces()))
+	assert.ErrorContains(t, exp.ExportMetrics(context.Background(), pmetric.NewMetrics()), "failed with status 503 Service Unavailable")
+	assert.Equal(t, []string{"/v1/logs", "/v1/traces", "/v1/metrics"}, paths)
+}
@@ -4,6 +4,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/spf13/pflag"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
@@ -12,8 +14,10 @@ import (
 // Config describes the test scenario.
 type Config struct {
 	common.Config
-	NumMetrics int
-	MetricType metricType
+	NumMetrics       int
+	MetricType       metricType
+	UniqueTimeseries int
+	TimeseriesChurn  time.Duration
 }
 
 // Flags registers config flags.
@@ -27,4 +31,6 @@ func (c *Config) Flags(fs *pflag.FlagSet) {
 
 	fs.Var(&c.MetricType, "metric-type", "Metric type enum. must be one of 'Gauge' or 'Sum'")
 	fs.IntVar(&c.NumMetrics, "metrics", 1, "Number of metrics to generate in each worker (ignored if duration is provided)")
+	fs.IntVar(&c.UniqueTimeseries, "unique-timeseries", 1, "Number of unique time series shared by the workers, each metric is sent for the next series in turn with a different value of the series_id attribute")
+	fs.DurationVar(&c.TimeseriesChurn, "timeseries-churn", 0, "Interval at which the unique time series are replaced by new ones. Zero means the time series never change")
 }
@@ -63,6 +63,9 @@ func Run(c *Config, exp sdkmetric.Exporter, logger *zap.Logger) error {
 	} else if c.NumMetrics <= 0 {
 		return fmt.Errorf("either `metrics` or `duration` must be greater than 0")
 	}
+	if c.UniqueTimeseries > 1 {
+		logger.Info("generating unique time series", zap.Int("series", c.UniqueTimeseries), zap.Duration("churn", c.TimeseriesChurn))
+	}
 
 	limit := rate.Limit(c.Rate)
 	if c.Rate == 0 {
@@ -77,18 +80,23 @@ func Run(c *Config, exp sdkmetric.Exporter, logger *zap.Logger) error {
 
 	running := &atomic.Bool{}
 	running.Store(true)
+	startTime := time.Now()
 
 	for i := 0; i < c.WorkerCount; i++ {
 		wg.Add(1)
 		w := worker{
-			numMetrics:     c.NumMetrics,
-			metricType:     c.MetricType,
-			limitPerSecond: limit,
-			totalDuration:  c.TotalDuration,
-			running:        running,
-			wg:             &wg,
-			logger:         logger.With(zap.Int("worker", i)),
-			index:          i,
+			numMetrics:       c.NumMetrics,
+			metricType:       c.MetricType,
+			uniqueTimeseries: c.UniqueTimeseries,
+			timeseriesChurn:  c.TimeseriesChurn,
+			startTime:        startTime,
+			limitPerSecond:   limit,
+			totalDuration:    c.TotalDuration,
+			running:          running,
+			wg:               &wg,
+			logger:           logger.With(zap.Int("worker", i)),
+			index:            i,
+			workerCount:      c.WorkerCount,
 		}
 
 		go w.simulateMetrics(res, exp, c.GetTelemetryAttributes())
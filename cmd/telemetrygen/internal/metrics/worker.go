@@ -5,6 +5,7 @@ package metrics
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -17,15 +18,22 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// seriesAttributeKey is the attribute distinguishing the unique time series.
+const seriesAttributeKey = "series_id"
+
 type worker struct {
-	running        *atomic.Bool    // pointer to shared flag that indicates it's time to stop the test
-	metricType     metricType      // type of metric to generate
-	numMetrics     int             // how many metrics the worker has to generate (only when duration==0)
-	totalDuration  time.Duration   // how long to run the test for (overrides `numMetrics`)
-	limitPerSecond rate.Limit      // how many metrics per second to generate
-	wg             *sync.WaitGroup // notify when done
-	logger         *zap.Logger     // logger
-	index          int             // worker index
+	running          *atomic.Bool    // pointer to shared flag that indicates it's time to stop the test
+	metricType       metricType      // type of metric to generate
+	numMetrics       int             // how many metrics the worker has to generate (only when duration==0)
+	uniqueTimeseries int             // how many unique time series the workers share
+	timeseriesChurn  time.Duration   // how often the unique time series are replaced by new ones
+	startTime        time.Time       // when the test started, time series churn is relative to it
+	totalDuration    time.Duration   // how long to run the test for (overrides `numMetrics`)
+	limitPerSecond   rate.Limit      // how many metrics per second to generate
+	wg               *sync.WaitGroup // notify when done
+	logger           *zap.Logger     // logger
+	index            int             // worker index
+	workerCount      int             // number of workers
 }
 
 func (w worker) simulateMetrics(res *resource.Resource, exporter sdkmetric.Exporter, signalAttrs []attribute.KeyValue) {
@@ -34,6 +42,10 @@ func (w worker) simulateMetrics(res *resource.Resource, exporter sdkmetric.Expor
 	var i int64
 	for w.running.Load() {
 		var metrics []metricdata.Metrics
+		attrs := attribute.NewSet(signalAttrs...)
+		if w.uniqueTimeseries > 1 {
+			attrs = attribute.NewSet(append([]attribute.KeyValue{attribute.String(seriesAttributeKey, w.seriesID(i))}, signalAttrs...)...)
+		}
 
 		switch w.metricType {
 		case metricTypeGauge:
@@ -44,7 +56,7 @@ func (w worker) simulateMetrics(res *resource.Resource, exporter sdkmetric.Expor
 						{
 							Time:       time.Now(),
 							Value:      i,
-							Attributes: attribute.NewSet(signalAttrs...),
+							Attributes: attrs,
 						},
 					},
 				},
@@ -60,7 +72,7 @@ func (w worker) simulateMetrics(res *resource.Resource, exporter sdkmetric.Expor
 							StartTime:  time.Now().Add(-1 * time.Second),
 							Time:       time.Now(),
 							Value:      i,
-							Attributes: attribute.NewSet(signalAttrs...),
+							Attributes: attrs,
 						},
 					},
 				},
@@ -90,3 +102,17 @@ func (w worker) simulateMetrics(res *resource.Resource, exporter sdkmetric.Expor
 	w.logger.Info("metrics generated", zap.Int64("metrics", i))
 	w.wg.Done()
 }
+
+// seriesID returns the time series of the i-th metric of the worker. The workers take turns
+// sending the metrics of the series, which are renamed at every churn interval.
+func (w worker) seriesID(i int64) string {
+	workers := int64(w.workerCount)
+	if workers < 1 {
+		workers = 1
+	}
+	id := "series_" + strconv.FormatInt((i*workers+int64(w.index))%int64(w.uniqueTimeseries), 10)
+	if w.timeseriesChurn > 0 {
+		id += "_" + strconv.FormatInt(int64(time.Since(w.startTime)/w.timeseriesChurn), 10)
+	}
+	return id
+}
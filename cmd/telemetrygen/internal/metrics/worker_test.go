@@ -300,3 +300,37 @@ func configWithMultipleAttributes(metric metricType, qty int) *Config {
 		MetricType: metric,
 	}
 }
+
+func TestUniqueTimeseries(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		NumMetrics:       6,
+		MetricType:       metricTypeSum,
+		UniqueTimeseries: 3,
+	}
+	exp := &mockExporter{}
+
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	require.Len(t, exp.rms, 6)
+	var series []string
+	for _, rm := range exp.rms {
+		attrs := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64]).DataPoints[0].Attributes
+		id, ok := attrs.Value(seriesAttributeKey)
+		require.True(t, ok)
+		series = append(series, id.AsString())
+	}
+	assert.Equal(t, []string{"series_0", "series_1", "series_2", "series_0", "series_1", "series_2"}, series)
+}
+
+func TestTimeseriesChurn(t *testing.T) {
+	w := worker{uniqueTimeseries: 4, timeseriesChurn: time.Minute, startTime: time.Now().Add(-90 * time.Second), index: 1, workerCount: 2}
+	assert.Equal(t, "series_1_1", w.seriesID(0))
+	assert.Equal(t, "series_3_1", w.seriesID(1))
+	assert.Equal(t, "series_1_1", w.seriesID(2))
+
+	w.startTime = time.Now()
+	assert.Equal(t, "series_3_0", w.seriesID(1))
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// batch is a captured OTLP request of one of the signals.
+type batch struct {
+	// timestamp is the earliest time of the telemetry of the request.
+	timestamp time.Time
+
+	traces  *ptrace.Traces
+	metrics *pmetric.Metrics
+	logs    *plog.Logs
+}
+
+// readFiles reads the requests of the files, ordered by timestamp.
+func readFiles(paths []string) ([]batch, error) {
+	var batches []batch
+	for _, path := range paths {
+		b, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, b...)
+	}
+	sort.SliceStable(batches, func(i, j int) bool {
+		return batches[i].timestamp.Before(batches[j].timestamp)
+	})
+	return batches, nil
+}
+
+// readFile reads the OTLP JSON requests of the file, one per line. Requests without
+// timestamps take the timestamp of the previous request of the file.
+func readFile(path string) ([]batch, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var batches []batch
+	r := bufio.NewReader(f)
+	for line := 1; ; line++ {
+		data, err := r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			b, parseErr := parseBatch(data)
+			if parseErr != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, line, parseErr)
+			}
+			batches = append(batches, b)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	// Fill the missing timestamps, requests at the start of the file take the first known timestamp.
+	var last time.Time
+	for i := range batches {
+		if batches[i].timestamp.IsZero() {
+			batches[i].timestamp = last
+		}
+		last = batches[i].timestamp
+	}
+	for i := len(batches) - 1; i >= 0; i-- {
+		if batches[i].timestamp.IsZero() {
+			batches[i].timestamp = last
+		}
+		last = batches[i].timestamp
+	}
+	return batches, nil
+}
+
+func parseBatch(data []byte) (batch, error) {
+	var keys struct {
+		ResourceSpans   json.RawMessage `json:"resourceSpans"`
+		ResourceMetrics json.RawMessage `json:"resourceMetrics"`
+		ResourceLogs    json.RawMessage `json:"resourceLogs"`
+	}
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return batch{}, err
+	}
+
+	switch {
+	case keys.ResourceSpans != nil:
+		td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(data)
+		if err != nil {
+			return batch{}, err
+		}
+		return batch{timestamp: tracesTimestamp(td), traces: &td}, nil
+	case keys.ResourceMetrics != nil:
+		md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(data)
+		if err != nil {
+			return batch{}, err
+		}
+		return batch{timestamp: metricsTimestamp(md), metrics: &md}, nil
+	case keys.ResourceLogs != nil:
+		ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(data)
+		if err != nil {
+			return batch{}, err
+		}
+		return batch{timestamp: logsTimestamp(ld), logs: &ld}, nil
+	default:
+		return batch{}, errors.New("not an OTLP traces, metrics or logs request")
+	}
+}
+
+func (b batch) signal() string {
+	switch {
+	case b.traces != nil:
+		return "traces"
+	case b.metrics != nil:
+		return "metrics"
+	default:
+		return "logs"
+	}
+}
+
+// export sends a copy of the request with its timestamps shifted by the duration
+// and the resource attributes set on all its resources.
+func (b batch) export(ctx context.Context, exp exporter, shift time.Duration, resourceAttributes map[string]string) error {
+	switch {
+	case b.traces != nil:
+		td := ptrace.NewTraces()
+		b.traces.CopyTo(td)
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			putAttributes(rss.At(i).Resource(), resourceAttributes)
+		}
+		shiftTraces(td, shift)
+		return exp.ExportTraces(ctx, td)
+	case b.metrics != nil:
+		md := pmetric.NewMetrics()
+		b.metrics.CopyTo(md)
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			putAttributes(rms.At(i).Resource(), resourceAttributes)
+		}
+		shiftMetrics(md, shift)
+		return exp.ExportMetrics(ctx, md)
+	default:
+		ld := plog.NewLogs()
+		b.logs.CopyTo(ld)
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			putAttributes(rls.At(i).Resource(), resourceAttributes)
+		}
+		shiftLogs(ld, shift)
+		return exp.ExportLogs(ctx, ld)
+	}
+}
+
+func putAttributes(res pcommon.Resource, attrs map[string]string) {
+	for k, v := range attrs {
+		res.Attributes().PutStr(k, v)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+// Config describes the replay of captured telemetry.
+type Config struct {
+	common.Config
+	Files []string
+	Speed float64
+}
+
+// Flags registers config flags.
+func (c *Config) Flags(fs *pflag.FlagSet) {
+	c.OTLPFlags(fs)
+
+	fs.DurationVar(&c.TotalDuration, "duration", 0, "For how long to replay the files, they are replayed in a loop until the duration elapses. Zero means the files are replayed once")
+	fs.StringSliceVar(&c.Files, "file", nil, "Path of a file of OTLP JSON requests, one per line, as written by the file exporter. Flag may be repeated to replay several files together")
+	fs.Float64Var(&c.Speed, "speed", 1, "Speed of the replay relative to the original timing, 2 replays twice as fast. Zero means the requests are sent as fast as possible")
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type exporter interface {
+	ExportTraces(context.Context, ptrace.Traces) error
+	ExportMetrics(context.Context, pmetric.Metrics) error
+	ExportLogs(context.Context, plog.Logs) error
+}
+
+// Start replays the files of the config.
+func Start(cfg *Config) error {
+	logger, err := common.CreateLogger(cfg.SkipSettingGRPCLogger)
+	if err != nil {
+		return err
+	}
+
+	if len(cfg.Files) == 0 {
+		return errors.New("at least one `file` must be provided")
+	}
+	batches, err := readFiles(cfg.Files)
+	if err != nil {
+		return err
+	}
+
+	exp, err := common.NewExporter(&cfg.Config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := exp.Shutdown(); err != nil {
+			logger.Error("failed to stop the exporter", zap.Error(err))
+		}
+	}()
+
+	return Run(cfg, batches, exp, logger)
+}
+
+// Run sends the batches at their original timing scaled by the speed of the config,
+// with their timestamps shifted to the time they are sent.
+func Run(c *Config, batches []batch, exp exporter, logger *zap.Logger) error {
+	if c.Speed < 0 {
+		return errors.New("`speed` must not be negative")
+	}
+	if len(batches) == 0 {
+		return errors.New("the files do not contain any telemetry to replay")
+	}
+
+	ctx := context.Background()
+	if c.TotalDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.TotalDuration)
+		defer cancel()
+	}
+
+	first := batches[0].timestamp
+	logger.Info("replaying telemetry", zap.Int("requests", len(batches)),
+		zap.Duration("captured-duration", batches[len(batches)-1].timestamp.Sub(first)), zap.Float64("speed", c.Speed))
+	for {
+		start := time.Now()
+		for _, b := range batches {
+			scheduled := start
+			if c.Speed > 0 {
+				scheduled = start.Add(time.Duration(float64(b.timestamp.Sub(first)) / c.Speed))
+			}
+			if !sleepUntil(ctx, scheduled) {
+				return nil
+			}
+			if err := b.export(ctx, exp, scheduled.Sub(b.timestamp), c.ResourceAttributes); err != nil && ctx.Err() == nil {
+				logger.Error("failed to export "+b.signal(), zap.Error(err))
+			}
+		}
+		if c.TotalDuration <= 0 || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// sleepUntil waits until the time t, it returns false if the context is done first.
+func sleepUntil(ctx context.Context, t time.Time) bool {
+	d := time.Until(t)
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type mockExporter struct {
+	mu      sync.Mutex
+	signals []string
+	traces  []ptrace.Traces
+	metrics []pmetric.Metrics
+	logs    []plog.Logs
+}
+
+func (m *mockExporter) ExportTraces(_ context.Context, td ptrace.Traces) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.signals = append(m.signals, "traces")
+	m.traces = append(m.traces, td)
+	return nil
+}
+
+func (m *mockExporter) ExportMetrics(_ context.Context, md pmetric.Metrics) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.signals = append(m.signals, "metrics")
+	m.metrics = append(m.metrics, md)
+	return nil
+}
+
+func (m *mockExporter) ExportLogs(_ context.Context, ld plog.Logs) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.signals = append(m.signals, "logs")
+	m.logs = append(m.logs, ld)
+	return nil
+}
+
+func testFiles() []string {
+	return []string{
+		filepath.Join("testdata", "traces.json"),
+		filepath.Join("testdata", "metrics.json"),
+		filepath.Join("testdata", "logs.json"),
+	}
+}
+
+func TestReadFiles(t *testing.T) {
+	batches, err := readFiles(testFiles())
+	require.NoError(t, err)
+
+	var signals []string
+	var offsets []time.Duration
+	for _, b := range batches {
+		signals = append(signals, b.signal())
+		offsets = append(offsets, b.timestamp.Sub(batches[0].timestamp))
+	}
+	assert.Equal(t, []string{"traces", "metrics", "logs", "logs", "traces"}, signals)
+	assert.Equal(t, []time.Duration{0, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond, 400 * time.Millisecond}, offsets)
+	assert.Equal(t, int64(1697000000000000000), batches[0].timestamp.UnixNano())
+}
+
+func TestReadFiles_errors(t *testing.T) {
+	_, err := readFiles([]string{filepath.Join("testdata", "invalid.json")})
+	assert.ErrorContains(t, err, "invalid.json:1: not an OTLP traces, metrics or logs request")
+
+	_, err = readFiles([]string{filepath.Join("testdata", "doesnotexist.json")})
+	assert.Error(t, err)
+}
+
+func TestRun(t *testing.T) {
+	batches, err := readFiles(testFiles())
+	require.NoError(t, err)
+
+	cfg := &Config{
+		Config: common.Config{ResourceAttributes: map[string]string{"k8s.cluster.name": "test"}},
+		Speed:  4,
+	}
+	exp := &mockExporter{}
+	start := time.Now()
+	require.NoError(t, Run(cfg, batches, exp, zap.NewNop()))
+
+	// The 400ms of captured telemetry are replayed 4 times faster.
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, []string{"traces", "metrics", "logs", "logs", "traces"}, exp.signals)
+
+	first := exp.traces[0].ResourceSpans().At(0)
+	cluster, ok := first.Resource().Attributes().Get("k8s.cluster.name")
+	require.True(t, ok)
+	assert.Equal(t, "test", cluster.Str())
+
+	span := first.ScopeSpans().At(0).Spans().At(0)
+	assert.WithinDuration(t, start, span.StartTimestamp().AsTime(), time.Second)
+	assert.Equal(t, 100*time.Millisecond, span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime()))
+	assert.Equal(t, 50*time.Millisecond, span.Events().At(0).Timestamp().AsTime().Sub(span.StartTimestamp().AsTime()))
+
+	// The timestamps of a request are shifted to the time it is sent.
+	dp := exp.metrics[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, 50*time.Millisecond, dp.Timestamp().AsTime().Sub(span.StartTimestamp().AsTime()))
+	assert.Equal(t, 10200*time.Millisecond, dp.Timestamp().AsTime().Sub(dp.StartTimestamp().AsTime()))
+
+	lr := exp.logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Zero(t, lr.Timestamp())
+	assert.Equal(t, 75*time.Millisecond, lr.ObservedTimestamp().AsTime().Sub(span.StartTimestamp().AsTime()))
+}
+
+func TestRun_loop(t *testing.T) {
+	batches, err := readFiles(testFiles())
+	require.NoError(t, err)
+
+	cfg := &Config{
+		Config: common.Config{TotalDuration: 100 * time.Millisecond},
+	}
+	exp := &mockExporter{}
+	require.NoError(t, Run(cfg, batches, exp, zap.NewNop()))
+
+	assert.Greater(t, len(exp.signals), len(batches))
+}
+
+func TestRun_errors(t *testing.T) {
+	assert.EqualError(t, Run(&Config{Speed: -1}, nil, &mockExporter{}, zap.NewNop()), "`speed` must not be negative")
+	assert.EqualError(t, Run(&Config{Speed: 1}, nil, &mockExporter{}, zap.NewNop()), "the files do not contain any telemetry to replay")
+}
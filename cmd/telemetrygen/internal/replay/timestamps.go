@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// dataPoint holds the timestamps common to the data points of all the metric types.
+type dataPoint interface {
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+}
+
+func tracesTimestamp(td ptrace.Traces) time.Time {
+	var earliest pcommon.Timestamp
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				earliest = earlier(earliest, spans.At(k).StartTimestamp())
+			}
+		}
+	}
+	return asTime(earliest)
+}
+
+func metricsTimestamp(md pmetric.Metrics) time.Time {
+	var earliest pcommon.Timestamp
+	forEachDataPoint(md, func(dp dataPoint) {
+		earliest = earlier(earliest, dp.Timestamp())
+	})
+	return asTime(earliest)
+}
+
+func logsTimestamp(ld plog.Logs) time.Time {
+	var earliest pcommon.Timestamp
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			records := sls.At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				ts := records.At(k).Timestamp()
+				if ts == 0 {
+					ts = records.At(k).ObservedTimestamp()
+				}
+				earliest = earlier(earliest, ts)
+			}
+		}
+	}
+	return asTime(earliest)
+}
+
+func shiftTraces(td ptrace.Traces, d time.Duration) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				span.SetStartTimestamp(shift(span.StartTimestamp(), d))
+				span.SetEndTimestamp(shift(span.EndTimestamp(), d))
+				events := span.Events()
+				for l := 0; l < events.Len(); l++ {
+					events.At(l).SetTimestamp(shift(events.At(l).Timestamp(), d))
+				}
+			}
+		}
+	}
+}
+
+func shiftMetrics(md pmetric.Metrics, d time.Duration) {
+	forEachDataPoint(md, func(dp dataPoint) {
+		dp.SetStartTimestamp(shift(dp.StartTimestamp(), d))
+		dp.SetTimestamp(shift(dp.Timestamp(), d))
+	})
+}
+
+func shiftLogs(ld plog.Logs, d time.Duration) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			records := sls.At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				lr := records.At(k)
+				lr.SetTimestamp(shift(lr.Timestamp(), d))
+				lr.SetObservedTimestamp(shift(lr.ObservedTimestamp(), d))
+			}
+		}
+	}
+}
+
+func forEachDataPoint(md pmetric.Metrics, fn func(dataPoint)) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				m := metrics.At(k)
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					for l := 0; l < m.Gauge().DataPoints().Len(); l++ {
+						fn(m.Gauge().DataPoints().At(l))
+					}
+				case pmetric.MetricTypeSum:
+					for l := 0; l < m.Sum().DataPoints().Len(); l++ {
+						fn(m.Sum().DataPoints().At(l))
+					}
+				case pmetric.MetricTypeHistogram:
+					for l := 0; l < m.Histogram().DataPoints().Len(); l++ {
+						fn(m.Histogram().DataPoints().At(l))
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					for l := 0; l < m.ExponentialHistogram().DataPoints().Len(); l++ {
+						fn(m.ExponentialHistogram().DataPoints().At(l))
+					}
+				case pmetric.MetricTypeSummary:
+					for l := 0; l < m.Summary().DataPoints().Len(); l++ {
+						fn(m.Summary().DataPoints().At(l))
+					}
+				}
+			}
+		}
+	}
+}
+
+// earlier returns the earliest of the non-zero timestamps.
+func earlier(a, b pcommon.Timestamp) pcommon.Timestamp {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// shift moves the timestamp by the duration, unset timestamps are left unset.
+func shift(ts pcommon.Timestamp, d time.Duration) pcommon.Timestamp {
+	if ts == 0 {
+		return 0
+	}
+	return pcommon.Timestamp(int64(ts) + int64(d))
+}
+
+func asTime(ts pcommon.Timestamp) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+// Config describes the test scenario.
+type Config struct {
+	common.Config
+	File string
+	Seed int64
+}
+
+// Flags registers config flags.
+func (c *Config) Flags(fs *pflag.FlagSet) {
+	c.OTLPFlags(fs)
+
+	fs.DurationVar(&c.TotalDuration, "duration", 0, "For how long to run the scenario")
+	fs.StringVar(&c.File, "file", "", "Path of the scenario file")
+	fs.Int64Var(&c.Seed, "seed", 0, "Seed of the random values of the scenario, zero means a different seed for each run")
+}
@@ -0,0 +1,397 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	semconv "go.opentelemetry.io/collector/semconv/v1.13.0"
+)
+
+const scopeName = "telemetrygen"
+
+// generator builds the telemetry of a scenario. A generator is not safe for
+// concurrent use, each signal is generated by its own generator.
+type generator struct {
+	scn       *Scenario
+	rng       *rand.Rand
+	resources map[string][]pcommon.Resource
+	startTime time.Time
+	// sums holds the values of the cumulative sums, by series, instance and attributes.
+	sums map[sumKey]float64
+}
+
+type sumKey struct {
+	series, instance, point int
+}
+
+// newGenerator creates a generator for the scenario. The resource attributes are
+// added to the resource of every service.
+func newGenerator(scn *Scenario, resourceAttributes map[string]string, seed int64, startTime time.Time) *generator {
+	g := &generator{
+		scn:       scn,
+		rng:       rand.New(rand.NewSource(seed)), // #nosec G404 -- synthetic data does not need a secure random source
+		resources: make(map[string][]pcommon.Resource, len(scn.Services)),
+		startTime: startTime,
+		sums:      make(map[sumKey]float64),
+	}
+	for _, svc := range scn.Services {
+		instances := svc.Instances
+		if instances == 0 {
+			instances = 1
+		}
+		for i := 0; i < instances; i++ {
+			res := pcommon.NewResource()
+			res.Attributes().PutStr(semconv.AttributeServiceName, svc.Name)
+			res.Attributes().PutStr(semconv.AttributeServiceInstanceID, svc.Name+"-"+strconv.Itoa(i))
+			for k, v := range svc.ResourceAttributes {
+				res.Attributes().PutStr(k, v)
+			}
+			for k, v := range resourceAttributes {
+				res.Attributes().PutStr(k, v)
+			}
+			g.resources[svc.Name] = append(g.resources[svc.Name], res)
+		}
+	}
+	return g
+}
+
+// traces generates a trace of the topology of the scenario, starting at now.
+func (g *generator) traces(now time.Time) ptrace.Traces {
+	td := ptrace.NewTraces()
+	t := &trace{
+		g:       g,
+		td:      td,
+		scopes:  make(map[string]ptrace.SpanSlice),
+		traceID: pcommon.TraceID(g.randomID16()),
+	}
+	t.appendSpan(&g.scn.Traces.Root, pcommon.NewSpanIDEmpty(), "", now)
+	return td
+}
+
+// trace holds the spans of a trace being generated, by service.
+type trace struct {
+	g       *generator
+	td      ptrace.Traces
+	scopes  map[string]ptrace.SpanSlice
+	traceID pcommon.TraceID
+}
+
+func (t *trace) spans(service string) ptrace.SpanSlice {
+	if spans, ok := t.scopes[service]; ok {
+		return spans
+	}
+	rs := t.td.ResourceSpans().AppendEmpty()
+	t.g.pickResource(service).CopyTo(rs.Resource())
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName(scopeName)
+	t.scopes[service] = ss.Spans()
+	return ss.Spans()
+}
+
+// appendSpan appends the span and its children to the trace and returns its end time.
+// A span called from another service is wrapped in a client span of the caller.
+func (t *trace) appendSpan(node *Span, parentID pcommon.SpanID, caller string, start time.Time) time.Time {
+	if caller == "" || caller == node.Service {
+		kind := ptrace.SpanKindInternal
+		if caller == "" {
+			kind = ptrace.SpanKindServer
+		}
+		end, _ := t.fillSpan(t.spans(node.Service).AppendEmpty(), node, parentID, kind, start)
+		return end
+	}
+
+	client := t.spans(caller).AppendEmpty()
+	clientID := t.newSpanID()
+	end, failed := t.fillSpan(t.spans(node.Service).AppendEmpty(), node, clientID, ptrace.SpanKindServer, start)
+
+	client.SetTraceID(t.traceID)
+	client.SetSpanID(clientID)
+	client.SetParentSpanID(parentID)
+	client.SetName(node.Name)
+	client.SetKind(ptrace.SpanKindClient)
+	client.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	client.SetEndTimestamp(pcommon.NewTimestampFromTime(end))
+	if failed {
+		client.Status().SetCode(ptrace.StatusCodeError)
+	}
+	return end
+}
+
+// fillSpan sets the fields of the span, appends its children and returns its
+// end time and whether it has an error status.
+func (t *trace) fillSpan(span ptrace.Span, node *Span, parentID pcommon.SpanID, kind ptrace.SpanKind, start time.Time) (time.Time, bool) {
+	spanID := t.newSpanID()
+	span.SetTraceID(t.traceID)
+	span.SetSpanID(spanID)
+	span.SetParentSpanID(parentID)
+	span.SetName(node.Name)
+	if k, ok := spanKinds[node.Kind]; ok {
+		kind = k
+	}
+	span.SetKind(kind)
+	for k, v := range node.Attributes {
+		span.Attributes().PutStr(k, v)
+	}
+
+	end := start.Add(t.g.latency(node.Latency))
+	childStart := start
+	for i := range node.Children {
+		childStart = t.appendSpan(&node.Children[i], spanID, node.Service, childStart)
+	}
+	if childStart.After(end) {
+		end = childStart
+	}
+
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(end))
+	failed := t.g.rng.Float64() < node.ErrorRate
+	if failed {
+		span.Status().SetCode(ptrace.StatusCodeError)
+	}
+	return end, failed
+}
+
+func (t *trace) newSpanID() pcommon.SpanID {
+	var id [8]byte
+	_, _ = t.g.rng.Read(id[:])
+	return id
+}
+
+func (g *generator) randomID16() [16]byte {
+	var id [16]byte
+	_, _ = g.rng.Read(id[:])
+	return id
+}
+
+// metrics generates a data point of every series of the scenario, for every
+// instance of their service and every combination of their attributes.
+func (g *generator) metrics(now time.Time, interval time.Duration) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	scopes := make(map[string][]pmetric.MetricSlice)
+	for _, svc := range g.scn.Services {
+		for _, res := range g.resources[svc.Name] {
+			rm := md.ResourceMetrics().AppendEmpty()
+			res.CopyTo(rm.Resource())
+			sm := rm.ScopeMetrics().AppendEmpty()
+			sm.Scope().SetName(scopeName)
+			scopes[svc.Name] = append(scopes[svc.Name], sm.Metrics())
+		}
+	}
+
+	ts := pcommon.NewTimestampFromTime(now)
+	for s, series := range g.scn.Metrics.Series {
+		combinations := attributeCombinations(series.Attributes)
+		for instance, metrics := range scopes[series.Service] {
+			m := metrics.AppendEmpty()
+			m.SetName(series.Name)
+			m.SetUnit(series.Unit)
+
+			switch series.Type {
+			case "gauge":
+				dps := m.SetEmptyGauge().DataPoints()
+				for _, attrs := range combinations {
+					dp := dps.AppendEmpty()
+					putAttributes(dp.Attributes(), attrs)
+					dp.SetTimestamp(ts)
+					dp.SetDoubleValue(g.value(series.Value))
+				}
+			case "sum":
+				sum := m.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				for point, attrs := range combinations {
+					key := sumKey{series: s, instance: instance, point: point}
+					g.sums[key] += math.Abs(g.value(series.Value))
+					dp := sum.DataPoints().AppendEmpty()
+					putAttributes(dp.Attributes(), attrs)
+					dp.SetStartTimestamp(pcommon.NewTimestampFromTime(g.startTime))
+					dp.SetTimestamp(ts)
+					dp.SetDoubleValue(g.sums[key])
+				}
+			case "histogram":
+				hist := m.SetEmptyHistogram()
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+				for _, attrs := range combinations {
+					dp := hist.DataPoints().AppendEmpty()
+					putAttributes(dp.Attributes(), attrs)
+					dp.SetStartTimestamp(pcommon.NewTimestampFromTime(now.Add(-interval)))
+					dp.SetTimestamp(ts)
+					g.fillHistogram(dp, series)
+				}
+			}
+		}
+	}
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		return rm.ScopeMetrics().At(0).Metrics().Len() == 0
+	})
+	return md
+}
+
+func (g *generator) fillHistogram(dp pmetric.HistogramDataPoint, series Series) {
+	samples := series.Samples
+	if samples == 0 {
+		samples = 1
+	}
+	dp.ExplicitBounds().FromRaw(series.Buckets)
+	counts := make([]uint64, len(series.Buckets)+1)
+	var sum float64
+	for i := 0; i < samples; i++ {
+		v := g.value(series.Value)
+		sum += v
+		counts[sort.SearchFloat64s(series.Buckets, v)]++
+		if i == 0 || v < dp.Min() {
+			dp.SetMin(v)
+		}
+		if i == 0 || v > dp.Max() {
+			dp.SetMax(v)
+		}
+	}
+	dp.BucketCounts().FromRaw(counts)
+	dp.SetCount(uint64(samples))
+	dp.SetSum(sum)
+}
+
+// attributeCombinations returns every combination of the values of the attributes,
+// the values of an attribute are "<key>-<n>" for n lower than its cardinality.
+func attributeCombinations(cardinalities map[string]int) [][][2]string {
+	keys := make([]string, 0, len(cardinalities))
+	for k := range cardinalities {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	combinations := [][][2]string{nil}
+	for _, k := range keys {
+		next := make([][][2]string, 0, len(combinations)*cardinalities[k])
+		for _, c := range combinations {
+			for n := 0; n < cardinalities[k]; n++ {
+				attrs := make([][2]string, len(c), len(c)+1)
+				copy(attrs, c)
+				next = append(next, append(attrs, [2]string{k, k + "-" + strconv.Itoa(n)}))
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+func putAttributes(m pcommon.Map, attrs [][2]string) {
+	for _, kv := range attrs {
+		m.PutStr(kv[0], kv[1])
+	}
+}
+
+// logs generates a log record from a template of the scenario picked by weight.
+func (g *generator) logs(now time.Time) plog.Logs {
+	tmpl := g.pickTemplate()
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	g.pickResource(tmpl.Service).CopyTo(rl.Resource())
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+	lr := sl.LogRecords().AppendEmpty()
+
+	severity := tmpl.Severity
+	if severity == "" {
+		severity = "info"
+	}
+	lr.SetSeverityNumber(severities[severity])
+	lr.SetSeverityText(strings.ToUpper(severity))
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+	for k, v := range tmpl.Attributes {
+		lr.Attributes().PutStr(k, v)
+	}
+
+	names := make([]string, 0, len(tmpl.Fields))
+	for name := range tmpl.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	replacements := make([]string, 0, 2*len(names))
+	for _, name := range names {
+		value := g.fieldValue(name, tmpl.Fields[name])
+		lr.Attributes().PutStr(name, value)
+		replacements = append(replacements, "{{"+name+"}}", value)
+	}
+	lr.Body().SetStr(strings.NewReplacer(replacements...).Replace(tmpl.Body))
+	return ld
+}
+
+func (g *generator) pickTemplate() *Template {
+	templates := g.scn.Logs.Templates
+	total := 0
+	for _, tmpl := range templates {
+		total += weight(tmpl)
+	}
+	n := g.rng.Intn(total)
+	for i := range templates {
+		if n -= weight(templates[i]); n < 0 {
+			return &templates[i]
+		}
+	}
+	return &templates[len(templates)-1]
+}
+
+func weight(tmpl Template) int {
+	if tmpl.Weight == 0 {
+		return 1
+	}
+	return tmpl.Weight
+}
+
+func (g *generator) fieldValue(name string, field Field) string {
+	if len(field.Values) > 0 {
+		return field.Values[g.rng.Intn(len(field.Values))]
+	}
+	return name + "-" + strconv.Itoa(g.rng.Intn(field.Cardinality))
+}
+
+func (g *generator) pickResource(service string) pcommon.Resource {
+	resources := g.resources[service]
+	return resources[g.rng.Intn(len(resources))]
+}
+
+func (g *generator) latency(l Latency) time.Duration {
+	d := time.Duration(sample(g.rng, l.Distribution, float64(l.Mean), float64(l.StdDev), float64(l.Min), float64(l.Max)))
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+func (g *generator) value(v Value) float64 {
+	return sample(g.rng, v.Distribution, v.Mean, v.StdDev, v.Min, v.Max)
+}
+
+// sample draws a value from the distribution, the value is clamped between
+// min and max when max is greater than min.
+func sample(rng *rand.Rand, distribution string, mean, stddev, min, max float64) float64 {
+	var v float64
+	switch distribution {
+	case "uniform":
+		v = min + rng.Float64()*(max-min)
+	case "normal":
+		v = mean + rng.NormFloat64()*stddev
+	case "exponential":
+		v = rng.ExpFloat64() * mean
+	default:
+		v = mean
+	}
+	if max > min {
+		v = math.Max(min, math.Min(max, v))
+	}
+	return v
+}
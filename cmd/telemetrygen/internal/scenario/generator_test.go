@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+func loadTestScenario(t *testing.T) *Scenario {
+	scn, err := Load(filepath.Join("testdata", "scenario.yaml"))
+	require.NoError(t, err)
+	return scn
+}
+
+// spansByService returns the spans of the traces by service name.
+func spansByService(td ptrace.Traces) map[string][]ptrace.Span {
+	spans := make(map[string][]ptrace.Span)
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		service, _ := rs.Resource().Attributes().Get("service.name")
+		ss := rs.ScopeSpans().At(0).Spans()
+		for j := 0; j < ss.Len(); j++ {
+			spans[service.Str()] = append(spans[service.Str()], ss.At(j))
+		}
+	}
+	return spans
+}
+
+func TestGenerator_traces(t *testing.T) {
+	now := time.Now()
+	g := newGenerator(loadTestScenario(t), map[string]string{"k8s.cluster.name": "test"}, 1, now)
+	td := g.traces(now)
+
+	assert.Equal(t, 6, td.SpanCount())
+	require.Equal(t, 3, td.ResourceSpans().Len())
+	cluster, ok := td.ResourceSpans().At(0).Resource().Attributes().Get("k8s.cluster.name")
+	require.True(t, ok)
+	assert.Equal(t, "test", cluster.Str())
+
+	spans := spansByService(td)
+	require.Len(t, spans["frontend"], 3)
+	require.Len(t, spans["checkout"], 2)
+	require.Len(t, spans["payment"], 1)
+
+	root, render, placeOrderClient := spans["frontend"][0], spans["frontend"][1], spans["frontend"][2]
+	assert.Equal(t, "GET /checkout", root.Name())
+	assert.Equal(t, ptrace.SpanKindServer, root.Kind())
+	assert.True(t, root.ParentSpanID().IsEmpty())
+	assert.Equal(t, "render", render.Name())
+	assert.Equal(t, ptrace.SpanKindInternal, render.Kind())
+	assert.Equal(t, root.SpanID(), render.ParentSpanID())
+	assert.Equal(t, ptrace.SpanKindClient, placeOrderClient.Kind())
+	assert.Equal(t, root.SpanID(), placeOrderClient.ParentSpanID())
+
+	placeOrder := spans["checkout"][0]
+	assert.Equal(t, ptrace.SpanKindServer, placeOrder.Kind())
+	assert.Equal(t, placeOrderClient.SpanID(), placeOrder.ParentSpanID())
+	assert.Equal(t, placeOrderClient.StartTimestamp(), placeOrder.StartTimestamp())
+	assert.Equal(t, placeOrderClient.EndTimestamp(), placeOrder.EndTimestamp())
+	// Children are called one after the other.
+	assert.Equal(t, render.EndTimestamp(), placeOrder.StartTimestamp())
+	assert.GreaterOrEqual(t, root.EndTimestamp(), placeOrder.EndTimestamp())
+
+	charge := spans["payment"][0]
+	assert.Equal(t, ptrace.StatusCodeError, charge.Status().Code())
+	assert.Equal(t, ptrace.StatusCodeError, spans["checkout"][1].Status().Code())
+	assert.Equal(t, ptrace.StatusCodeUnset, placeOrder.Status().Code())
+
+	for _, s := range []ptrace.Span{root, render, placeOrderClient, placeOrder, charge} {
+		assert.Equal(t, root.TraceID(), s.TraceID())
+	}
+}
+
+func TestGenerator_metrics(t *testing.T) {
+	now := time.Now()
+	g := newGenerator(loadTestScenario(t), nil, 1, now)
+	md := g.metrics(now, time.Second)
+
+	// 2 instances of frontend reporting 3 routes and 2 status codes, a histogram and a gauge.
+	assert.Equal(t, 4, md.ResourceMetrics().Len())
+	assert.Equal(t, 2*3*2+1+1, md.DataPointCount())
+
+	requests := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "http.server.request.count", requests.Name())
+	assert.True(t, requests.Sum().IsMonotonic())
+	first := requests.Sum().DataPoints().At(0)
+	route, _ := first.Attributes().Get("http.route")
+	assert.Equal(t, "http.route-0", route.Str())
+
+	next := g.metrics(now.Add(time.Second), time.Second).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.GreaterOrEqual(t, next.DoubleValue(), first.DoubleValue())
+	assert.Equal(t, first.StartTimestamp(), next.StartTimestamp())
+
+	duration := md.ResourceMetrics().At(2).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "ms", duration.Unit())
+	dp := duration.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, 250.0, dp.Sum())
+	assert.Equal(t, []uint64{0, 5, 0, 0}, dp.BucketCounts().AsRaw())
+
+	gauge := md.ResourceMetrics().At(3).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, 7.0, gauge.Gauge().DataPoints().At(0).DoubleValue())
+}
+
+func TestGenerator_logs(t *testing.T) {
+	now := time.Now()
+	g := newGenerator(loadTestScenario(t), nil, 1, now)
+
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		ld := g.logs(now)
+		require.Equal(t, 1, ld.LogRecordCount())
+		lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+		switch lr.SeverityNumber() {
+		case plog.SeverityNumberError:
+			assert.Equal(t, "ERROR", lr.SeverityText())
+			user, ok := lr.Attributes().Get("user")
+			require.True(t, ok)
+			assert.True(t, strings.HasPrefix(user.Str(), "user-"))
+			assert.True(t, strings.HasPrefix(lr.Body().Str(), "payment of "+user.Str()+" failed: "))
+		case plog.SeverityNumberInfo:
+			assert.Equal(t, "order placed", lr.Body().Str())
+		}
+		seen[lr.SeverityText()] = true
+	}
+	assert.Equal(t, map[string]bool{"ERROR": true, "INFO": true}, seen)
+}
+
+func TestAttributeCombinations(t *testing.T) {
+	assert.Equal(t, [][][2]string{nil}, attributeCombinations(nil))
+	assert.Equal(t, [][][2]string{
+		{{"a", "a-0"}, {"b", "b-0"}},
+		{{"a", "a-0"}, {"b", "b-1"}},
+		{{"a", "a-1"}, {"b", "b-0"}},
+		{{"a", "a-1"}, {"b", "b-1"}},
+	}, attributeCombinations(map[string]int{"b": 2, "a": 2}))
+}
+
+type mockExporter struct {
+	mu      sync.Mutex
+	traces  []ptrace.Traces
+	metrics []pmetric.Metrics
+	logs    []plog.Logs
+}
+
+func (m *mockExporter) ExportTraces(_ context.Context, td ptrace.Traces) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.traces = append(m.traces, td)
+	return nil
+}
+
+func (m *mockExporter) ExportMetrics(_ context.Context, md pmetric.Metrics) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = append(m.metrics, md)
+	return nil
+}
+
+func (m *mockExporter) ExportLogs(_ context.Context, ld plog.Logs) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = append(m.logs, ld)
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{TotalDuration: time.Second / 2},
+		Seed:   1,
+	}
+	exp := &mockExporter{}
+	require.NoError(t, Run(cfg, loadTestScenario(t), exp, zap.NewNop()))
+
+	// the rate of traces and logs is 10/sec, metrics are reported every second
+	assert.True(t, len(exp.traces) >= 5 && len(exp.traces) <= 20, "there should have been between 5 and 20 traces, had %d", len(exp.traces))
+	assert.True(t, len(exp.logs) >= 5 && len(exp.logs) <= 20, "there should have been between 5 and 20 logs, had %d", len(exp.logs))
+	assert.Len(t, exp.metrics, 1)
+}
+
+func TestRun_noDuration(t *testing.T) {
+	assert.EqualError(t, Run(&Config{}, loadTestScenario(t), &mockExporter{}, zap.NewNop()), "`duration` must be greater than 0")
+}
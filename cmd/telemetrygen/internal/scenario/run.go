@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+const defaultMetricsInterval = 10 * time.Second
+
+type exporter interface {
+	ExportTraces(context.Context, ptrace.Traces) error
+	ExportMetrics(context.Context, pmetric.Metrics) error
+	ExportLogs(context.Context, plog.Logs) error
+}
+
+// Start runs the scenario of the file of the config.
+func Start(cfg *Config) error {
+	logger, err := common.CreateLogger(cfg.SkipSettingGRPCLogger)
+	if err != nil {
+		return err
+	}
+
+	if cfg.File == "" {
+		return errors.New("a scenario `file` must be provided")
+	}
+	scn, err := Load(cfg.File)
+	if err != nil {
+		return err
+	}
+
+	exp, err := common.NewExporter(&cfg.Config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := exp.Shutdown(); err != nil {
+			logger.Error("failed to stop the exporter", zap.Error(err))
+		}
+	}()
+
+	return Run(cfg, scn, exp, logger)
+}
+
+// Run generates the telemetry of the scenario for the duration of the config.
+func Run(c *Config, scn *Scenario, exp exporter, logger *zap.Logger) error {
+	if c.TotalDuration <= 0 {
+		return errors.New("`duration` must be greater than 0")
+	}
+
+	seed := c.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	logger.Info("running scenario", zap.String("file", c.File), zap.Int64("seed", seed))
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.TotalDuration)
+	defer cancel()
+	startTime := time.Now()
+
+	wg := sync.WaitGroup{}
+	if scn.Traces != nil {
+		g := newGenerator(scn, c.ResourceAttributes, seed, startTime)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			generateAtRate(ctx, scn.Traces.Rate, func(now time.Time) {
+				if err := exp.ExportTraces(ctx, g.traces(now)); err != nil && ctx.Err() == nil {
+					logger.Error("failed to export traces", zap.Error(err))
+				}
+			})
+		}()
+	}
+	if scn.Logs != nil {
+		g := newGenerator(scn, c.ResourceAttributes, seed+1, startTime)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			generateAtRate(ctx, scn.Logs.Rate, func(now time.Time) {
+				if err := exp.ExportLogs(ctx, g.logs(now)); err != nil && ctx.Err() == nil {
+					logger.Error("failed to export logs", zap.Error(err))
+				}
+			})
+		}()
+	}
+	if scn.Metrics != nil {
+		g := newGenerator(scn, c.ResourceAttributes, seed+2, startTime)
+		interval := scn.Metrics.Interval
+		if interval == 0 {
+			interval = defaultMetricsInterval
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for now := time.Now(); ; {
+				if err := exp.ExportMetrics(ctx, g.metrics(now, interval)); err != nil && ctx.Err() == nil {
+					logger.Error("failed to export metrics", zap.Error(err))
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case now = <-ticker.C:
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// generateAtRate calls generate the given number of times per second until the
+// context is done, a zero rate means no throttling.
+func generateAtRate(ctx context.Context, perSecond float64, generate func(time.Time)) {
+	limit := rate.Limit(perSecond)
+	if perSecond == 0 {
+		limit = rate.Inf
+	}
+	limiter := rate.NewLimiter(limit, 1)
+	for {
+		if err := limiter.Wait(ctx); err != nil {
+			return
+		}
+		generate(time.Now())
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario describes the telemetry generated by a set of services.
+type Scenario struct {
+	// Services are the services emitting telemetry, every service referenced
+	// by spans, metric series and log templates must be declared here.
+	Services []Service `yaml:"services"`
+	Traces   *Traces   `yaml:"traces"`
+	Metrics  *Metrics  `yaml:"metrics"`
+	Logs     *Logs     `yaml:"logs"`
+}
+
+// Service is a service emitting telemetry.
+type Service struct {
+	Name string `yaml:"name"`
+	// Instances is the number of instances of the service, each instance is a
+	// different value of the service.instance.id resource attribute. Defaults to 1.
+	Instances          int               `yaml:"instances"`
+	ResourceAttributes map[string]string `yaml:"resource_attributes"`
+}
+
+// Traces describes the traces generated by the services.
+type Traces struct {
+	// Rate is the number of traces generated per second.
+	Rate float64 `yaml:"rate"`
+	// Root is the root span of the traces, the topology of the traces is the
+	// tree of spans under it.
+	Root Span `yaml:"root"`
+}
+
+// Span is a span of the trace topology.
+type Span struct {
+	Service string `yaml:"service"`
+	Name    string `yaml:"name"`
+	// Kind is one of server, client, internal, producer or consumer. Defaults to
+	// server for the root span and for spans called from another service, and to
+	// internal otherwise.
+	Kind    string  `yaml:"kind"`
+	Latency Latency `yaml:"latency"`
+	// ErrorRate is the probability for the span to have an error status.
+	ErrorRate  float64           `yaml:"error_rate"`
+	Attributes map[string]string `yaml:"attributes"`
+	// Children are called one after the other. A client span is recorded in the
+	// caller service for each child run by another service.
+	Children []Span `yaml:"children"`
+}
+
+// Metrics describes the metric series reported by the services.
+type Metrics struct {
+	// Interval at which the series are reported. Defaults to 10s.
+	Interval time.Duration `yaml:"interval"`
+	Series   []Series      `yaml:"series"`
+}
+
+// Series describes a metric reported by every instance of a service.
+type Series struct {
+	Name    string `yaml:"name"`
+	Service string `yaml:"service"`
+	// Type is one of gauge, sum or histogram. Sums are cumulative and monotonic,
+	// histograms have a delta temporality.
+	Type string `yaml:"type"`
+	Unit string `yaml:"unit"`
+	// Attributes maps the attribute keys of the metric to their number of
+	// distinct values, a data point is reported for every combination of values.
+	Attributes map[string]int `yaml:"attributes"`
+	Value      Value          `yaml:"value"`
+	// Buckets are the explicit bounds of histograms.
+	Buckets []float64 `yaml:"buckets"`
+	// Samples is the number of values recorded by histograms at each interval. Defaults to 1.
+	Samples int `yaml:"samples"`
+}
+
+// Logs describes the logs emitted by the services.
+type Logs struct {
+	// Rate is the number of logs generated per second.
+	Rate      float64    `yaml:"rate"`
+	Templates []Template `yaml:"templates"`
+}
+
+// Template is a log record template.
+type Template struct {
+	Service string `yaml:"service"`
+	// Severity is one of trace, debug, info, warn, error or fatal. Defaults to info.
+	Severity string `yaml:"severity"`
+	// Weight of the template relatively to the other templates. Defaults to 1.
+	Weight int `yaml:"weight"`
+	// Body of the log records, {{field}} placeholders are replaced by values of the fields.
+	Body string `yaml:"body"`
+	// Fields are the values substituted in the body, also set as attributes of the records.
+	Fields     map[string]Field  `yaml:"fields"`
+	Attributes map[string]string `yaml:"attributes"`
+}
+
+// Field describes the values of a log template field. The values are either
+// listed, or generated as "<field>-<n>" for n lower than the cardinality.
+type Field struct {
+	Values      []string `yaml:"values"`
+	Cardinality int      `yaml:"cardinality"`
+}
+
+// Latency is the distribution of the durations of a span.
+type Latency struct {
+	// Distribution is one of constant, uniform, normal or exponential. Defaults to constant.
+	Distribution string        `yaml:"distribution"`
+	Mean         time.Duration `yaml:"mean"`
+	StdDev       time.Duration `yaml:"stddev"`
+	Min          time.Duration `yaml:"min"`
+	Max          time.Duration `yaml:"max"`
+}
+
+// Value is the distribution of the values of a metric.
+type Value struct {
+	// Distribution is one of constant, uniform, normal or exponential. Defaults to constant.
+	Distribution string  `yaml:"distribution"`
+	Mean         float64 `yaml:"mean"`
+	StdDev       float64 `yaml:"stddev"`
+	Min          float64 `yaml:"min"`
+	Max          float64 `yaml:"max"`
+}
+
+var (
+	spanKinds = map[string]ptrace.SpanKind{
+		"server":   ptrace.SpanKindServer,
+		"client":   ptrace.SpanKindClient,
+		"internal": ptrace.SpanKindInternal,
+		"producer": ptrace.SpanKindProducer,
+		"consumer": ptrace.SpanKindConsumer,
+	}
+	severities = map[string]plog.SeverityNumber{
+		"trace": plog.SeverityNumberTrace,
+		"debug": plog.SeverityNumberDebug,
+		"info":  plog.SeverityNumberInfo,
+		"warn":  plog.SeverityNumberWarn,
+		"error": plog.SeverityNumberError,
+		"fatal": plog.SeverityNumberFatal,
+	}
+	distributions = map[string]bool{
+		"":            true,
+		"constant":    true,
+		"uniform":     true,
+		"normal":      true,
+		"exponential": true,
+	}
+)
+
+// Load reads and validates the scenario in the YAML file at path.
+func Load(path string) (*Scenario, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	scn := &Scenario{}
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err = dec.Decode(scn); err != nil {
+		return nil, fmt.Errorf("cannot parse scenario %s: %w", path, err)
+	}
+	if err = scn.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	return scn, nil
+}
+
+// Validate checks the scenario is consistent.
+func (s *Scenario) Validate() error {
+	if s.Traces == nil && s.Metrics == nil && s.Logs == nil {
+		return errors.New("at least one of traces, metrics or logs must be defined")
+	}
+
+	services := make(map[string]bool, len(s.Services))
+	for _, svc := range s.Services {
+		if svc.Name == "" {
+			return errors.New("services must have a name")
+		}
+		if services[svc.Name] {
+			return fmt.Errorf("service %q is defined more than once", svc.Name)
+		}
+		if svc.Instances < 0 {
+			return fmt.Errorf("service %q: instances must not be negative", svc.Name)
+		}
+		services[svc.Name] = true
+	}
+	checkService := func(name string) error {
+		if !services[name] {
+			return fmt.Errorf("unknown service %q", name)
+		}
+		return nil
+	}
+
+	if s.Traces != nil {
+		if s.Traces.Rate < 0 {
+			return errors.New("traces: rate must not be negative")
+		}
+		if err := validateSpan(&s.Traces.Root, checkService); err != nil {
+			return fmt.Errorf("traces: %w", err)
+		}
+	}
+
+	if s.Metrics != nil {
+		if s.Metrics.Interval < 0 {
+			return errors.New("metrics: interval must not be negative")
+		}
+		if len(s.Metrics.Series) == 0 {
+			return errors.New("metrics: at least one series must be defined")
+		}
+		for _, series := range s.Metrics.Series {
+			if err := validateSeries(series, checkService); err != nil {
+				return fmt.Errorf("metrics: %w", err)
+			}
+		}
+	}
+
+	if s.Logs != nil {
+		if s.Logs.Rate < 0 {
+			return errors.New("logs: rate must not be negative")
+		}
+		if len(s.Logs.Templates) == 0 {
+			return errors.New("logs: at least one template must be defined")
+		}
+		for i, tmpl := range s.Logs.Templates {
+			if err := validateTemplate(tmpl, checkService); err != nil {
+				return fmt.Errorf("logs: template %d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateSpan(span *Span, checkService func(string) error) error {
+	if span.Name == "" {
+		return errors.New("spans must have a name")
+	}
+	if err := checkService(span.Service); err != nil {
+		return fmt.Errorf("span %q: %w", span.Name, err)
+	}
+	if _, ok := spanKinds[span.Kind]; span.Kind != "" && !ok {
+		return fmt.Errorf("span %q: unknown kind %q", span.Name, span.Kind)
+	}
+	if span.ErrorRate < 0 || span.ErrorRate > 1 {
+		return fmt.Errorf("span %q: error_rate must be between 0 and 1", span.Name)
+	}
+	if !distributions[span.Latency.Distribution] {
+		return fmt.Errorf("span %q: unknown latency distribution %q", span.Name, span.Latency.Distribution)
+	}
+	for i := range span.Children {
+		if err := validateSpan(&span.Children[i], checkService); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSeries(series Series, checkService func(string) error) error {
+	if series.Name == "" {
+		return errors.New("series must have a name")
+	}
+	if err := checkService(series.Service); err != nil {
+		return fmt.Errorf("series %q: %w", series.Name, err)
+	}
+	switch series.Type {
+	case "gauge", "sum", "histogram":
+	default:
+		return fmt.Errorf("series %q: unknown type %q", series.Name, series.Type)
+	}
+	for key, cardinality := range series.Attributes {
+		if cardinality <= 0 {
+			return fmt.Errorf("series %q: cardinality of attribute %q must be positive", series.Name, key)
+		}
+	}
+	if !distributions[series.Value.Distribution] {
+		return fmt.Errorf("series %q: unknown value distribution %q", series.Name, series.Value.Distribution)
+	}
+	if series.Samples < 0 {
+		return fmt.Errorf("series %q: samples must not be negative", series.Name)
+	}
+	return nil
+}
+
+func validateTemplate(tmpl Template, checkService func(string) error) error {
+	if err := checkService(tmpl.Service); err != nil {
+		return err
+	}
+	if _, ok := severities[tmpl.Severity]; tmpl.Severity != "" && !ok {
+		return fmt.Errorf("unknown severity %q", tmpl.Severity)
+	}
+	if tmpl.Weight < 0 {
+		return errors.New("weight must not be negative")
+	}
+	for name, field := range tmpl.Fields {
+		if len(field.Values) == 0 && field.Cardinality <= 0 {
+			return fmt.Errorf("field %q must have values or a positive cardinality", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	scn, err := Load(filepath.Join("testdata", "scenario.yaml"))
+	require.NoError(t, err)
+
+	require.Len(t, scn.Services, 3)
+	assert.Equal(t, Service{
+		Name:               "frontend",
+		Instances:          2,
+		ResourceAttributes: map[string]string{"deployment.environment": "production"},
+	}, scn.Services[0])
+
+	require.NotNil(t, scn.Traces)
+	assert.Equal(t, 10.0, scn.Traces.Rate)
+	assert.Equal(t, Latency{Distribution: "normal", Mean: 20 * time.Millisecond, StdDev: 5 * time.Millisecond, Min: time.Millisecond}, scn.Traces.Root.Latency)
+	require.Len(t, scn.Traces.Root.Children, 2)
+	assert.Equal(t, 1.0, scn.Traces.Root.Children[1].Children[0].ErrorRate)
+
+	require.NotNil(t, scn.Metrics)
+	assert.Equal(t, time.Second, scn.Metrics.Interval)
+	assert.Equal(t, map[string]int{"http.route": 3, "http.status_code": 2}, scn.Metrics.Series[0].Attributes)
+
+	require.NotNil(t, scn.Logs)
+	assert.Equal(t, Field{Values: []string{"declined", "timeout"}}, scn.Logs.Templates[0].Fields["reason"])
+}
+
+func TestLoad_errors(t *testing.T) {
+	_, err := Load(filepath.Join("testdata", "invalid.yaml"))
+	assert.ErrorContains(t, err, `traces: span "GET /": unknown service "backend"`)
+
+	_, err = Load(filepath.Join("testdata", "doesnotexist.yaml"))
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	services := []Service{{Name: "frontend"}}
+	root := Span{Service: "frontend", Name: "GET /"}
+	tests := []struct {
+		name     string
+		scenario Scenario
+		err      string
+	}{
+		{
+			name: "empty",
+			err:  "at least one of traces, metrics or logs must be defined",
+		},
+		{
+			name: "duplicate service",
+			scenario: Scenario{
+				Services: []Service{{Name: "frontend"}, {Name: "frontend"}},
+				Traces:   &Traces{Root: root},
+			},
+			err: `service "frontend" is defined more than once`,
+		},
+		{
+			name: "span kind",
+			scenario: Scenario{
+				Services: services,
+				Traces:   &Traces{Root: Span{Service: "frontend", Name: "GET /", Kind: "remote"}},
+			},
+			err: `traces: span "GET /": unknown kind "remote"`,
+		},
+		{
+			name: "error rate",
+			scenario: Scenario{
+				Services: services,
+				Traces: &Traces{Root: Span{Service: "frontend", Name: "GET /", Children: []Span{
+					{Service: "frontend", Name: "render", ErrorRate: 2},
+				}}},
+			},
+			err: `traces: span "render": error_rate must be between 0 and 1`,
+		},
+		{
+			name: "latency distribution",
+			scenario: Scenario{
+				Services: services,
+				Traces:   &Traces{Root: Span{Service: "frontend", Name: "GET /", Latency: Latency{Distribution: "pareto"}}},
+			},
+			err: `traces: span "GET /": unknown latency distribution "pareto"`,
+		},
+		{
+			name: "series type",
+			scenario: Scenario{
+				Services: services,
+				Metrics:  &Metrics{Series: []Series{{Name: "requests", Service: "frontend", Type: "counter"}}},
+			},
+			err: `metrics: series "requests": unknown type "counter"`,
+		},
+		{
+			name: "series cardinality",
+			scenario: Scenario{
+				Services: services,
+				Metrics:  &Metrics{Series: []Series{{Name: "requests", Service: "frontend", Type: "sum", Attributes: map[string]int{"route": 0}}}},
+			},
+			err: `metrics: series "requests": cardinality of attribute "route" must be positive`,
+		},
+		{
+			name: "no templates",
+			scenario: Scenario{
+				Services: services,
+				Logs:     &Logs{},
+			},
+			err: "logs: at least one template must be defined",
+		},
+		{
+			name: "template field",
+			scenario: Scenario{
+				Services: services,
+				Logs:     &Logs{Templates: []Template{{Service: "frontend", Fields: map[string]Field{"user": {}}}}},
+			},
+			err: `logs: template 0: field "user" must have values or a positive cardinality`,
+		},
+		{
+			name: "severity",
+			scenario: Scenario{
+				Services: services,
+				Logs:     &Logs{Templates: []Template{{Service: "frontend", Severity: "critical"}}},
+			},
+			err: `logs: template 0: unknown severity "critical"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.scenario.Validate(), tt.err)
+		})
+	}
+}
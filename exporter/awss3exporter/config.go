@@ -7,6 +7,8 @@ import (
 	"errors"
 
 	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 // S3UploaderConfig contains aws s3 uploader related config to controls things
@@ -25,6 +27,7 @@ type MarshalerType string
 const (
 	OtlpJSON MarshalerType = "otlp_json"
 	SumoIC   MarshalerType = "sumo_ic"
+	Parquet  MarshalerType = "parquet"
 )
 
 // Config contains the main configuration options for the s3 exporter
@@ -33,6 +36,9 @@ type Config struct {
 	MarshalerName MarshalerType    `mapstructure:"marshaler"`
 
 	FileFormat string `mapstructure:"file_format"`
+
+	// Parquet configures the files written by the parquet marshaler.
+	Parquet parquet.Config `mapstructure:"parquet"`
 }
 
 func (c *Config) Validate() error {
@@ -43,5 +49,8 @@ func (c *Config) Validate() error {
 	if c.S3Uploader.S3Bucket == "" {
 		errs = multierr.Append(errs, errors.New("bucket is required"))
 	}
+	if c.MarshalerName == Parquet {
+		errs = multierr.Append(errs, c.Parquet.Validate())
+	}
 	return errs
 }
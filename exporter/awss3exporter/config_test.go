@@ -13,6 +13,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/otelcol/otelcoltest"
 	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -35,6 +37,7 @@ func TestLoadConfig(t *testing.T) {
 				S3Partition: "minute",
 			},
 			MarshalerName: "otlp_json",
+			Parquet:       parquet.DefaultConfig(),
 		},
 	)
 }
@@ -63,6 +66,7 @@ func TestConfig(t *testing.T) {
 				Endpoint:    "http://endpoint.com",
 			},
 			MarshalerName: "otlp_json",
+			Parquet:       parquet.DefaultConfig(),
 		},
 	)
 }
@@ -115,6 +119,17 @@ func TestConfig_Validate(t *testing.T) {
 			}(),
 			errExpected: errors.New("region is required"),
 		},
+		{
+			name: "invalid parquet settings",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.MarshalerName = Parquet
+				c.Parquet.RowGroupSize = 0
+				return c
+			}(),
+			errExpected: errors.New("row_group_size must be larger than zero"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -147,6 +162,37 @@ func TestMarshallerName(t *testing.T) {
 				S3Partition: "minute",
 			},
 			MarshalerName: "sumo_ic",
+			Parquet:       parquet.DefaultConfig(),
+		},
+	)
+}
+
+func TestParquetConfig(t *testing.T) {
+	factories, err := otelcoltest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[factory.Type()] = factory
+	cfg, err := otelcoltest.LoadConfigAndValidate(
+		filepath.Join("testdata", "parquet.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	e := cfg.Exporters[component.NewID("awss3")].(*Config)
+
+	assert.Equal(t, e,
+		&Config{
+			S3Uploader: S3UploaderConfig{
+				Region:      "us-east-1",
+				S3Bucket:    "foo",
+				S3Partition: "hour",
+			},
+			MarshalerName: "parquet",
+			Parquet: parquet.Config{
+				RowGroupSize: 10000,
+				Compression:  "zstd",
+			},
 		},
 	)
 }
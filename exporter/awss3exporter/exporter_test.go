@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 var testLogs = []byte(`{"resourceLogs":[{"resource":{"attributes":[{"key":"_sourceCategory","value":{"stringValue":"logfile"}},{"key":"_sourceHost","value":{"stringValue":"host"}}]},"scopeLogs":[{"scope":{},"logRecords":[{"observedTimeUnixNano":"1654257420681895000","body":{"stringValue":"2022-06-03 13:57:00.62739 +0200 CEST m=+14.018296742 log entry14"},"attributes":[{"key":"log.file.path_resolved","value":{"stringValue":"logwriter/data.log"}}],"traceId":"","spanId":""}]}],"schemaUrl":"https://opentelemetry.io/schemas/1.6.1"}]}`)
@@ -31,7 +33,7 @@ func getTestLogs(tb testing.TB) plog.Logs {
 }
 
 func getLogExporter(t *testing.T) *s3Exporter {
-	marshaler, _ := newMarshaler("otlp_json", zap.NewNop())
+	marshaler, _ := newMarshaler("otlp_json", parquet.DefaultConfig(), zap.NewNop())
 	exporter := &s3Exporter{
 		config:     createDefaultConfig().(*Config),
 		dataWriter: &TestWriter{t},
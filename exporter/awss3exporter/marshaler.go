@@ -10,6 +10,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 type marshaler interface {
@@ -23,7 +25,7 @@ var (
 	ErrUnknownMarshaler = errors.New("unknown marshaler")
 )
 
-func newMarshaler(mType MarshalerType, logger *zap.Logger) (marshaler, error) {
+func newMarshaler(mType MarshalerType, parquetConfig parquet.Config, logger *zap.Logger) (marshaler, error) {
 	marshaler := &s3Marshaler{logger: logger}
 	switch mType {
 	case OtlpJSON:
@@ -35,6 +37,15 @@ func newMarshaler(mType MarshalerType, logger *zap.Logger) (marshaler, error) {
 		sumomarshaler := newSumoICMarshaler()
 		marshaler.logsMarshaler = &sumomarshaler
 		marshaler.fileFormat = "json.gz"
+	case Parquet:
+		parquetMarshaler, err := parquet.NewMarshaler(parquetConfig)
+		if err != nil {
+			return nil, err
+		}
+		marshaler.logsMarshaler = parquetMarshaler
+		marshaler.tracesMarshaler = parquetMarshaler
+		marshaler.metricsMarshaler = parquetMarshaler
+		marshaler.fileFormat = "parquet"
 	default:
 		return nil, ErrUnknownMarshaler
 	}
@@ -9,23 +9,36 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 func TestMarshaler(t *testing.T) {
 	{
-		m, err := newMarshaler("otlp_json", zap.NewNop())
+		m, err := newMarshaler("otlp_json", parquet.DefaultConfig(), zap.NewNop())
 		assert.NoError(t, err)
 		require.NotNil(t, m)
 		assert.Equal(t, m.format(), "json")
 	}
 	{
-		m, err := newMarshaler("sumo_ic", zap.NewNop())
+		m, err := newMarshaler("sumo_ic", parquet.DefaultConfig(), zap.NewNop())
 		assert.NoError(t, err)
 		require.NotNil(t, m)
 		assert.Equal(t, m.format(), "json.gz")
 	}
 	{
-		m, err := newMarshaler("unknown", zap.NewNop())
+		m, err := newMarshaler("parquet", parquet.DefaultConfig(), zap.NewNop())
+		assert.NoError(t, err)
+		require.NotNil(t, m)
+		assert.Equal(t, m.format(), "parquet")
+	}
+	{
+		m, err := newMarshaler("parquet", parquet.Config{}, zap.NewNop())
+		assert.Error(t, err)
+		require.Nil(t, m)
+	}
+	{
+		m, err := newMarshaler("unknown", parquet.DefaultConfig(), zap.NewNop())
 		assert.Error(t, err)
 		require.Nil(t, m)
 	}
@@ -40,6 +40,54 @@ type Config struct {
 	MetricsTableName string `mapstructure:"metrics_table_name"`
 	// TTLDays is The data time-to-live in days, 0 means no ttl.
 	TTLDays uint `mapstructure:"ttl_days"`
+	// Schema configures how the exporter manages the schema of its tables.
+	Schema SchemaSettings `mapstructure:"schema"`
+}
+
+// SchemaSettings configures the schema migrations applied by the exporter at start.
+type SchemaSettings struct {
+	// Mode is one of `apply`, `check`, `dry_run` or `disabled`. default is `apply`.
+	Mode string `mapstructure:"mode"`
+	// MigrationsTableName is the table recording the applied migrations. default is `otel_schema_migrations`.
+	MigrationsTableName string `mapstructure:"migrations_table_name"`
+	// Logs are the user-supplied extensions of the logs table.
+	Logs SchemaExtensions `mapstructure:"logs"`
+	// Traces are the user-supplied extensions of the traces table.
+	Traces SchemaExtensions `mapstructure:"traces"`
+	// Metrics are the user-supplied extensions of the metrics tables.
+	Metrics SchemaExtensions `mapstructure:"metrics"`
+}
+
+// SchemaExtensions are user-supplied columns and materialized views, applied after the schema migrations.
+type SchemaExtensions struct {
+	// Columns are added to the table, for example to promote an attribute to a column.
+	Columns []ColumnSettings `mapstructure:"columns"`
+	// MaterializedViews are created once the table exists.
+	MaterializedViews []MaterializedViewSettings `mapstructure:"materialized_views"`
+}
+
+// ColumnSettings defines a column added to a table.
+type ColumnSettings struct {
+	// Name is the name of the column.
+	Name string `mapstructure:"name"`
+	// Type is the ClickHouse type of the column, e.g. `LowCardinality(String)`.
+	Type string `mapstructure:"type"`
+	// Expression the column is materialized from, e.g. `LogAttributes['http.method']`.
+	// The column is left empty on insert if no expression is set.
+	Expression string `mapstructure:"expression"`
+}
+
+// MaterializedViewSettings defines a materialized view created on a table.
+type MaterializedViewSettings struct {
+	// Name is the name of the view.
+	Name string `mapstructure:"name"`
+	// To is the existing table the view writes to. Exclusive with Engine.
+	To string `mapstructure:"to"`
+	// Engine is the engine of the table the view stores its data in, e.g. `SummingMergeTree ORDER BY ServiceName`.
+	// Exclusive with To.
+	Engine string `mapstructure:"engine"`
+	// Query is the SELECT query of the view.
+	Query string `mapstructure:"query"`
 }
 
 // QueueSettings is a subset of exporterhelper.QueueSettings.
@@ -50,9 +98,17 @@ type QueueSettings struct {
 
 const defaultDatabase = "default"
 
+const (
+	schemaModeApply    = "apply"
+	schemaModeCheck    = "check"
+	schemaModeDryRun   = "dry_run"
+	schemaModeDisabled = "disabled"
+)
+
 var (
 	errConfigNoEndpoint      = errors.New("endpoint must be specified")
 	errConfigInvalidEndpoint = errors.New("endpoint must be url format")
+	errConfigInvalidSchema   = errors.New("invalid schema")
 )
 
 // Validate the clickhouse server configuration.
@@ -71,6 +127,35 @@ func (cfg *Config) Validate() (err error) {
 		err = errors.Join(err, e)
 	}
 
+	err = errors.Join(err, cfg.Schema.validate())
+
+	return err
+}
+
+func (s *SchemaSettings) validate() (err error) {
+	switch s.Mode {
+	case schemaModeApply, schemaModeCheck, schemaModeDryRun, schemaModeDisabled:
+	default:
+		err = errors.Join(err, fmt.Errorf("%w: unknown mode %q", errConfigInvalidSchema, s.Mode))
+	}
+	if s.MigrationsTableName == "" {
+		err = errors.Join(err, fmt.Errorf("%w: migrations_table_name must be specified", errConfigInvalidSchema))
+	}
+	for _, ext := range []SchemaExtensions{s.Logs, s.Traces, s.Metrics} {
+		for _, c := range ext.Columns {
+			if c.Name == "" || c.Type == "" {
+				err = errors.Join(err, fmt.Errorf("%w: column name and type must be specified", errConfigInvalidSchema))
+			}
+		}
+		for _, v := range ext.MaterializedViews {
+			if v.Name == "" || v.Query == "" {
+				err = errors.Join(err, fmt.Errorf("%w: materialized view name and query must be specified", errConfigInvalidSchema))
+			}
+			if (v.To == "") == (v.Engine == "") {
+				err = errors.Join(err, fmt.Errorf("%w: materialized view %q must specify exactly one of to or engine", errConfigInvalidSchema, v.Name))
+			}
+		}
+	}
 	return err
 }
 
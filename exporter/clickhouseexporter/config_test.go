@@ -66,6 +66,27 @@ func TestLoadConfig(t *testing.T) {
 				QueueSettings: QueueSettings{
 					QueueSize: 100,
 				},
+				Schema: SchemaSettings{
+					Mode:                schemaModeCheck,
+					MigrationsTableName: "schema_migrations",
+					Logs: SchemaExtensions{
+						Columns: []ColumnSettings{
+							{Name: "TraceFlags", Type: "UInt32"},
+						},
+					},
+					Traces: SchemaExtensions{
+						Columns: []ColumnSettings{
+							{Name: "HttpMethod", Type: "LowCardinality(String)", Expression: "SpanAttributes['http.method']"},
+						},
+						MaterializedViews: []MaterializedViewSettings{
+							{
+								Name:  "otel_traces_by_service",
+								To:    "otel_traces_by_service_local",
+								Query: "SELECT ServiceName, count() AS Spans FROM otel_traces GROUP BY ServiceName",
+							},
+						},
+					},
+				},
 			},
 		},
 	}
@@ -267,3 +288,58 @@ func TestConfig_buildDSN(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_validateSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  func(*SchemaSettings)
+		wantErr string
+	}{
+		{
+			name:   "default",
+			schema: func(*SchemaSettings) {},
+		},
+		{
+			name:    "unknown mode",
+			schema:  func(s *SchemaSettings) { s.Mode = "auto" },
+			wantErr: `unknown mode "auto"`,
+		},
+		{
+			name:    "no migrations table",
+			schema:  func(s *SchemaSettings) { s.MigrationsTableName = "" },
+			wantErr: "migrations_table_name must be specified",
+		},
+		{
+			name: "column without type",
+			schema: func(s *SchemaSettings) {
+				s.Logs.Columns = []ColumnSettings{{Name: "TraceFlags"}}
+			},
+			wantErr: "column name and type must be specified",
+		},
+		{
+			name: "materialized view with both to and engine",
+			schema: func(s *SchemaSettings) {
+				s.Metrics.MaterializedViews = []MaterializedViewSettings{
+					{Name: "view", To: "table", Engine: "MergeTree ORDER BY Name", Query: "SELECT 1"},
+				}
+			},
+			wantErr: `materialized view "view" must specify exactly one of to or engine`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+			})
+			tt.schema(&cfg.Schema)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, errConfigInvalidSchema)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
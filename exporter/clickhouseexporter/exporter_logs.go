@@ -42,11 +42,7 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*logsExporter, error) {
 }
 
 func (e *logsExporter) start(ctx context.Context, _ component.Host) error {
-	if err := createDatabase(ctx, e.cfg); err != nil {
-		return err
-	}
-
-	return createLogsTable(ctx, e.cfg, e.client)
+	return migrateSchema(ctx, e.cfg, e.client, e.logger, logsMigrations(e.cfg))
 }
 
 // shutdown will shut down the exporter.
@@ -226,13 +222,6 @@ func createDatabase(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
-func createLogsTable(ctx context.Context, cfg *Config, db *sql.DB) error {
-	if _, err := db.ExecContext(ctx, renderCreateLogsTableSQL(cfg)); err != nil {
-		return fmt.Errorf("exec create logs table sql: %w", err)
-	}
-	return nil
-}
-
 func renderCreateLogsTableSQL(cfg *Config) string {
 	var ttlExpr string
 	if cfg.TTLDays > 0 {
@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -51,7 +52,7 @@ func TestLogsExporter_New(t *testing.T) {
 	}{
 		"no dsn": {
 			config: withDefaultConfig(),
-			want:   failWithMsg("exec create logs table sql: parse dsn address failed"),
+			want:   failWithMsg("exec create schema migrations table sql: parse dsn address failed"),
 		},
 	}
 
@@ -135,6 +136,8 @@ func withTestExporterConfig(fns ...func(*Config)) func(string) *Config {
 		var configMods []func(*Config)
 		configMods = append(configMods, func(cfg *Config) {
 			cfg.Endpoint = endpoint
+			// Schema migrations are tested on their own, keep them out of the recorded queries.
+			cfg.Schema.Mode = schemaModeDisabled
 		})
 		configMods = append(configMods, fns...)
 		return withDefaultConfig(configMods...)
@@ -165,32 +168,44 @@ func mustPushLogsData(t *testing.T, exporter *logsExporter, ld plog.Logs) {
 }
 
 func initClickhouseTestServer(t *testing.T, recorder recorder) {
+	initClickhouseTestServerWithRows(t, recorder, nil)
+}
+
+// initClickhouseTestServerWithRows registers a test driver answering queries with the rows returned by queryer.
+func initClickhouseTestServerWithRows(t *testing.T, recorder recorder, queryer queryer) {
 	driverName = t.Name()
 	sql.Register(t.Name(), &testClickhouseDriver{
 		recorder: recorder,
+		queryer:  queryer,
 	})
 }
 
 type recorder func(query string, values []driver.Value) error
 
+type queryer func(query string, values []driver.Value) [][]driver.Value
+
 type testClickhouseDriver struct {
 	recorder recorder
+	queryer  queryer
 }
 
 func (t *testClickhouseDriver) Open(_ string) (driver.Conn, error) {
 	return &testClickhouseDriverConn{
 		recorder: t.recorder,
+		queryer:  t.queryer,
 	}, nil
 }
 
 type testClickhouseDriverConn struct {
 	recorder recorder
+	queryer  queryer
 }
 
 func (t *testClickhouseDriverConn) Prepare(query string) (driver.Stmt, error) {
 	return &testClickhouseDriverStmt{
 		query:    query,
 		recorder: t.recorder,
+		queryer:  t.queryer,
 	}, nil
 }
 
@@ -209,6 +224,7 @@ func (*testClickhouseDriverConn) CheckNamedValue(_ *driver.NamedValue) error {
 type testClickhouseDriverStmt struct {
 	query    string
 	recorder recorder
+	queryer  queryer
 }
 
 func (*testClickhouseDriverStmt) Close() error {
@@ -223,8 +239,33 @@ func (t *testClickhouseDriverStmt) Exec(args []driver.Value) (driver.Result, err
 	return nil, t.recorder(t.query, args)
 }
 
-func (t *testClickhouseDriverStmt) Query(_ []driver.Value) (driver.Rows, error) {
-	return nil, nil
+func (t *testClickhouseDriverStmt) Query(args []driver.Value) (driver.Rows, error) {
+	rows := &testClickhouseDriverRows{}
+	if t.queryer != nil {
+		rows.values = t.queryer(t.query, args)
+	}
+	return rows, nil
+}
+
+type testClickhouseDriverRows struct {
+	values [][]driver.Value
+}
+
+func (*testClickhouseDriverRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (*testClickhouseDriverRows) Close() error {
+	return nil
+}
+
+func (r *testClickhouseDriverRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
 }
 
 type testClickhouseDriverTx struct {
@@ -37,12 +37,8 @@ func newMetricsExporter(logger *zap.Logger, cfg *Config) (*metricsExporter, erro
 }
 
 func (e *metricsExporter) start(ctx context.Context, _ component.Host) error {
-	if err := createDatabase(ctx, e.cfg); err != nil {
-		return err
-	}
-
 	internal.SetLogger(e.logger)
-	return internal.NewMetricsTable(ctx, e.cfg.MetricsTableName, e.cfg.TTLDays, e.client)
+	return migrateSchema(ctx, e.cfg, e.client, e.logger, metricsMigrations(e.cfg))
 }
 
 // shutdown will shut down the exporter.
@@ -38,6 +38,10 @@ func createDefaultConfig() component.Config {
 		TracesTableName:  "otel_traces",
 		MetricsTableName: "otel_metrics",
 		TTLDays:          0,
+		Schema: SchemaSettings{
+			Mode:                schemaModeApply,
+			MigrationsTableName: "otel_schema_migrations",
+		},
 	}
 }
 
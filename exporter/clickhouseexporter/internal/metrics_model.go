@@ -18,12 +18,12 @@ import (
 	"go.uber.org/zap"
 )
 
-var supportedMetricTypes = map[string]struct{}{
-	createGaugeTableSQL:        {},
-	createSumTableSQL:          {},
-	createHistogramTableSQL:    {},
-	createExpHistogramTableSQL: {},
-	createSummaryTableSQL:      {},
+var supportedMetricTypes = []string{
+	createGaugeTableSQL,
+	createSumTableSQL,
+	createHistogramTableSQL,
+	createExpHistogramTableSQL,
+	createSummaryTableSQL,
 }
 
 var logger *zap.Logger
@@ -50,19 +50,28 @@ func SetLogger(l *zap.Logger) {
 	logger = l
 }
 
-// NewMetricsTable create metric tables with an expiry time to storage metric telemetry data
-func NewMetricsTable(ctx context.Context, tableName string, ttlDays uint, db *sql.DB) error {
+// NewMetricsTableSQL returns the statements creating the metric tables with an expiry time to storage metric telemetry data
+func NewMetricsTableSQL(tableName string, ttlDays uint) []string {
 	var ttlExpr string
 	if ttlDays > 0 {
 		ttlExpr = fmt.Sprintf(`TTL toDateTime(TimeUnix) + toIntervalDay(%d)`, ttlDays)
 	}
-	for table := range supportedMetricTypes {
-		query := fmt.Sprintf(table, tableName, ttlExpr)
-		if _, err := db.ExecContext(ctx, query); err != nil {
-			return fmt.Errorf("exec create metrics table sql: %w", err)
-		}
+	queries := make([]string, 0, len(supportedMetricTypes))
+	for _, table := range supportedMetricTypes {
+		queries = append(queries, fmt.Sprintf(table, tableName, ttlExpr))
+	}
+	return queries
+}
+
+// MetricsTableNames returns the names of the tables storing each type of metric
+func MetricsTableNames(tableName string) []string {
+	return []string{
+		tableName + "_gauge",
+		tableName + "_sum",
+		tableName + "_histogram",
+		tableName + "_exponential_histogram",
+		tableName + "_summary",
 	}
-	return nil
 }
 
 // NewMetricsModel create a model for contain different metric data
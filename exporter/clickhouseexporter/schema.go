@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+)
+
+// migration is a change to the schema of the tables of a signal.
+// The ID of a migration is recorded in the migrations table once it has been applied,
+// so that every migration is applied only once. IDs are prefixed with the name of the
+// table of the signal, since exporters writing to different tables may share a database.
+//
+// Released migrations must never be changed: schema changes are added as a new migration
+// with the next version, e.g. `otel_logs/2`, so that existing tables are migrated on upgrade.
+type migration struct {
+	id         string
+	statements []string
+}
+
+var errPendingMigrations = errors.New("pending schema migrations")
+
+const (
+	// language=ClickHouse SQL
+	createMigrationsTableSQL = `
+CREATE TABLE IF NOT EXISTS %s (
+     ID String,
+     AppliedAt DateTime DEFAULT now()
+) ENGINE MergeTree()
+ORDER BY ID;
+`
+	// language=ClickHouse SQL
+	existsMigrationsTableSQL = `SELECT name FROM system.tables WHERE database = currentDatabase() AND name = ?`
+	// language=ClickHouse SQL
+	selectMigrationsSQL = `SELECT ID FROM %s`
+	// language=ClickHouse SQL
+	insertMigrationSQL = `INSERT INTO %s (ID) VALUES (?)`
+)
+
+func logsMigrations(cfg *Config) []migration {
+	migrations := []migration{
+		{id: migrationID(cfg.LogsTableName, "1"), statements: []string{renderCreateLogsTableSQL(cfg)}},
+	}
+	return append(migrations, extensionMigrations(cfg.LogsTableName, []string{cfg.LogsTableName}, cfg.Schema.Logs)...)
+}
+
+func tracesMigrations(cfg *Config) []migration {
+	migrations := []migration{
+		{id: migrationID(cfg.TracesTableName, "1"), statements: []string{
+			renderCreateTracesTableSQL(cfg),
+			renderCreateTraceIDTsTableSQL(cfg),
+			renderTraceIDTsMaterializedViewSQL(cfg),
+		}},
+	}
+	return append(migrations, extensionMigrations(cfg.TracesTableName, []string{cfg.TracesTableName}, cfg.Schema.Traces)...)
+}
+
+func metricsMigrations(cfg *Config) []migration {
+	migrations := []migration{
+		{id: migrationID(cfg.MetricsTableName, "1"), statements: internal.NewMetricsTableSQL(cfg.MetricsTableName, cfg.TTLDays)},
+	}
+	return append(migrations, extensionMigrations(cfg.MetricsTableName, internal.MetricsTableNames(cfg.MetricsTableName), cfg.Schema.Metrics)...)
+}
+
+// extensionMigrations returns the migrations adding the user-supplied columns and materialized views.
+// Extensions are identified by their name: changing the definition of an extension that has already
+// been applied has no effect.
+func extensionMigrations(table string, tables []string, ext SchemaExtensions) []migration {
+	var migrations []migration
+	for _, c := range ext.Columns {
+		column := fmt.Sprintf("%s %s", c.Name, c.Type)
+		if c.Expression != "" {
+			column = fmt.Sprintf("%s MATERIALIZED %s", column, c.Expression)
+		}
+		m := migration{id: migrationID(table, "column", c.Name)}
+		for _, table := range tables {
+			m.statements = append(m.statements, fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s", table, column))
+		}
+		migrations = append(migrations, m)
+	}
+	for _, v := range ext.MaterializedViews {
+		target := fmt.Sprintf("TO %s", v.To)
+		if v.Engine != "" {
+			target = fmt.Sprintf("ENGINE = %s", v.Engine)
+		}
+		migrations = append(migrations, migration{
+			id:         migrationID(table, "materialized_view", v.Name),
+			statements: []string{fmt.Sprintf("CREATE MATERIALIZED VIEW IF NOT EXISTS %s %s AS %s", v.Name, target, v.Query)},
+		})
+	}
+	return migrations
+}
+
+// migrationID returns the ID of a migration of the given table.
+func migrationID(table string, parts ...string) string {
+	return strings.Join(append([]string{table}, parts...), "/")
+}
+
+// migrateSchema applies the pending migrations, checks that there are none, or logs them,
+// depending on the schema mode.
+func migrateSchema(ctx context.Context, cfg *Config, db *sql.DB, logger *zap.Logger, migrations []migration) error {
+	mode := cfg.Schema.Mode
+	if mode == schemaModeDisabled {
+		return nil
+	}
+
+	if mode == schemaModeApply {
+		if err := createDatabase(ctx, cfg); err != nil {
+			return err
+		}
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(createMigrationsTableSQL, cfg.Schema.MigrationsTableName)); err != nil {
+			return fmt.Errorf("exec create schema migrations table sql: %w", err)
+		}
+	}
+
+	applied, err := appliedMigrations(ctx, cfg, db)
+	if err != nil {
+		return err
+	}
+	var pending []migration
+	for _, m := range migrations {
+		if _, ok := applied[m.id]; !ok {
+			pending = append(pending, m)
+		}
+	}
+
+	switch mode {
+	case schemaModeCheck:
+		if len(pending) > 0 {
+			ids := make([]string, 0, len(pending))
+			for _, m := range pending {
+				ids = append(ids, m.id)
+			}
+			return fmt.Errorf("%w: %s", errPendingMigrations, strings.Join(ids, ", "))
+		}
+	case schemaModeDryRun:
+		for _, m := range pending {
+			for _, statement := range m.statements {
+				logger.Info("Pending schema migration", zap.String("migration", m.id), zap.String("statement", statement))
+			}
+		}
+	case schemaModeApply:
+		insertSQL := fmt.Sprintf(insertMigrationSQL, cfg.Schema.MigrationsTableName)
+		for _, m := range pending {
+			for _, statement := range m.statements {
+				if _, err := db.ExecContext(ctx, statement); err != nil {
+					return fmt.Errorf("exec schema migration %s: %w", m.id, err)
+				}
+			}
+			if _, err := db.ExecContext(ctx, insertSQL, m.id); err != nil {
+				return fmt.Errorf("record schema migration %s: %w", m.id, err)
+			}
+			logger.Info("Applied schema migration", zap.String("migration", m.id))
+		}
+	}
+	return nil
+}
+
+// appliedMigrations returns the IDs of the migrations recorded in the migrations table.
+// No migration has been applied if the table does not exist.
+func appliedMigrations(ctx context.Context, cfg *Config, db *sql.DB) (map[string]struct{}, error) {
+	exists, err := queryStrings(ctx, db, existsMigrationsTableSQL, cfg.Schema.MigrationsTableName)
+	if err != nil {
+		return nil, fmt.Errorf("query schema migrations table: %w", err)
+	}
+	applied := make(map[string]struct{})
+	if len(exists) == 0 {
+		return applied, nil
+	}
+
+	ids, err := queryStrings(ctx, db, fmt.Sprintf(selectMigrationsSQL, cfg.Schema.MigrationsTableName))
+	if err != nil {
+		return nil, fmt.Errorf("query applied schema migrations: %w", err)
+	}
+	for _, id := range ids {
+		applied[id] = struct{}{}
+	}
+	return applied, nil
+}
+
+func queryStrings(ctx context.Context, db *sql.DB, query string, args ...any) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// migrationsServer is a test server keeping the IDs of the recorded migrations.
+type migrationsServer struct {
+	mu         sync.Mutex
+	tableFound bool
+	applied    []string
+	queries    []string
+}
+
+func newMigrationsServer(t *testing.T, applied ...string) *migrationsServer {
+	s := &migrationsServer{tableFound: len(applied) > 0, applied: applied}
+	initClickhouseTestServerWithRows(t, s.exec, s.query)
+	return s
+}
+
+func (s *migrationsServer) exec(query string, values []driver.Value) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queries = append(s.queries, query)
+	if strings.HasPrefix(query, "CREATE TABLE IF NOT EXISTS otel_schema_migrations") {
+		s.tableFound = true
+	}
+	if strings.HasPrefix(query, "INSERT INTO otel_schema_migrations") {
+		s.applied = append(s.applied, values[0].(string))
+	}
+	return nil
+}
+
+func (s *migrationsServer) query(query string, _ []driver.Value) [][]driver.Value {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if strings.HasPrefix(query, "SELECT name FROM system.tables") {
+		if s.tableFound {
+			return [][]driver.Value{{"otel_schema_migrations"}}
+		}
+		return nil
+	}
+	var rows [][]driver.Value
+	for _, id := range s.applied {
+		rows = append(rows, []driver.Value{id})
+	}
+	return rows
+}
+
+func newTestSchemaConfig(fns ...func(*Config)) *Config {
+	return withDefaultConfig(append([]func(*Config){func(cfg *Config) {
+		cfg.Endpoint = defaultEndpoint
+	}}, fns...)...)
+}
+
+func TestMigrateSchema(t *testing.T) {
+	t.Run("apply", func(t *testing.T) {
+		server := newMigrationsServer(t)
+		cfg := newTestSchemaConfig()
+		db, err := newClickhouseClient(cfg)
+		require.NoError(t, err)
+		defer db.Close()
+
+		require.NoError(t, migrateSchema(context.TODO(), cfg, db, zaptest.NewLogger(t), logsMigrations(cfg)))
+		assert.Equal(t, []string{"otel_logs/1"}, server.applied)
+
+		// Applied migrations are not applied again.
+		queries := len(server.queries)
+		require.NoError(t, migrateSchema(context.TODO(), cfg, db, zaptest.NewLogger(t), logsMigrations(cfg)))
+		assert.Equal(t, []string{"otel_logs/1"}, server.applied)
+		assert.Len(t, server.queries, queries+1, "only the migrations table should be created")
+	})
+
+	t.Run("apply to tables sharing a database", func(t *testing.T) {
+		server := newMigrationsServer(t)
+		cfg := newTestSchemaConfig()
+		other := newTestSchemaConfig(func(cfg *Config) {
+			cfg.LogsTableName = "otel_audit_logs"
+		})
+		db, err := newClickhouseClient(cfg)
+		require.NoError(t, err)
+		defer db.Close()
+
+		require.NoError(t, migrateSchema(context.TODO(), cfg, db, zaptest.NewLogger(t), logsMigrations(cfg)))
+		require.NoError(t, migrateSchema(context.TODO(), other, db, zaptest.NewLogger(t), logsMigrations(other)))
+		assert.Equal(t, []string{"otel_logs/1", "otel_audit_logs/1"}, server.applied)
+
+		var created []string
+		for _, query := range server.queries {
+			if strings.Contains(query, "CREATE TABLE IF NOT EXISTS otel_logs ") || strings.Contains(query, "CREATE TABLE IF NOT EXISTS otel_audit_logs ") {
+				created = append(created, query)
+			}
+		}
+		assert.Len(t, created, 2, "the table of each exporter should be created")
+	})
+
+	t.Run("apply extensions", func(t *testing.T) {
+		server := newMigrationsServer(t, "otel_traces/1")
+		cfg := newTestSchemaConfig(func(cfg *Config) {
+			cfg.Schema.Traces = SchemaExtensions{
+				Columns: []ColumnSettings{
+					{Name: "HttpMethod", Type: "LowCardinality(String)", Expression: "SpanAttributes['http.method']"},
+				},
+				MaterializedViews: []MaterializedViewSettings{
+					{Name: "otel_traces_by_service", Engine: "SummingMergeTree ORDER BY ServiceName", Query: "SELECT ServiceName, count() AS Spans FROM otel_traces GROUP BY ServiceName"},
+				},
+			}
+		})
+		db, err := newClickhouseClient(cfg)
+		require.NoError(t, err)
+		defer db.Close()
+
+		require.NoError(t, migrateSchema(context.TODO(), cfg, db, zaptest.NewLogger(t), tracesMigrations(cfg)))
+		assert.Equal(t, []string{"otel_traces/1", "otel_traces/column/HttpMethod", "otel_traces/materialized_view/otel_traces_by_service"}, server.applied)
+		assert.Contains(t, server.queries, "ALTER TABLE otel_traces ADD COLUMN IF NOT EXISTS HttpMethod LowCardinality(String) MATERIALIZED SpanAttributes['http.method']")
+		assert.Contains(t, server.queries, "CREATE MATERIALIZED VIEW IF NOT EXISTS otel_traces_by_service ENGINE = SummingMergeTree ORDER BY ServiceName AS SELECT ServiceName, count() AS Spans FROM otel_traces GROUP BY ServiceName")
+	})
+
+	t.Run("apply metrics column to every metrics table", func(t *testing.T) {
+		cfg := newTestSchemaConfig(func(cfg *Config) {
+			cfg.Schema.Metrics.Columns = []ColumnSettings{{Name: "Cluster", Type: "String"}}
+		})
+		migrations := metricsMigrations(cfg)
+		require.Len(t, migrations, 2)
+		assert.Equal(t, "otel_metrics/column/Cluster", migrations[1].id)
+		assert.Equal(t, []string{
+			"ALTER TABLE otel_metrics_gauge ADD COLUMN IF NOT EXISTS Cluster String",
+			"ALTER TABLE otel_metrics_sum ADD COLUMN IF NOT EXISTS Cluster String",
+			"ALTER TABLE otel_metrics_histogram ADD COLUMN IF NOT EXISTS Cluster String",
+			"ALTER TABLE otel_metrics_exponential_histogram ADD COLUMN IF NOT EXISTS Cluster String",
+			"ALTER TABLE otel_metrics_summary ADD COLUMN IF NOT EXISTS Cluster String",
+		}, migrations[1].statements)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		server := newMigrationsServer(t)
+		cfg := newTestSchemaConfig(func(cfg *Config) {
+			cfg.Schema.Mode = schemaModeCheck
+		})
+		db, err := newClickhouseClient(cfg)
+		require.NoError(t, err)
+		defer db.Close()
+
+		err = migrateSchema(context.TODO(), cfg, db, zaptest.NewLogger(t), logsMigrations(cfg))
+		assert.ErrorIs(t, err, errPendingMigrations)
+		assert.ErrorContains(t, err, "otel_logs/1")
+		assert.Empty(t, server.queries)
+
+		server.tableFound = true
+		server.applied = []string{"otel_logs/1"}
+		assert.NoError(t, migrateSchema(context.TODO(), cfg, db, zaptest.NewLogger(t), logsMigrations(cfg)))
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		server := newMigrationsServer(t)
+		cfg := newTestSchemaConfig(func(cfg *Config) {
+			cfg.Schema.Mode = schemaModeDryRun
+		})
+		db, err := newClickhouseClient(cfg)
+		require.NoError(t, err)
+		defer db.Close()
+
+		require.NoError(t, migrateSchema(context.TODO(), cfg, db, zaptest.NewLogger(t), metricsMigrations(cfg)))
+		assert.Empty(t, server.queries)
+		assert.Empty(t, server.applied)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		server := newMigrationsServer(t)
+		cfg := newTestSchemaConfig(func(cfg *Config) {
+			cfg.Schema.Mode = schemaModeDisabled
+		})
+		db, err := newClickhouseClient(cfg)
+		require.NoError(t, err)
+		defer db.Close()
+
+		require.NoError(t, migrateSchema(context.TODO(), cfg, db, zaptest.NewLogger(t), logsMigrations(cfg)))
+		assert.Empty(t, server.queries)
+	})
+}
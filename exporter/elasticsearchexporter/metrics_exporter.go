@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package elasticsearchexporter contains an opentelemetry-collector exporter
+// for Elasticsearch.
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
+)
+
+type elasticsearchMetricsExporter struct {
+	logger *zap.Logger
+
+	index        string
+	dynamicIndex bool
+	maxAttempts  int
+
+	client      *esClientCurrent
+	bulkIndexer esBulkIndexerCurrent
+	model       mappingModel
+}
+
+func newMetricsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchMetricsExporter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := newElasticsearchClient(logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	bulkIndexer, err := newBulkIndexer(logger, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	maxAttempts := 1
+	if cfg.Retry.Enabled {
+		maxAttempts = cfg.Retry.MaxRequests
+	}
+
+	model := &encodeModel{dedup: cfg.Mapping.Dedup, dedot: cfg.Mapping.Dedot}
+
+	return &elasticsearchMetricsExporter{
+		logger:      logger,
+		client:      client,
+		bulkIndexer: bulkIndexer,
+
+		index:        cfg.MetricsIndex,
+		dynamicIndex: cfg.MetricsDynamicIndex.Enabled,
+		maxAttempts:  maxAttempts,
+		model:        model,
+	}, nil
+}
+
+func (e *elasticsearchMetricsExporter) Shutdown(ctx context.Context) error {
+	return e.bulkIndexer.Close(ctx)
+}
+
+// pushMetricsData indexes one document per resource, timestamp and attribute set.
+// Every document holds the values of all data points sharing these dimensions, keyed by metric name.
+func (e *elasticsearchMetricsExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
+	var errs []error
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resource := rm.Resource()
+		documents := make(map[string]map[uint64]*objmodel.Document)
+
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				e.upsertMetric(documents, resource, metrics.At(k))
+			}
+		}
+
+		for fIndex, docs := range documents {
+			for _, doc := range docs {
+				if err := e.pushMetricDocument(ctx, fIndex, *doc); err != nil {
+					if cerr := ctx.Err(); cerr != nil {
+						return cerr
+					}
+
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	return multierr.Combine(errs...)
+}
+
+func (e *elasticsearchMetricsExporter) upsertMetric(documents map[string]map[uint64]*objmodel.Document, resource pcommon.Resource, metric pmetric.Metric) {
+	name := metric.Name()
+	upsert := func(dp dataPoint, key string, value objmodel.Value) {
+		fIndex := e.index
+		if e.dynamicIndex {
+			prefix := getFromBothResourceAndAttribute(indexPrefix, resource, dp)
+			suffix := getFromBothResourceAndAttribute(indexSuffix, resource, dp)
+
+			fIndex = fmt.Sprintf("%s%s%s", prefix, fIndex, suffix)
+		}
+
+		docs, ok := documents[fIndex]
+		if !ok {
+			docs = make(map[uint64]*objmodel.Document)
+			documents[fIndex] = docs
+		}
+		e.model.upsertMetricDataPoint(docs, resource, dp, key, value)
+	}
+
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		upsertNumberDataPoints(metric.Gauge().DataPoints(), name, upsert)
+	case pmetric.MetricTypeSum:
+		upsertNumberDataPoints(metric.Sum().DataPoints(), name, upsert)
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if dp.Flags().NoRecordedValue() {
+				continue
+			}
+			values, counts, ok := histogramValues(dp)
+			if !ok {
+				e.logger.Debug("Dropping histogram data point with invalid buckets", zap.String("metric", name))
+				continue
+			}
+			upsert(dp, name+".values", objmodel.ArrValue(values...))
+			upsert(dp, name+".counts", objmodel.ArrValue(counts...))
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if dp.Flags().NoRecordedValue() {
+				continue
+			}
+			upsert(dp, name+".sum", objmodel.DoubleValue(dp.Sum()))
+			upsert(dp, name+".value_count", objmodel.IntValue(int64(dp.Count())))
+		}
+	default:
+		e.logger.Debug("Dropping metric of unsupported type", zap.String("metric", name), zap.String("type", metric.Type().String()))
+	}
+}
+
+func upsertNumberDataPoints(dps pmetric.NumberDataPointSlice, name string, upsert func(dataPoint, string, objmodel.Value)) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.Flags().NoRecordedValue() {
+			continue
+		}
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			upsert(dp, name, objmodel.IntValue(dp.IntValue()))
+		case pmetric.NumberDataPointValueTypeDouble:
+			upsert(dp, name, objmodel.DoubleValue(dp.DoubleValue()))
+		}
+	}
+}
+
+func (e *elasticsearchMetricsExporter) pushMetricDocument(ctx context.Context, index string, doc objmodel.Document) error {
+	document, err := e.model.encodeDocument(doc)
+	if err != nil {
+		return fmt.Errorf("Failed to encode metric document: %w", err)
+	}
+	return pushDocuments(ctx, e.logger, index, document, e.bulkIndexer, e.maxAttempts)
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package elasticsearchexporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
+)
+
+func TestExporter_PushMetricsData(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/10178")
+	}
+	t.Run("publish with success", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL)
+		mustSendMetrics(t, exporter, newTestMetrics())
+
+		rec.WaitItems(2)
+
+		var docs []map[string]interface{}
+		for _, item := range rec.Items() {
+			doc := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(item.Document, &doc))
+			docs = append(docs, doc)
+		}
+		assert.ElementsMatch(t, []map[string]interface{}{
+			{
+				"@timestamp": "2023-04-19T03:04:05.000000006Z",
+				"Attributes": map[string]interface{}{"state": "idle"},
+				"Resource":   map[string]interface{}{"host": map[string]interface{}{"name": "localhost"}},
+				"system": map[string]interface{}{
+					"cpu": map[string]interface{}{"time": 1.5},
+					"processes": map[string]interface{}{
+						"count": float64(3),
+					},
+				},
+			},
+			{
+				"@timestamp": "2023-04-19T03:04:05.000000006Z",
+				"Attributes": map[string]interface{}{"state": "busy"},
+				"Resource":   map[string]interface{}{"host": map[string]interface{}{"name": "localhost"}},
+				"system": map[string]interface{}{
+					"cpu": map[string]interface{}{"time": 2.5},
+				},
+			},
+		}, docs)
+	})
+
+	t.Run("publish with dynamic index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		var (
+			prefix = "resprefix-"
+			suffix = "-attrsuffix"
+			index  = "someindex"
+		)
+
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+
+			data, err := docs[0].Action.MarshalJSON()
+			assert.Nil(t, err)
+
+			jsonVal := map[string]interface{}{}
+			err = json.Unmarshal(data, &jsonVal)
+			assert.Nil(t, err)
+
+			create := jsonVal["create"].(map[string]interface{})
+			expected := fmt.Sprintf("%s%s%s", prefix, index, suffix)
+			assert.Equal(t, expected, create["_index"].(string))
+
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL, func(cfg *Config) {
+			cfg.MetricsIndex = index
+			cfg.MetricsDynamicIndex.Enabled = true
+		})
+
+		metrics := newMetricsWithAttributeAndResourceMap(
+			map[string]string{
+				indexPrefix: "attrprefix-",
+				indexSuffix: suffix,
+			},
+			map[string]string{
+				indexPrefix: prefix,
+			},
+		)
+		mustSendMetrics(t, exporter, metrics)
+
+		rec.WaitItems(1)
+	})
+
+	t.Run("drop data points without value", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL)
+
+		metrics := pmetric.NewMetrics()
+		scopeMetrics := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+		dp := scopeMetrics.Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		scopeMetrics.Metrics().AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+		mustSendMetrics(t, exporter, metrics)
+
+		time.Sleep(200 * time.Millisecond)
+		assert.Equal(t, 0, rec.NumItems())
+	})
+}
+
+func TestHistogramValues(t *testing.T) {
+	dp := pmetric.NewHistogramDataPoint()
+	dp.ExplicitBounds().FromRaw([]float64{1, 2, 4})
+	dp.BucketCounts().FromRaw([]uint64{1, 0, 3, 4})
+
+	values, counts, ok := histogramValues(dp)
+	require.True(t, ok)
+	assert.Equal(t, []objmodel.Value{objmodel.DoubleValue(0.5), objmodel.DoubleValue(3), objmodel.DoubleValue(4)}, values)
+	assert.Equal(t, []objmodel.Value{objmodel.IntValue(1), objmodel.IntValue(3), objmodel.IntValue(4)}, counts)
+
+	dp.BucketCounts().FromRaw([]uint64{1, 2})
+	_, _, ok = histogramValues(dp)
+	assert.False(t, ok)
+}
+
+func newTestMetricsExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchMetricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(url))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, exporter.Shutdown(context.TODO()))
+	})
+	return exporter
+}
+
+func mustSendMetrics(t *testing.T, exporter *elasticsearchMetricsExporter, metrics pmetric.Metrics) {
+	err := exporter.pushMetricsData(context.TODO(), metrics)
+	require.NoError(t, err)
+}
+
+// newTestMetrics returns a gauge and a sum sharing the same attributes, and a second gauge data point
+// with different attributes.
+func newTestMetrics() pmetric.Metrics {
+	ts := pcommon.NewTimestampFromTime(time.Date(2023, 4, 19, 3, 4, 5, 6, time.UTC))
+
+	metrics := pmetric.NewMetrics()
+	resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+	resourceMetrics.Resource().Attributes().PutStr("host.name", "localhost")
+	scopeMetrics := resourceMetrics.ScopeMetrics().AppendEmpty()
+
+	cpuTime := scopeMetrics.Metrics().AppendEmpty()
+	cpuTime.SetName("system.cpu.time")
+	dps := cpuTime.SetEmptyGauge().DataPoints()
+	dp := dps.AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(1.5)
+	dp.Attributes().PutStr("state", "idle")
+	dp = dps.AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(2.5)
+	dp.Attributes().PutStr("state", "busy")
+
+	processes := scopeMetrics.Metrics().AppendEmpty()
+	processes.SetName("system.processes.count")
+	dp = processes.SetEmptySum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(3)
+	dp.Attributes().PutStr("state", "idle")
+
+	return metrics
+}
@@ -5,11 +5,16 @@ package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
+	"hash"
+	"hash/fnv"
+	"sort"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
@@ -19,6 +24,14 @@ import (
 type mappingModel interface {
 	encodeLog(pcommon.Resource, plog.LogRecord) ([]byte, error)
 	encodeSpan(pcommon.Resource, ptrace.Span) ([]byte, error)
+	upsertMetricDataPoint(map[uint64]*objmodel.Document, pcommon.Resource, dataPoint, string, objmodel.Value)
+	encodeDocument(objmodel.Document) ([]byte, error)
+}
+
+// dataPoint is implemented by the data points of all metric types.
+type dataPoint interface {
+	Timestamp() pcommon.Timestamp
+	Attributes() pcommon.Map
 }
 
 // encodeModel tries to keep the event as close to the original open telemetry semantics as is.
@@ -50,15 +63,7 @@ func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord
 	document.AddAttributes("Attributes", record.Attributes())
 	document.AddAttributes("Resource", resource.Attributes())
 
-	if m.dedup {
-		document.Dedup()
-	} else if m.dedot {
-		document.Sort()
-	}
-
-	var buf bytes.Buffer
-	err := document.Serialize(&buf, m.dedot)
-	return buf.Bytes(), err
+	return m.encodeDocument(document)
 }
 
 func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([]byte, error) {
@@ -77,6 +82,27 @@ func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([
 	document.AddEvents("Events", span.Events())
 	document.AddInt("Duration", durationAsMicroseconds(span.StartTimestamp().AsTime(), span.EndTimestamp().AsTime())) // unit is microseconds
 
+	return m.encodeDocument(document)
+}
+
+// upsertMetricDataPoint adds the value of a data point to the document holding all data points of
+// the resource with the same timestamp and attributes, creating the document if it does not exist yet.
+// Documents are keyed by a hash of the data point dimensions, so that each document maps to a single
+// time series in a time series data stream.
+func (m *encodeModel) upsertMetricDataPoint(documents map[uint64]*objmodel.Document, resource pcommon.Resource, dp dataPoint, name string, value objmodel.Value) {
+	key := metricHash(dp.Timestamp(), dp.Attributes())
+	document, ok := documents[key]
+	if !ok {
+		document = &objmodel.Document{}
+		document.AddTimestamp("@timestamp", dp.Timestamp())
+		document.AddAttributes("Attributes", dp.Attributes())
+		document.AddAttributes("Resource", resource.Attributes())
+		documents[key] = document
+	}
+	document.Add(name, value)
+}
+
+func (m *encodeModel) encodeDocument(document objmodel.Document) ([]byte, error) {
 	if m.dedup {
 		document.Dedup()
 	} else if m.dedot {
@@ -88,6 +114,79 @@ func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([
 	return buf.Bytes(), err
 }
 
+// metricHash returns a hash of the timestamp and the attributes of a data point.
+// Attributes are hashed in key order, so that the hash does not depend on their insertion order.
+func metricHash(timestamp pcommon.Timestamp, attributes pcommon.Map) uint64 {
+	// the implementation fnv.Write() never returns an error, see hash/fnv/fnv.go
+	hasher := fnv.New64a()
+
+	timestampBuf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(timestampBuf, uint64(timestamp))
+	_, _ = hasher.Write(timestampBuf)
+
+	mapHash(hasher, attributes)
+
+	return hasher.Sum64()
+}
+
+func mapHash(hasher hash.Hash64, m pcommon.Map) {
+	keys := make([]string, 0, m.Len())
+	m.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v, _ := m.Get(k)
+		_, _ = hasher.Write([]byte(k))
+		// Separate the key from the value, and the value from the next key,
+		// so that e.g. {"a": "bc"} and {"ab": "c"} do not collide.
+		_, _ = hasher.Write([]byte{0})
+		_, _ = hasher.Write([]byte(v.Type().String()))
+		_, _ = hasher.Write([]byte(v.AsString()))
+		_, _ = hasher.Write([]byte{0})
+	}
+}
+
+// histogramValues converts the buckets of a histogram data point into the values and counts arrays of an
+// Elasticsearch histogram field. Each bucket is represented by its midpoint, the unbounded first and last
+// buckets by their only bound. Empty buckets are left out.
+func histogramValues(dp pmetric.HistogramDataPoint) (values []objmodel.Value, counts []objmodel.Value, ok bool) {
+	bucketCounts := dp.BucketCounts()
+	explicitBounds := dp.ExplicitBounds()
+	if explicitBounds.Len() == 0 || bucketCounts.Len() != explicitBounds.Len()+1 {
+		return nil, nil, false
+	}
+
+	for i := 0; i < bucketCounts.Len(); i++ {
+		count := bucketCounts.At(i)
+		if count == 0 {
+			continue
+		}
+
+		var value float64
+		switch {
+		// (-infinity, explicit_bounds[i]]
+		case i == 0:
+			value = explicitBounds.At(i)
+			if value > 0 {
+				value /= 2
+			}
+		// (explicit_bounds[i-1], +infinity)
+		case i == explicitBounds.Len():
+			value = explicitBounds.At(i - 1)
+		// (explicit_bounds[i-1], explicit_bounds[i]]
+		default:
+			value = explicitBounds.At(i-1) + (explicitBounds.At(i)-explicitBounds.At(i-1))/2
+		}
+
+		values = append(values, objmodel.DoubleValue(value))
+		counts = append(counts, objmodel.IntValue(int64(count)))
+	}
+	return values, counts, true
+}
+
 func spanLinksToString(spanLinkSlice ptrace.SpanLinkSlice) string {
 	linkArray := make([]map[string]interface{}, 0, spanLinkSlice.Len())
 	for i := 0; i < spanLinkSlice.Len(); i++ {
@@ -17,6 +17,7 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -245,6 +246,21 @@ func newTracesWithAttributeAndResourceMap(attrMp map[string]string, resMp map[st
 	return traces
 }
 
+func newMetricsWithAttributeAndResourceMap(attrMp map[string]string, resMp map[string]string) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	resourceMetrics := metrics.ResourceMetrics()
+	rm := resourceMetrics.AppendEmpty()
+
+	dp := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetIntValue(0)
+	fillResourceAttributeMap(dp.Attributes(), attrMp)
+
+	resAttr := rm.Resource().Attributes()
+	fillResourceAttributeMap(resAttr, resMp)
+
+	return metrics
+}
+
 func fillResourceAttributeMap(attrs pcommon.Map, mp map[string]string) {
 	attrs.EnsureCapacity(len(mp))
 	for k, v := range mp {
@@ -9,6 +9,8 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 const (
@@ -29,8 +31,12 @@ type Config struct {
 	// Options:
 	// - json[default]:  OTLP json bytes.
 	// - proto:  OTLP binary protobuf bytes.
+	// - parquet:  Parquet files, one per batch of telemetry data.
 	FormatType string `mapstructure:"format"`
 
+	// Parquet configures the files written when the format is parquet.
+	Parquet parquet.Config `mapstructure:"parquet"`
+
 	// Compression Codec used to export telemetry data
 	// Supported compression algorithms:`zstd`
 	Compression string `mapstructure:"compression"`
@@ -70,12 +76,23 @@ func (cfg *Config) Validate() error {
 	if cfg.Path == "" {
 		return errors.New("path must be non-empty")
 	}
-	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto {
+	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto && cfg.FormatType != formatTypeParquet {
 		return errors.New("format type is not supported")
 	}
 	if cfg.Compression != "" && cfg.Compression != compressionZSTD {
 		return errors.New("compression is not supported")
 	}
+	if cfg.FormatType == formatTypeParquet {
+		if cfg.Compression != "" {
+			return errors.New("compression cannot be used with the parquet format, use parquet::compression instead")
+		}
+		if cfg.Rotation != nil {
+			return errors.New("rotation cannot be used with the parquet format")
+		}
+		if err := cfg.Parquet.Validate(); err != nil {
+			return err
+		}
+	}
 	if cfg.FlushInterval < 0 {
 		return errors.New("flush_interval must be larger than zero")
 	}
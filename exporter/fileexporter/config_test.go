@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -38,6 +39,7 @@ func TestLoadConfig(t *testing.T) {
 					LocalTime:    true,
 				},
 				FormatType:    formatTypeJSON,
+				Parquet:       parquet.DefaultConfig(),
 				FlushInterval: time.Second,
 			},
 		},
@@ -52,6 +54,7 @@ func TestLoadConfig(t *testing.T) {
 					LocalTime:    true,
 				},
 				FormatType:    formatTypeProto,
+				Parquet:       parquet.DefaultConfig(),
 				Compression:   compressionZSTD,
 				FlushInterval: time.Second,
 			},
@@ -61,6 +64,7 @@ func TestLoadConfig(t *testing.T) {
 			expected: &Config{
 				Path:       "./foo",
 				FormatType: formatTypeJSON,
+				Parquet:    parquet.DefaultConfig(),
 				Rotation: &Rotation{
 					MaxBackups: defaultMaxBackups,
 				},
@@ -76,6 +80,7 @@ func TestLoadConfig(t *testing.T) {
 					MaxBackups:   defaultMaxBackups,
 				},
 				FormatType:    formatTypeJSON,
+				Parquet:       parquet.DefaultConfig(),
 				FlushInterval: time.Second,
 			},
 		},
@@ -93,6 +98,7 @@ func TestLoadConfig(t *testing.T) {
 				Path:          "./flushed",
 				FlushInterval: 5,
 				FormatType:    formatTypeJSON,
+				Parquet:       parquet.DefaultConfig(),
 			},
 		},
 		{
@@ -101,6 +107,7 @@ func TestLoadConfig(t *testing.T) {
 				Path:          "./flushed",
 				FlushInterval: 5 * time.Second,
 				FormatType:    formatTypeJSON,
+				Parquet:       parquet.DefaultConfig(),
 			},
 		},
 		{
@@ -109,12 +116,37 @@ func TestLoadConfig(t *testing.T) {
 				Path:          "./flushed",
 				FlushInterval: 500 * time.Millisecond,
 				FormatType:    formatTypeJSON,
+				Parquet:       parquet.DefaultConfig(),
 			},
 		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "flush_interval_negative_value"),
 			errorMessage: "flush_interval must be larger than zero",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "parquet"),
+			expected: &Config{
+				Path:          "./data.parquet",
+				FormatType:    formatTypeParquet,
+				FlushInterval: time.Second,
+				Parquet: parquet.Config{
+					RowGroupSize: 1000,
+					Compression:  "zstd",
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "parquet_compression_error"),
+			errorMessage: "compression cannot be used with the parquet format, use parquet::compression instead",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "parquet_rotation_error"),
+			errorMessage: "rotation cannot be used with the parquet format",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "parquet_row_group_size_error"),
+			errorMessage: "row_group_size must be larger than zero",
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, ""),
 			errorMessage: "path must be non-empty",
@@ -16,6 +16,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 const (
@@ -23,8 +24,9 @@ const (
 	defaultMaxBackups = 100
 
 	// the format of encoded telemetry data
-	formatTypeJSON  = "json"
-	formatTypeProto = "proto"
+	formatTypeJSON    = "json"
+	formatTypeProto   = "proto"
+	formatTypeParquet = "parquet"
 
 	// the type of compression codec
 	compressionZSTD = "zstd"
@@ -44,6 +46,7 @@ func createDefaultConfig() component.Config {
 	return &Config{
 		FormatType: formatTypeJSON,
 		Rotation:   &Rotation{MaxBackups: defaultMaxBackups},
+		Parquet:    parquet.DefaultConfig(),
 	}
 }
 
@@ -58,8 +61,13 @@ func createTracesExporter(
 		return nil, err
 	}
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return newFileExporter(conf, writer)
+		var e *fileExporter
+		e, err = newFileExporter(conf, writer)
+		return e
 	})
+	if err != nil {
+		return nil, err
+	}
 	return exporterhelper.NewTracesExporter(
 		ctx,
 		set,
@@ -82,8 +90,13 @@ func createMetricsExporter(
 		return nil, err
 	}
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return newFileExporter(conf, writer)
+		var e *fileExporter
+		e, err = newFileExporter(conf, writer)
+		return e
 	})
+	if err != nil {
+		return nil, err
+	}
 	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
@@ -106,8 +119,13 @@ func createLogsExporter(
 		return nil, err
 	}
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return newFileExporter(conf, writer)
+		var e *fileExporter
+		e, err = newFileExporter(conf, writer)
+		return e
 	})
+	if err != nil {
+		return nil, err
+	}
 	return exporterhelper.NewLogsExporter(
 		ctx,
 		set,
@@ -119,8 +137,8 @@ func createLogsExporter(
 	)
 }
 
-func newFileExporter(conf *Config, writer io.WriteCloser) *fileExporter {
-	return &fileExporter{
+func newFileExporter(conf *Config, writer io.WriteCloser) (*fileExporter, error) {
+	e := &fileExporter{
 		path:             conf.Path,
 		formatType:       conf.FormatType,
 		file:             writer,
@@ -132,9 +150,22 @@ func newFileExporter(conf *Config, writer io.WriteCloser) *fileExporter {
 		compressor:       buildCompressor(conf.Compression),
 		flushInterval:    conf.FlushInterval,
 	}
+	if conf.FormatType == formatTypeParquet {
+		m, err := parquet.NewMarshaler(conf.Parquet)
+		if err != nil {
+			return nil, err
+		}
+		e.tracesMarshaler = m
+		e.metricsMarshaler = m
+		e.logsMarshaler = m
+	}
+	return e, nil
 }
 
 func buildFileWriter(cfg *Config) (io.WriteCloser, error) {
+	if cfg.FormatType == formatTypeParquet {
+		return newParquetFileWriter(cfg.Path), nil
+	}
 	if cfg.Rotation == nil {
 		f, err := os.OpenFile(cfg.Path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 		if err != nil {
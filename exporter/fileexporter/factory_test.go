@@ -13,6 +13,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -69,6 +71,19 @@ func TestCreateTracesExporterError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestCreateTracesExporterParquetError(t *testing.T) {
+	cfg := &Config{
+		FormatType: formatTypeParquet,
+		Path:       tempFileName(t),
+		Parquet:    parquet.Config{RowGroupSize: 0},
+	}
+	_, err := createTracesExporter(
+		context.Background(),
+		exportertest.NewNopCreateSettings(),
+		cfg)
+	assert.Error(t, err)
+}
+
 func TestCreateLogsExporter(t *testing.T) {
 	cfg := &Config{
 		FormatType: formatTypeJSON,
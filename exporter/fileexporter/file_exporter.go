@@ -106,6 +106,15 @@ func exportMessageAsBuffer(e *fileExporter, buf []byte) error {
 	return binary.Write(e.file, binary.BigEndian, append(data, buf...))
 }
 
+// exportMessageAsFile writes buf as is. Every write of the parquetFileWriter creates a new file.
+func exportMessageAsFile(e *fileExporter, buf []byte) error {
+	// Ensure only one write operation happens at a time.
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	_, err := e.file.Write(buf)
+	return err
+}
+
 // startFlusher starts the flusher.
 // It does not check the flushInterval
 func (e *fileExporter) startFlusher() {
@@ -158,6 +167,9 @@ func (e *fileExporter) Shutdown(context.Context) error {
 }
 
 func buildExportFunc(cfg *Config) func(e *fileExporter, buf []byte) error {
+	if cfg.FormatType == formatTypeParquet {
+		return exportMessageAsFile
+	}
 	if cfg.FormatType == formatTypeProto {
 		return exportMessageAsBuffer
 	}
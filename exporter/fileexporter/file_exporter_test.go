@@ -642,7 +642,8 @@ func TestFlushing(t *testing.T) {
 	// Wrap the buffer with the buffered writer closer that implements flush() method.
 	bwc := newBufferedWriteCloser(buf)
 	// Create a file exporter with flushing enabled.
-	fe := newFileExporter(cfg, bwc)
+	fe, err := newFileExporter(cfg, bwc)
+	require.NoError(t, err)
 
 	// Start the flusher.
 	ctx := context.Background()
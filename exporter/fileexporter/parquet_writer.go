@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// parquetTimeFormat is the same layout used by rotated files, with nanosecond precision.
+const parquetTimeFormat = "2006-01-02T15-04-05.000000000"
+
+// parquetFileWriter writes every buffer to a new file, since a Parquet file
+// cannot be appended to once its footer has been written.
+// Files are named after the configured path with the current time inserted
+// before the extension, e.g. data-2022-09-14T05-02-14.173071000.parquet.
+type parquetFileWriter struct {
+	base string
+	ext  string
+	now  func() time.Time
+}
+
+var (
+	_ io.WriteCloser = (*parquetFileWriter)(nil)
+)
+
+func newParquetFileWriter(path string) io.WriteCloser {
+	ext := filepath.Ext(path)
+	return &parquetFileWriter{
+		base: strings.TrimSuffix(path, ext),
+		ext:  ext,
+		now:  time.Now,
+	}
+}
+
+func (w *parquetFileWriter) Write(p []byte) (n int, err error) {
+	f, err := w.create()
+	if err != nil {
+		return 0, err
+	}
+	n, err = f.Write(p)
+	return n, multierr.Combine(err, f.Close())
+}
+
+// create opens a new file for writing. A counter is appended to the name in the
+// unlikely case that a file with the same timestamp already exists.
+func (w *parquetFileWriter) create() (*os.File, error) {
+	name := w.base + "-" + w.now().UTC().Format(parquetTimeFormat)
+	for i := 0; ; i++ {
+		path := name + w.ext
+		if i > 0 {
+			path = name + "-" + strconv.Itoa(i) + w.ext
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if !errors.Is(err, os.ErrExist) {
+			return f, err
+		}
+	}
+}
+
+func (w *parquetFileWriter) Close() error {
+	return nil
+}
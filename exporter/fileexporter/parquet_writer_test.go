@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
+)
+
+func TestParquetFileWriter(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	w := newParquetFileWriter(filepath.Join(dir, "data.parquet")).(*parquetFileWriter)
+	w.now = func() time.Time {
+		return time.Date(2022, 9, 14, 5, 2, 14, 173071000, time.UTC)
+	}
+
+	for _, msg := range []string{"first", "second", "third"} {
+		n, err := w.Write([]byte(msg))
+		require.NoError(t, err)
+		assert.Equal(t, len(msg), n)
+	}
+	require.NoError(t, w.Close())
+
+	for name, expected := range map[string]string{
+		"data-2022-09-14T05-02-14.173071000.parquet":   "first",
+		"data-2022-09-14T05-02-14.173071000-1.parquet": "second",
+		"data-2022-09-14T05-02-14.173071000-2.parquet": "third",
+	} {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(b))
+	}
+}
+
+func TestFileExporterParquet(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:       filepath.Join(dir, "logs.parquet"),
+		FormatType: formatTypeParquet,
+		Parquet:    parquet.DefaultConfig(),
+	}
+	require.NoError(t, conf.Validate())
+
+	writer, err := buildFileWriter(conf)
+	require.NoError(t, err)
+	fe, err := newFileExporter(conf, writer)
+	require.NoError(t, err)
+
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, fe.consumeLogs(context.Background(), ld))
+	assert.NoError(t, fe.consumeLogs(context.Background(), ld))
+	assert.NoError(t, fe.Shutdown(context.Background()))
+
+	files, err := filepath.Glob(filepath.Join(dir, "logs-*.parquet"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		require.NoError(t, err)
+		// Every Parquet file starts and ends with the "PAR1" magic number.
+		assert.True(t, bytes.HasPrefix(b, []byte("PAR1")))
+		assert.True(t, bytes.HasSuffix(b, []byte("PAR1")))
+	}
+}
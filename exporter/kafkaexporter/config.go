@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
 )
 
 // Config defines configuration for Kafka exporter.
@@ -39,6 +40,9 @@ type Config struct {
 
 	// Authentication defines used authentication mechanism.
 	Authentication kafka.Authentication `mapstructure:"auth"`
+
+	// SchemaRegistry defines the schema registry used by the avro and protobuf encodings.
+	SchemaRegistry schemaregistry.Config `mapstructure:"schema_registry"`
 }
 
 // Metadata defines configuration for retrieving metadata from the broker.
@@ -102,6 +106,12 @@ func (cfg *Config) Validate() error {
 		return err
 	}
 
+	if _, ok := schemaRegistryFormats[cfg.Encoding]; ok {
+		if err = cfg.SchemaRegistry.ValidateSerializer(); err != nil {
+			return err
+		}
+	}
+
 	return validateSASLConfig(cfg.Authentication.SASL)
 }
 
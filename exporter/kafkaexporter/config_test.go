@@ -19,6 +19,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -235,6 +236,21 @@ func TestValidate_sasl_version(t *testing.T) {
 	assert.EqualError(t, err, "auth.sasl.version has to be either 0 or 1. configured value 42")
 }
 
+func TestValidate_schema_registry_subject(t *testing.T) {
+	config := &Config{
+		Encoding: "protobuf",
+		Producer: Producer{
+			Compression: "none",
+		},
+		SchemaRegistry: schemaregistry.Config{
+			Endpoint: "http://localhost:8081",
+		},
+	}
+
+	err := config.Validate()
+	assert.EqualError(t, err, "schema_registry.subject is required")
+}
+
 func Test_saramaProducerCompressionCodec(t *testing.T) {
 	tests := map[string]struct {
 		compression         string
@@ -201,6 +201,12 @@ func newLogsExporter(config Config, set exporter.CreateSettings, marshalers map[
 	if marshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
+	if m, ok := marshaler.(*schemaRegistryMarshaler); ok {
+		var err error
+		if marshaler, err = m.withSchemaRegistry(config.SchemaRegistry); err != nil {
+			return nil, err
+		}
+	}
 	producer, err := newSaramaProducer(config)
 	if err != nil {
 		return nil, err
@@ -58,6 +58,8 @@ func TestDefaultLogsMarshalers(t *testing.T) {
 		"otlp_proto",
 		"otlp_json",
 		"raw",
+		"avro",
+		"protobuf",
 	}
 	marshalers := logsMarshalers()
 	assert.Equal(t, len(expectedEncodings), len(marshalers))
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"context"
+	"errors"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
+)
+
+// schemaRegistryFormats are the formats of the encodings whose schemas are resolved with a schema registry.
+var schemaRegistryFormats = map[string]schemaregistry.Format{
+	"avro":     schemaregistry.FormatAvro,
+	"protobuf": schemaregistry.FormatProtobuf,
+}
+
+// schemaRegistryMarshaler serializes the bodies of log records with the latest schema of a subject,
+// the configured attribute fields are added to the record from the log record attributes.
+type schemaRegistryMarshaler struct {
+	encoding        string
+	serde           *schemaregistry.Serde
+	attributeFields []string
+}
+
+func newSchemaRegistryMarshaler(encoding string) *schemaRegistryMarshaler {
+	return &schemaRegistryMarshaler{encoding: encoding}
+}
+
+// withSchemaRegistry returns a marshaler serializing with the configured schema registry.
+func (r *schemaRegistryMarshaler) withSchemaRegistry(cfg schemaregistry.Config) (LogsMarshaler, error) {
+	serde, err := schemaregistry.NewSerde(cfg, schemaRegistryFormats[r.encoding])
+	if err != nil {
+		return nil, err
+	}
+	return &schemaRegistryMarshaler{
+		encoding:        r.encoding,
+		serde:           serde,
+		attributeFields: cfg.AttributeFields,
+	}, nil
+}
+
+func (r *schemaRegistryMarshaler) Marshal(logs plog.Logs, topic string) ([]*sarama.ProducerMessage, error) {
+	if r.serde == nil {
+		return nil, errors.New("schema registry not set")
+	}
+	var messages []*sarama.ProducerMessage
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				b, err := r.serde.Serialize(context.Background(), r.record(sl.LogRecords().At(k)))
+				if err != nil {
+					return nil, err
+				}
+
+				messages = append(messages, &sarama.ProducerMessage{
+					Topic: topic,
+					Value: sarama.ByteEncoder(b),
+				})
+			}
+		}
+	}
+
+	return messages, nil
+}
+
+// record returns the record of a log record: its body, with the attribute fields if the body is a map or empty.
+func (r *schemaRegistryMarshaler) record(lr plog.LogRecord) any {
+	var record map[string]any
+	switch lr.Body().Type() {
+	case pcommon.ValueTypeMap:
+		record = lr.Body().Map().AsRaw()
+	case pcommon.ValueTypeEmpty:
+		record = make(map[string]any)
+	default:
+		return lr.Body().AsRaw()
+	}
+	for _, name := range r.attributeFields {
+		if value, ok := lr.Attributes().Get(name); ok {
+			record[name] = value.AsRaw()
+		}
+	}
+	return record
+}
+
+func (r *schemaRegistryMarshaler) Encoding() string {
+	return r.encoding
+}
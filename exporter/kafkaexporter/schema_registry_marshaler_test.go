@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry/schemaregistrytest"
+)
+
+const userEventSchema = `{
+  "type": "record",
+  "name": "UserEvent",
+  "fields": [
+    {"name": "user", "type": "string"},
+    {"name": "action", "type": "string"}
+  ]
+}`
+
+func TestSchemaRegistryMarshaler(t *testing.T) {
+	registry := schemaregistrytest.NewRegistry(t)
+	registry.RegisterAvro("events-value", userEventSchema)
+	cfg := schemaregistry.Config{
+		Endpoint:        registry.URL,
+		Subject:         "events-value",
+		AttributeFields: []string{"user"},
+	}
+	m, err := newSchemaRegistryMarshaler("avro").withSchemaRegistry(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "avro", m.Encoding())
+
+	logs := plog.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutStr("user", "alice")
+	lr.Body().SetEmptyMap().PutStr("action", "login")
+
+	messages, err := m.Marshal(logs, "events")
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "events", messages[0].Topic)
+
+	serde, err := schemaregistry.NewSerde(cfg, schemaregistry.FormatAvro)
+	require.NoError(t, err)
+	record, err := serde.Deserialize(context.Background(), messages[0].Value.(sarama.ByteEncoder))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"user": "alice", "action": "login"}, record)
+
+	lr.Body().SetStr("not a record")
+	_, err = m.Marshal(logs, "events")
+	assert.Error(t, err)
+}
+
+func TestSchemaRegistryMarshaler_not_configured(t *testing.T) {
+	_, err := newSchemaRegistryMarshaler("protobuf").Marshal(plog.NewLogs(), "events")
+	assert.EqualError(t, err, "schema registry not set")
+}
+
+func TestNewLogsExporter_err_schema_registry(t *testing.T) {
+	c := Config{
+		Encoding: "avro",
+		SchemaRegistry: schemaregistry.Config{
+			Endpoint: "http://localhost:8081",
+			Subject:  "events-value",
+			TLS: &configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{
+					CAFile: "/doesnotexist",
+				},
+			},
+		},
+	}
+	lexp, err := newLogsExporter(c, exportertest.NewNopCreateSettings(), logsMarshalers())
+	assert.ErrorContains(t, err, "failed to load TLS config")
+	assert.Nil(t, lexp)
+}
@@ -4,8 +4,10 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 )
 
@@ -18,11 +20,28 @@ const (
 	resourceRouting
 )
 
+var errNegativeRingTransitionWindow = errors.New("ring_transition_window must not be negative")
+
 // Config defines configuration for the exporter.
 type Config struct {
 	Protocol   Protocol         `mapstructure:"protocol"`
 	Resolver   ResolverSettings `mapstructure:"resolver"`
 	RoutingKey string           `mapstructure:"routing_key"`
+
+	// RingTransitionWindow is how long the previous hash ring is kept after the list of backends changes.
+	// During this window, identifiers that were already seen before the change are still routed to the
+	// backend they were routed to before. When 0, the new ring is used right away.
+	RingTransitionWindow time.Duration `mapstructure:"ring_transition_window"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.RingTransitionWindow < 0 {
+		return errNegativeRingTransitionWindow
+	}
+	return nil
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -6,7 +6,9 @@ package loadbalancingexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -25,3 +27,31 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, component.UnmarshalConfig(sub, cfg))
 	require.NotNil(t, cfg)
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name                 string
+		ringTransitionWindow time.Duration
+		expected             error
+	}{
+		{
+			name: "no ring transition window",
+		},
+		{
+			name:                 "ring transition window",
+			ringTransitionWindow: time.Minute,
+		},
+		{
+			name:                 "negative ring transition window",
+			ringTransitionWindow: -time.Minute,
+			expected:             errNegativeRingTransitionWindow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.RingTransitionWindow = tt.ringTransitionWindow
+			assert.Equal(t, tt.expected, cfg.Validate())
+		})
+	}
+}
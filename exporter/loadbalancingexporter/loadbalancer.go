@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
@@ -38,8 +39,22 @@ type loadBalancerImp struct {
 	logger *zap.Logger
 	host   component.Host
 
-	res  resolver
-	ring *hashRing
+	res      resolver
+	ring     *hashRing
+	resolved []string
+
+	// the previous ring is kept for the duration of the transition window after the backends change,
+	// so that identifiers seen before the change keep being routed to the same backends
+	transitionWindow time.Duration
+	previousRing     *hashRing
+	previousResolved []string
+	transitionStart  time.Time
+	transitionTimer  *time.Timer
+
+	// seen holds the identifiers routed within the last transition window
+	seen      map[string]seenIdentifier
+	lastPrune time.Time
+	seenLock  sync.Mutex
 
 	componentFactory componentFactory
 	exporters        map[string]component.Component
@@ -48,6 +63,12 @@ type loadBalancerImp struct {
 	updateLock sync.RWMutex
 }
 
+// seenIdentifier records when an identifier was first and last routed by the load balancer
+type seenIdentifier struct {
+	first time.Time
+	last  time.Time
+}
+
 // Create new load balancer
 func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, factory componentFactory) (*loadBalancerImp, error) {
 	oCfg := cfg.(*Config)
@@ -93,6 +114,8 @@ func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, facto
 	return &loadBalancerImp{
 		logger:           params.Logger,
 		res:              res,
+		transitionWindow: oCfg.RingTransitionWindow,
+		seen:             map[string]seenIdentifier{},
 		componentFactory: factory,
 		exporters:        map[string]component.Component{},
 	}, nil
@@ -111,17 +134,49 @@ func (lb *loadBalancerImp) onBackendChanges(resolved []string) {
 		lb.updateLock.Lock()
 		defer lb.updateLock.Unlock()
 
+		if lb.transitionWindow > 0 && lb.ring != nil {
+			lb.previousRing = lb.ring
+			lb.previousResolved = lb.resolved
+			lb.transitionStart = time.Now()
+			if lb.transitionTimer != nil {
+				lb.transitionTimer.Stop()
+			}
+			lb.transitionTimer = time.AfterFunc(lb.transitionWindow, lb.endRingTransition)
+		}
+
 		lb.ring = newRing
+		lb.resolved = resolved
 
 		// TODO: set a timeout?
 		ctx := context.Background()
 
 		// add the missing exporters first
 		lb.addMissingExporters(ctx, resolved)
-		lb.removeExtraExporters(ctx, resolved)
+
+		// the exporters for the backends from the previous ring are still needed during the transition
+		inUse := make([]string, 0, len(resolved)+len(lb.previousResolved))
+		inUse = append(inUse, resolved...)
+		inUse = append(inUse, lb.previousResolved...)
+		lb.removeExtraExporters(ctx, inUse)
 	}
 }
 
+// endRingTransition drops the previous ring, along with the exporters for the backends that are not part of the current ring
+func (lb *loadBalancerImp) endRingTransition() {
+	lb.updateLock.Lock()
+	defer lb.updateLock.Unlock()
+
+	// the ring might have changed again since this was scheduled
+	if lb.previousRing == nil || time.Since(lb.transitionStart) < lb.transitionWindow {
+		return
+	}
+
+	lb.previousRing = nil
+	lb.previousResolved = nil
+	lb.transitionTimer = nil
+	lb.removeExtraExporters(context.Background(), lb.resolved)
+}
+
 func (lb *loadBalancerImp) addMissingExporters(ctx context.Context, endpoints []string) {
 	for _, endpoint := range endpoints {
 		endpoint = endpointWithPort(endpoint)
@@ -173,6 +228,12 @@ func endpointFound(endpoint string, endpoints []string) bool {
 }
 
 func (lb *loadBalancerImp) Shutdown(context.Context) error {
+	lb.updateLock.Lock()
+	if lb.transitionTimer != nil {
+		lb.transitionTimer.Stop()
+	}
+	lb.updateLock.Unlock()
+
 	lb.stopped = true
 	return nil
 }
@@ -181,9 +242,49 @@ func (lb *loadBalancerImp) Endpoint(identifier []byte) string {
 	lb.updateLock.RLock()
 	defer lb.updateLock.RUnlock()
 
+	if lb.transitionWindow == 0 {
+		return lb.ring.endpointFor(identifier)
+	}
+
+	firstSeen := lb.markSeen(identifier)
+	if lb.previousRing != nil && firstSeen.Before(lb.transitionStart) {
+		// only route by the previous ring if we still have an exporter for its backend
+		endpoint := lb.previousRing.endpointFor(identifier)
+		if _, found := lb.exporters[endpointWithPort(endpoint)]; found {
+			return endpoint
+		}
+	}
+
 	return lb.ring.endpointFor(identifier)
 }
 
+// markSeen records that the identifier is being routed now, returning when it was first seen.
+// Identifiers that haven't been seen for longer than the transition window are forgotten.
+func (lb *loadBalancerImp) markSeen(identifier []byte) time.Time {
+	now := time.Now()
+
+	lb.seenLock.Lock()
+	defer lb.seenLock.Unlock()
+
+	if now.Sub(lb.lastPrune) > lb.transitionWindow {
+		for key, s := range lb.seen {
+			if now.Sub(s.last) > lb.transitionWindow {
+				delete(lb.seen, key)
+			}
+		}
+		lb.lastPrune = now
+	}
+
+	s, found := lb.seen[string(identifier)]
+	if !found {
+		s.first = now
+	}
+	s.last = now
+	lb.seen[string(identifier)] = s
+
+	return s.first
+}
+
 func (lb *loadBalancerImp) Exporter(endpoint string) (component.Component, error) {
 	// NOTE: make rolling updates of next tier of collectors work. currently, this may cause
 	// data loss because the latest batches sent to outdated backend will never find their way out.
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -219,6 +220,100 @@ func TestOnBackendChanges(t *testing.T) {
 	assert.Len(t, p.ring.items, 2*defaultWeight)
 }
 
+func TestRingTransitionWindow(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	cfg.RingTransitionWindow = time.Hour
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// these identifiers reach the endpoint-2 once it's part of the ring -- see the consistent hashing tests for more info
+	inFlight := []byte{128, 128, 0, 0}
+	newcomer := []byte("get-recommendations-1")
+
+	p.onBackendChanges([]string{"endpoint-1"})
+	require.Equal(t, "endpoint-1", p.Endpoint(inFlight))
+
+	// test
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2"})
+
+	// verify
+	assert.Equal(t, "endpoint-1", p.Endpoint(inFlight))
+	assert.Equal(t, "endpoint-2", p.Endpoint(newcomer))
+
+	// test
+	p.updateLock.Lock()
+	p.transitionStart = time.Now().Add(-2 * time.Hour)
+	p.updateLock.Unlock()
+	p.endRingTransition()
+
+	// verify
+	assert.Nil(t, p.previousRing)
+	assert.Equal(t, "endpoint-2", p.Endpoint(inFlight))
+}
+
+func TestRingTransitionKeepsPreviousExporters(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	cfg.RingTransitionWindow = time.Hour
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2"})
+
+	// test
+	p.onBackendChanges([]string{"endpoint-2"})
+
+	// verify
+	assert.Len(t, p.exporters, 2)
+	assert.Contains(t, p.exporters, endpointWithPort("endpoint-1"))
+
+	// test
+	p.updateLock.Lock()
+	p.transitionStart = time.Now().Add(-2 * time.Hour)
+	p.updateLock.Unlock()
+	p.endRingTransition()
+
+	// verify
+	assert.Len(t, p.exporters, 1)
+	assert.NotContains(t, p.exporters, endpointWithPort("endpoint-1"))
+}
+
+func TestMarkSeenForgetsOldIdentifiers(t *testing.T) {
+	// prepare
+	cfg := simpleConfig()
+	cfg.RingTransitionWindow = time.Minute
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	old := time.Now().Add(-time.Hour)
+	p.seen["old"] = seenIdentifier{first: old, last: old}
+	p.lastPrune = old
+
+	// test
+	firstSeen := p.markSeen([]byte("new"))
+
+	// verify
+	assert.NotContains(t, p.seen, "old")
+	assert.Contains(t, p.seen, "new")
+	assert.Equal(t, firstSeen, p.markSeen([]byte("new")))
+}
+
 func TestRemoveExtraExporters(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
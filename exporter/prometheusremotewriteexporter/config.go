@@ -45,8 +45,17 @@ type Config struct {
 
 	// AddMetricSuffixes controls whether unit and type suffixes are added to metrics on export
 	AddMetricSuffixes bool `mapstructure:"add_metric_suffixes"`
+
+	// ProtobufMessage is the message sent to the endpoint: "prometheus.WriteRequest" for remote write 1.0,
+	// or "io.prometheus.write.v2.Request" for remote write 2.0.
+	ProtobufMessage string `mapstructure:"protobuf_message"`
 }
 
+const (
+	protobufMessageV1 = "prometheus.WriteRequest"
+	protobufMessageV2 = "io.prometheus.write.v2.Request"
+)
+
 type CreatedMetric struct {
 	// Enabled if true the _created metrics could be exported
 	Enabled bool `mapstructure:"enabled"`
@@ -90,6 +99,16 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("remote write consumer number can't be negative")
 	}
 
+	switch cfg.ProtobufMessage {
+	case protobufMessageV1:
+	case protobufMessageV2:
+		if cfg.WAL != nil {
+			return fmt.Errorf("the write-ahead log is not supported with %s", protobufMessageV2)
+		}
+	default:
+		return fmt.Errorf("unknown protobuf message %q, must be %s or %s", cfg.ProtobufMessage, protobufMessageV1, protobufMessageV2)
+	}
+
 	if cfg.TargetInfo == nil {
 		cfg.TargetInfo = &TargetInfo{
 			Enabled: true,
@@ -55,6 +55,7 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 10,
 				},
 				AddMetricSuffixes: false,
+				ProtobufMessage:   protobufMessageV2,
 				Namespace:         "test-space",
 				ExternalLabels:    map[string]string{"key1": "value1", "key2": "value2"},
 				HTTPClientSettings: confighttp.HTTPClientSettings{
@@ -87,6 +88,14 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "negative_num_consumers"),
 			errorMessage: "remote write consumer number can't be negative",
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unknown_protobuf_message"),
+			errorMessage: `unknown protobuf message "prometheus.WriteRequestV2", must be prometheus.WriteRequest or io.prometheus.write.v2.Request`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "v2_with_wal"),
+			errorMessage: "the write-ahead log is not supported with io.prometheus.write.v2.Request",
+		},
 	}
 
 	for _, tt := range tests {
@@ -14,9 +14,9 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/cenkalti/backoff/v4"
-	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	"github.com/prometheus/prometheus/prompb"
 	"go.opentelemetry.io/collector/component"
@@ -26,13 +26,23 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/multierr"
+	"go.uber.org/zap"
 
 	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
 )
 
 const maxBatchByteSize = 3000000
 
+// errUnsupportedMediaType is returned when the endpoint does not accept the content type of a request.
+var errUnsupportedMediaType = errors.New("remote write endpoint does not support the content type")
+
+// writeRequest is a remote write 1.0 or 2.0 request.
+type writeRequest interface {
+	Marshal() ([]byte, error)
+}
+
 // prwExporter converts OTLP metrics to Prometheus remote write TimeSeries and sends them to a remote endpoint.
 type prwExporter struct {
 	endpointURL     *url.URL
@@ -47,6 +57,10 @@ type prwExporter struct {
 
 	wal              *prweWAL
 	exporterSettings prometheusremotewrite.Settings
+
+	// sendV2 is true while remote write 2.0 requests are sent, it is cleared
+	// if the endpoint rejects their content type.
+	sendV2 atomic.Bool
 }
 
 // newPRWExporter initializes a new prwExporter instance and sets fields accordingly.
@@ -80,6 +94,7 @@ func newPRWExporter(cfg *Config, set exporter.CreateSettings) (*prwExporter, err
 			AddMetricSuffixes:   cfg.AddMetricSuffixes,
 		},
 	}
+	prwe.sendV2.Store(cfg.ProtobufMessage == protobufMessageV2)
 	if cfg.WAL == nil {
 		return prwe, nil
 	}
@@ -131,6 +146,16 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
+		if prwe.sendV2.Load() {
+			err := prwe.pushMetricsV2(ctx, md)
+			if !errors.Is(err, errUnsupportedMediaType) {
+				return err
+			}
+			// Negotiate the protocol down, remote write 1.0 is supported by every endpoint.
+			prwe.settings.Logger.Warn("Remote write endpoint does not support remote write 2.0, falling back to remote write 1.0", zap.Error(err))
+			prwe.sendV2.Store(false)
+		}
+
 		tsMap, err := prometheusremotewrite.FromMetrics(md, prwe.exporterSettings)
 		if err != nil {
 			err = consumererror.NewPermanent(err)
@@ -140,6 +165,27 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	}
 }
 
+// pushMetricsV2 converts metrics to remote write 2.0 series and sends them to the remote endpoint.
+func (prwe *prwExporter) pushMetricsV2(ctx context.Context, md pmetric.Metrics) error {
+	tsMap, err := prometheusremotewrite.FromMetricsV2(md, prwe.exporterSettings)
+	if err != nil {
+		err = consumererror.NewPermanent(err)
+	}
+	if len(tsMap) == 0 {
+		return err
+	}
+	requests, errBatch := batchTimeSeriesV2(tsMap, maxBatchByteSize)
+	if errBatch != nil {
+		return multierr.Combine(err, errBatch)
+	}
+	writeRequests := make([]writeRequest, 0, len(requests))
+	for _, request := range requests {
+		writeRequests = append(writeRequests, request)
+	}
+	// Call export even if a conversion error, since there may be points that were successfully converted.
+	return multierr.Combine(err, prwe.exportRequests(ctx, writeRequests))
+}
+
 func validateAndSanitizeExternalLabels(cfg *Config) (map[string]string, error) {
 	sanitizedLabels := make(map[string]string)
 	for key, value := range cfg.ExternalLabels {
@@ -178,7 +224,16 @@ func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*pro
 
 // export sends a Snappy-compressed WriteRequest containing TimeSeries to a remote write endpoint in order
 func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteRequest) error {
-	input := make(chan *prompb.WriteRequest, len(requests))
+	writeRequests := make([]writeRequest, 0, len(requests))
+	for _, request := range requests {
+		writeRequests = append(writeRequests, request)
+	}
+	return prwe.exportRequests(ctx, writeRequests)
+}
+
+// exportRequests sends the requests concurrently to the remote write endpoint.
+func (prwe *prwExporter) exportRequests(ctx context.Context, requests []writeRequest) error {
+	input := make(chan writeRequest, len(requests))
 	for _, request := range requests {
 		input <- request
 	}
@@ -219,11 +274,13 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 	return errs
 }
 
-func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequest) error {
+func (prwe *prwExporter) execute(ctx context.Context, writeReq writeRequest) error {
+	_, isV2 := writeReq.(*writev2.Request)
+
 	// Retry function for backoff
 	retryFunc := func() error {
-		// Uses proto.Marshal to convert the WriteRequest into bytes array
-		data, err := proto.Marshal(writeReq)
+		// Uses the protobuf encoding of the WriteRequest as bytes array
+		data, err := writeReq.Marshal()
 		if err != nil {
 			return backoff.Permanent(consumererror.NewPermanent(err))
 		}
@@ -239,8 +296,14 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 		// Add necessary headers specified by:
 		// https://cortexmetrics.io/docs/apis/#remote-api
 		req.Header.Add("Content-Encoding", "snappy")
-		req.Header.Set("Content-Type", "application/x-protobuf")
-		req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+		if isV2 {
+			// https://prometheus.io/docs/specs/remote_write_spec_2_0/#protocol
+			req.Header.Set("Content-Type", writev2.ContentType)
+			req.Header.Set("X-Prometheus-Remote-Write-Version", writev2.Version)
+		} else {
+			req.Header.Set("Content-Type", "application/x-protobuf")
+			req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+		}
 		req.Header.Set("User-Agent", prwe.userAgentHeader)
 
 		resp, err := prwe.client.Do(req)
@@ -259,6 +322,9 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 
 		body, err := io.ReadAll(io.LimitReader(resp.Body, 256))
 		rerr := fmt.Errorf("remote write returned HTTP status %v; err = %w: %s", resp.Status, err, body)
+		if isV2 && resp.StatusCode == http.StatusUnsupportedMediaType {
+			return backoff.Permanent(consumererror.NewPermanent(fmt.Errorf("%w: %w", errUnsupportedMediaType, rerr)))
+		}
 		if resp.StatusCode >= 500 && resp.StatusCode < 600 {
 			return rerr
 		}
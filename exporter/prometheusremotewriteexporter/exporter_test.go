@@ -27,9 +27,11 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
 )
 
 // Test_NewPRWExporter checks that a new exporter instance with non-nil fields is initialized
@@ -1049,3 +1051,109 @@ func TestNoRetryOn4xx(t *testing.T) {
 	assert.True(t, consumererror.IsPermanent(err))
 	assert.Equal(t, 1, attempts)
 }
+
+func newTestPRWExporterV2(t *testing.T, endpoint string) *prwExporter {
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: endpoint,
+		},
+		RemoteWriteQueue: RemoteWriteQueue{NumConsumers: 1},
+		TargetInfo: &TargetInfo{
+			Enabled: false,
+		},
+		CreatedMetric: &CreatedMetric{
+			Enabled: false,
+		},
+		ProtobufMessage: protobufMessageV2,
+	}
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, prwe.Shutdown(context.Background()))
+	})
+	return prwe
+}
+
+func TestPushMetricsV2(t *testing.T) {
+	var mu sync.Mutex
+	var requests []*writev2.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, writev2.ContentType, r.Header.Get("Content-Type"))
+		assert.Equal(t, writev2.Version, r.Header.Get("X-Prometheus-Remote-Write-Version"))
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		data, err := snappy.Decode(nil, body)
+		require.NoError(t, err)
+		request := &writev2.Request{}
+		require.NoError(t, request.Unmarshal(data))
+
+		mu.Lock()
+		requests = append(requests, request)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	prwe := newTestPRWExporterV2(t, server.URL)
+
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("requests")
+	metric.SetDescription("Number of requests")
+	metric.SetEmptySum().SetIsMonotonic(true)
+	metric.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := metric.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.Timestamp(1_000_000_000))
+	dp.SetTimestamp(pcommon.Timestamp(2_000_000_000))
+	dp.SetIntValue(3)
+
+	require.NoError(t, prwe.PushMetrics(context.Background(), md))
+
+	require.Len(t, requests, 1)
+	require.Len(t, requests[0].Timeseries, 1)
+	ts := requests[0].Timeseries[0]
+	labels, err := writev2.DesymbolizeLabels(ts.LabelsRefs, requests[0].Symbols)
+	require.NoError(t, err)
+	assert.Equal(t, []prompb.Label{{Name: "__name__", Value: "requests"}}, labels)
+	assert.Equal(t, []writev2.Sample{{Value: 3, Timestamp: 2000}}, ts.Samples)
+	assert.Equal(t, int64(1000), ts.CreatedTimestamp)
+	assert.Equal(t, writev2.MetricTypeCounter, ts.Metadata.Type)
+	help, err := writev2.Symbol(ts.Metadata.HelpRef, requests[0].Symbols)
+	require.NoError(t, err)
+	assert.Equal(t, "Number of requests", help)
+}
+
+func TestPushMetricsV2FallsBackToV1(t *testing.T) {
+	var mu sync.Mutex
+	var contentTypes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+		mu.Unlock()
+		if r.Header.Get("Content-Type") == writev2.ContentType {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	prwe := newTestPRWExporterV2(t, server.URL)
+
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("temperature")
+	dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.Timestamp(2_000_000_000))
+	dp.SetDoubleValue(21.5)
+
+	require.NoError(t, prwe.PushMetrics(context.Background(), md))
+	require.NoError(t, prwe.PushMetrics(context.Background(), md))
+
+	// The second push is sent with remote write 1.0 right away.
+	assert.Equal(t, []string{writev2.ContentType, "application/x-protobuf", "application/x-protobuf"}, contentTypes)
+	assert.False(t, prwe.sendV2.Load())
+}
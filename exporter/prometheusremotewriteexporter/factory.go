@@ -80,6 +80,7 @@ func createDefaultConfig() component.Config {
 			Multiplier:          backoff.DefaultMultiplier,
 		},
 		AddMetricSuffixes: true,
+		ProtobufMessage:   protobufMessageV1,
 		HTTPClientSettings: confighttp.HTTPClientSettings{
 			Endpoint: "http://some.url:9411/api/prom/push",
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
@@ -8,6 +8,9 @@ import (
 	"sort"
 
 	"github.com/prometheus/prometheus/prompb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
 )
 
 // batchTimeSeries splits series into multiple batch write requests.
@@ -66,3 +69,39 @@ func orderBySampleTimestamp(tsArray []prompb.TimeSeries) []prompb.TimeSeries {
 	}
 	return tsArray
 }
+
+// batchTimeSeriesV2 splits series into multiple remote write 2.0 requests, each with its own symbols.
+func batchTimeSeriesV2(tsMap map[string]*prometheusremotewrite.TimeSeriesV2, maxBatchByteSize int) ([]*writev2.Request, error) {
+	if len(tsMap) == 0 {
+		return nil, errors.New("invalid tsMap: cannot be empty map")
+	}
+
+	var requests []*writev2.Request
+	batch := make([]*prometheusremotewrite.TimeSeriesV2, 0, len(tsMap))
+	sizeOfCurrentBatch := 0
+
+	for _, v := range tsMap {
+		// The size of the series with its labels and metadata as strings is an upper bound
+		// of its size once they are interned in the symbols of the request.
+		sizeOfSeries := v.Size() + len(v.Metadata.Help) + len(v.Metadata.Unit)
+
+		if len(batch) > 0 && sizeOfCurrentBatch+sizeOfSeries >= maxBatchByteSize {
+			requests = append(requests, prometheusremotewrite.ToWriteRequestV2(batch))
+			batch = batch[:0]
+			sizeOfCurrentBatch = 0
+		}
+
+		// Prometheus requires time series to be sorted by Timestamp to avoid out of order problems.
+		sort.Slice(v.Samples, func(i, j int) bool {
+			return v.Samples[i].Timestamp < v.Samples[j].Timestamp
+		})
+		batch = append(batch, v)
+		sizeOfCurrentBatch += sizeOfSeries
+	}
+
+	if len(batch) != 0 {
+		requests = append(requests, prometheusremotewrite.ToWriteRequestV2(batch))
+	}
+
+	return requests, nil
+}
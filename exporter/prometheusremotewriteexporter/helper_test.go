@@ -8,6 +8,10 @@ import (
 
 	"github.com/prometheus/prometheus/prompb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
 )
 
 // Test_batchTimeSeries checks batchTimeSeries return the correct number of requests
@@ -180,3 +184,38 @@ func TestEnsureTimeseriesPointsAreSortedByTimestamp(t *testing.T) {
 		}
 	}
 }
+
+// Test_batchTimeSeriesV2 checks batchTimeSeriesV2 splits series in requests with their own symbols.
+func Test_batchTimeSeriesV2(t *testing.T) {
+	labels := getPromLabels(label11, value11, label12, value12)
+	ts1 := getTimeSeries(labels, getSample(floatVal2, msTime2), getSample(floatVal1, msTime1))
+	ts2 := getTimeSeries(getPromLabels(label21, value21), getSample(floatVal3, msTime3))
+	tsMap := map[string]*prometheusremotewrite.TimeSeriesV2{
+		"ts1": {TimeSeries: *ts1, Metadata: prometheusremotewrite.MetadataV2{Type: writev2.MetricTypeGauge}},
+		"ts2": {TimeSeries: *ts2, Metadata: prometheusremotewrite.MetadataV2{Type: writev2.MetricTypeCounter}},
+	}
+
+	_, err := batchTimeSeriesV2(map[string]*prometheusremotewrite.TimeSeriesV2{}, 100)
+	assert.Error(t, err)
+
+	requests, err := batchTimeSeriesV2(tsMap, 300)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	require.Len(t, requests[0].Timeseries, 2)
+	for _, ts := range requests[0].Timeseries {
+		for i := 1; i < len(ts.Samples); i++ {
+			assert.Less(t, ts.Samples[i-1].Timestamp, ts.Samples[i].Timestamp)
+		}
+	}
+
+	requests, err = batchTimeSeriesV2(tsMap, ts1.Size()+1)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	for _, request := range requests {
+		require.Len(t, request.Timeseries, 1)
+		labels, err := writev2.DesymbolizeLabels(request.Timeseries[0].LabelsRefs, request.Symbols)
+		require.NoError(t, err)
+		assert.NotEmpty(t, labels)
+		assert.Equal(t, len(labels)*2+1, len(request.Symbols), "each request only holds its own symbols")
+	}
+}
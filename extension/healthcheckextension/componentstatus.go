@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension/internal/healthcheck"
+)
+
+// extensionsKey is the key under which the status of extensions is reported,
+// since extensions do not belong to any pipeline.
+const extensionsKey = "extensions"
+
+// componentStatusResponse is the JSON representation of the status of the collector,
+// a pipeline or a component. The status of the collector and of the pipelines is
+// aggregated from the status of the components they contain.
+type componentStatusResponse struct {
+	Healthy    bool                                `json:"healthy"`
+	Status     string                              `json:"status"`
+	Error      string                              `json:"error,omitempty"`
+	StatusTime time.Time                           `json:"status_time"`
+	Components map[string]*componentStatusResponse `json:"components,omitempty"`
+}
+
+// componentStatusTracker keeps the latest status event reported by every component instance.
+type componentStatusTracker struct {
+	mu     sync.RWMutex
+	events map[*component.InstanceID]*component.StatusEvent
+}
+
+func newComponentStatusTracker() *componentStatusTracker {
+	return &componentStatusTracker{
+		events: make(map[*component.InstanceID]*component.StatusEvent),
+	}
+}
+
+func (t *componentStatusTracker) set(source *component.InstanceID, event *component.StatusEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events[source] = event
+}
+
+// groups returns the status events of the components grouped by pipeline, then by component.
+// Components shared by several pipelines, such as receivers, are reported in each of them.
+func (t *componentStatusTracker) groups() map[string]map[string]*component.StatusEvent {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	groups := make(map[string]map[string]*component.StatusEvent)
+	add := func(group string, source *component.InstanceID, event *component.StatusEvent) {
+		if _, ok := groups[group]; !ok {
+			groups[group] = make(map[string]*component.StatusEvent)
+		}
+		groups[group][kindString(source.Kind)+":"+source.ID.String()] = event
+	}
+
+	for source, event := range t.events {
+		if len(source.PipelineIDs) == 0 {
+			add(extensionsKey, source, event)
+			continue
+		}
+		for pipelineID := range source.PipelineIDs {
+			add("pipeline:"+pipelineID.String(), source, event)
+		}
+	}
+	return groups
+}
+
+// response returns the status of the collector, of every pipeline and of every component.
+// Nil is returned if no component has reported its status yet.
+func (t *componentStatusTracker) response() *componentStatusResponse {
+	groups := t.groups()
+	if len(groups) == 0 {
+		return nil
+	}
+
+	groupEvents := make(map[string]*component.StatusEvent, len(groups))
+	groupResps := make(map[string]*componentStatusResponse, len(groups))
+	for group, events := range groups {
+		groupEvent := component.AggregateStatusEvent(events)
+		groupEvents[group] = groupEvent
+
+		groupResp := newComponentStatusResponse(groupEvent)
+		groupResp.Components = make(map[string]*componentStatusResponse, len(events))
+		for name, event := range events {
+			groupResp.Components[name] = newComponentStatusResponse(event)
+		}
+		groupResps[group] = groupResp
+	}
+
+	resp := newComponentStatusResponse(component.AggregateStatusEvent(groupEvents))
+	resp.Components = groupResps
+	return resp
+}
+
+// hasStatus reports whether any component is in a status matching the given predicate.
+func (t *componentStatusTracker) hasStatus(match func(component.Status) bool) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, event := range t.events {
+		if match(event.Status()) {
+			return true
+		}
+	}
+	return false
+}
+
+func newComponentStatusResponse(event *component.StatusEvent) *componentStatusResponse {
+	resp := &componentStatusResponse{
+		Healthy:    !component.StatusIsError(event.Status()),
+		Status:     event.Status().String(),
+		StatusTime: event.Timestamp(),
+	}
+	if err := event.Err(); err != nil {
+		resp.Error = err.Error()
+	}
+	return resp
+}
+
+func kindString(kind component.Kind) string {
+	switch kind {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	case component.KindConnector:
+		return "connector"
+	default:
+		return "unknown"
+	}
+}
+
+// isPermanentError reports whether a component failed in a way it cannot recover from on its own.
+func isPermanentError(status component.Status) bool {
+	return status == component.StatusPermanentError || status == component.StatusFatalError
+}
+
+// componentStatusHandler serves the status of every pipeline and component as JSON.
+func (hc *healthCheckExtension) componentStatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		resp := hc.componentStatus.response()
+		if resp == nil {
+			resp = &componentStatusResponse{Status: component.StatusStarting.String(), Healthy: true}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if resp.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		body, _ := json.Marshal(resp)
+		_, _ = w.Write(body)
+	})
+}
+
+// livenessHandler fails once a component reported a permanent or fatal error,
+// so that the collector can be restarted by the orchestrator.
+func (hc *healthCheckExtension) livenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if hc.componentStatus.hasStatus(isPermanentError) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// readinessHandler fails while the pipelines are not ready, or while any component reports an error,
+// so that no traffic is routed to the collector until it recovers.
+func (hc *healthCheckExtension) readinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if hc.state.Get() != healthcheck.Ready || hc.componentStatus.hasStatus(component.StatusIsError) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
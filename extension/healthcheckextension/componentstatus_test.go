@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func newInstanceID(kind component.Kind, id component.ID, pipelineIDs ...component.ID) *component.InstanceID {
+	instanceID := &component.InstanceID{
+		ID:          id,
+		Kind:        kind,
+		PipelineIDs: make(map[component.ID]struct{}),
+	}
+	for _, pipelineID := range pipelineIDs {
+		instanceID.PipelineIDs[pipelineID] = struct{}{}
+	}
+	return instanceID
+}
+
+func getComponentStatus(t *testing.T, handler http.Handler) (int, *componentStatusResponse) {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	resp := &componentStatusResponse{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+	return rec.Code, resp
+}
+
+func getStatusCode(handler http.Handler) int {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rec.Code
+}
+
+func TestComponentStatus(t *testing.T) {
+	hcExt := newServer(*createDefaultConfig().(*Config), componenttest.NewNopTelemetrySettings())
+
+	traces := component.NewID("traces")
+	metrics := component.NewID("metrics")
+	receiver := newInstanceID(component.KindReceiver, component.NewID("otlp"), traces, metrics)
+	tracesExporter := newInstanceID(component.KindExporter, component.NewID("otlp"), traces)
+	metricsExporter := newInstanceID(component.KindExporter, component.NewIDWithName("otlp", "metrics"), metrics)
+	ext := newInstanceID(component.KindExtension, component.NewID("health_check"))
+
+	code, resp := getComponentStatus(t, hcExt.componentStatusHandler())
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, component.StatusStarting.String(), resp.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, getStatusCode(hcExt.readinessHandler()))
+
+	for _, source := range []*component.InstanceID{receiver, tracesExporter, metricsExporter, ext} {
+		hcExt.ComponentStatusChanged(source, component.NewStatusEvent(component.StatusStarting))
+		hcExt.ComponentStatusChanged(source, component.NewStatusEvent(component.StatusOK))
+	}
+	require.NoError(t, hcExt.Ready())
+
+	code, resp = getComponentStatus(t, hcExt.componentStatusHandler())
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, resp.Healthy)
+	assert.Equal(t, component.StatusOK.String(), resp.Status)
+	require.Len(t, resp.Components, 3)
+	assert.Len(t, resp.Components["pipeline:traces"].Components, 2)
+	assert.Len(t, resp.Components["pipeline:metrics"].Components, 2)
+	assert.Contains(t, resp.Components[extensionsKey].Components, "extension:health_check")
+	assert.Equal(t, http.StatusOK, getStatusCode(hcExt.livenessHandler()))
+	assert.Equal(t, http.StatusOK, getStatusCode(hcExt.readinessHandler()))
+
+	hcExt.ComponentStatusChanged(tracesExporter, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+
+	code, resp = getComponentStatus(t, hcExt.componentStatusHandler())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, resp.Healthy)
+	assert.Equal(t, component.StatusRecoverableError.String(), resp.Status)
+	assert.Equal(t, "connection refused", resp.Error)
+	assert.False(t, resp.Components["pipeline:traces"].Healthy)
+	assert.Equal(t, "connection refused", resp.Components["pipeline:traces"].Components["exporter:otlp"].Error)
+	assert.True(t, resp.Components["pipeline:metrics"].Healthy)
+	assert.Equal(t, http.StatusOK, getStatusCode(hcExt.livenessHandler()))
+	assert.Equal(t, http.StatusServiceUnavailable, getStatusCode(hcExt.readinessHandler()))
+
+	hcExt.ComponentStatusChanged(metricsExporter, component.NewPermanentErrorEvent(errors.New("invalid credentials")))
+
+	code, resp = getComponentStatus(t, hcExt.componentStatusHandler())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, component.StatusPermanentError.String(), resp.Status)
+	assert.Equal(t, "invalid credentials", resp.Error)
+	assert.Equal(t, http.StatusServiceUnavailable, getStatusCode(hcExt.livenessHandler()))
+	assert.Equal(t, http.StatusServiceUnavailable, getStatusCode(hcExt.readinessHandler()))
+}
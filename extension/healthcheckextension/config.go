@@ -37,6 +37,9 @@ type Config struct {
 
 	// CheckCollectorPipeline contains the list of settings of collector pipeline health check
 	CheckCollectorPipeline checkCollectorPipelineSettings `mapstructure:"check_collector_pipeline"`
+
+	// ComponentHealth contains the settings of the endpoints reporting the status of every pipeline and component
+	ComponentHealth componentHealthSettings `mapstructure:"component_health"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -44,6 +47,7 @@ var (
 	errNoEndpointProvided                      = errors.New("bad config: endpoint must be specified")
 	errInvalidExporterFailureThresholdProvided = errors.New("bad config: exporter_failure_threshold expects a positive number")
 	errInvalidPath                             = errors.New("bad config: path must start with /")
+	errDuplicatePath                           = errors.New("bad config: path and component_health paths must all differ")
 )
 
 // Validate checks if the extension configuration is valid
@@ -61,6 +65,19 @@ func (cfg *Config) Validate() error {
 	if !strings.HasPrefix(cfg.Path, "/") {
 		return errInvalidPath
 	}
+	if cfg.ComponentHealth.Enabled {
+		// Every path is registered on the same mux, which panics on duplicate registrations.
+		paths := map[string]struct{}{cfg.Path: {}}
+		for _, path := range []string{cfg.ComponentHealth.StatusPath, cfg.ComponentHealth.LivenessPath, cfg.ComponentHealth.ReadinessPath} {
+			if !strings.HasPrefix(path, "/") {
+				return errInvalidPath
+			}
+			if _, ok := paths[path]; ok {
+				return errDuplicatePath
+			}
+			paths[path] = struct{}{}
+		}
+	}
 	return nil
 }
 
@@ -72,3 +89,14 @@ type checkCollectorPipelineSettings struct {
 	// ExporterFailureThreshold is the threshold of exporter failure numbers during the Interval
 	ExporterFailureThreshold int `mapstructure:"exporter_failure_threshold"`
 }
+
+type componentHealthSettings struct {
+	// Enabled indicates whether to serve the status of every pipeline and component.
+	Enabled bool `mapstructure:"enabled"`
+	// StatusPath is the path returning the status of every pipeline and component as JSON
+	StatusPath string `mapstructure:"status_path"`
+	// LivenessPath is the path failing once a component reported a permanent or fatal error
+	LivenessPath string `mapstructure:"liveness_path"`
+	// ReadinessPath is the path failing while the collector is not ready or a component reports an error
+	ReadinessPath string `mapstructure:"readiness_path"`
+}
@@ -43,10 +43,27 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentHealth:        defaultComponentHealthSettings(),
 				Path:                   "/",
 				ResponseBody:           nil,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "componenthealth"),
+			expected: &Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:13",
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentHealth: componentHealthSettings{
+					Enabled:       true,
+					StatusPath:    "/health/status",
+					LivenessPath:  "/livez",
+					ReadinessPath: "/readyz",
+				},
+				Path: "/",
+			},
+		},
 		{
 			id:          component.NewIDWithName(metadata.Type, "missingendpoint"),
 			expectedErr: errNoEndpointProvided,
@@ -59,6 +76,10 @@ func TestLoadConfig(t *testing.T) {
 			id:          component.NewIDWithName(metadata.Type, "invalidpath"),
 			expectedErr: errInvalidPath,
 		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "duplicatepath"),
+			expectedErr: errDuplicatePath,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -78,3 +99,51 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateComponentHealthPaths(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		health componentHealthSettings
+	}{
+		{
+			name:   "path and status_path",
+			path:   "/status",
+			health: componentHealthSettings{StatusPath: "/status", LivenessPath: "/livez", ReadinessPath: "/readyz"},
+		},
+		{
+			name:   "path and liveness_path",
+			path:   "/livez",
+			health: componentHealthSettings{StatusPath: "/status", LivenessPath: "/livez", ReadinessPath: "/readyz"},
+		},
+		{
+			name:   "path and readiness_path",
+			path:   "/readyz",
+			health: componentHealthSettings{StatusPath: "/status", LivenessPath: "/livez", ReadinessPath: "/readyz"},
+		},
+		{
+			name:   "status_path and liveness_path",
+			path:   "/",
+			health: componentHealthSettings{StatusPath: "/health", LivenessPath: "/health", ReadinessPath: "/readyz"},
+		},
+		{
+			name:   "status_path and readiness_path",
+			path:   "/",
+			health: componentHealthSettings{StatusPath: "/health", LivenessPath: "/livez", ReadinessPath: "/health"},
+		},
+		{
+			name:   "liveness_path and readiness_path",
+			path:   "/",
+			health: componentHealthSettings{StatusPath: "/status", LivenessPath: "/health", ReadinessPath: "/health"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Path = tt.path
+			cfg.ComponentHealth = tt.health
+			cfg.ComponentHealth.Enabled = true
+			assert.ErrorIs(t, component.ValidateConfig(cfg), errDuplicatePath)
+		})
+	}
+}
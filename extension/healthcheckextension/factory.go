@@ -35,6 +35,7 @@ func createDefaultConfig() component.Config {
 			Endpoint: defaultEndpoint,
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentHealth:        defaultComponentHealthSettings(),
 		Path:                   "/",
 	}
 }
@@ -53,3 +54,13 @@ func defaultCheckCollectorPipelineSettings() checkCollectorPipelineSettings {
 		ExporterFailureThreshold: 5,
 	}
 }
+
+// defaultComponentHealthSettings returns the default settings for ComponentHealth.
+func defaultComponentHealthSettings() componentHealthSettings {
+	return componentHealthSettings{
+		Enabled:       false,
+		StatusPath:    "/status",
+		LivenessPath:  "/livez",
+		ReadinessPath: "/readyz",
+	}
+}
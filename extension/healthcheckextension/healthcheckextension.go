@@ -26,9 +26,12 @@ type healthCheckExtension struct {
 	stopCh   chan struct{}
 	exporter *healthCheckExporter
 	settings component.TelemetrySettings
+
+	componentStatus *componentStatusTracker
 }
 
 var _ extension.PipelineWatcher = (*healthCheckExtension)(nil)
+var _ extension.StatusWatcher = (*healthCheckExtension)(nil)
 
 func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) error {
 
@@ -47,6 +50,7 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 		// Mount HC handler
 		mux := http.NewServeMux()
 		mux.Handle(hc.config.Path, hc.baseHandler())
+		hc.handleComponentHealth(mux)
 		hc.server.Handler = mux
 		hc.stopCh = make(chan struct{})
 		go func() {
@@ -72,6 +76,7 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 
 		mux := http.NewServeMux()
 		mux.Handle(hc.config.Path, hc.checkCollectorPipelineHandler())
+		hc.handleComponentHealth(mux)
 		hc.server.Handler = mux
 		hc.stopCh = make(chan struct{})
 		go func() {
@@ -132,6 +137,16 @@ func (hc *healthCheckExtension) checkCollectorPipelineHandler() http.Handler {
 	})
 }
 
+// handleComponentHealth mounts the component health handlers, if enabled.
+func (hc *healthCheckExtension) handleComponentHealth(mux *http.ServeMux) {
+	if !hc.config.ComponentHealth.Enabled {
+		return
+	}
+	mux.Handle(hc.config.ComponentHealth.StatusPath, hc.componentStatusHandler())
+	mux.Handle(hc.config.ComponentHealth.LivenessPath, hc.livenessHandler())
+	mux.Handle(hc.config.ComponentHealth.ReadinessPath, hc.readinessHandler())
+}
+
 func (hc *healthCheckExtension) check() bool {
 	return hc.exporter.checkHealthStatus(hc.config.CheckCollectorPipeline.ExporterFailureThreshold)
 }
@@ -157,12 +172,18 @@ func (hc *healthCheckExtension) NotReady() error {
 	return nil
 }
 
+// ComponentStatusChanged records the latest status reported by a component.
+func (hc *healthCheckExtension) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	hc.componentStatus.set(source, event)
+}
+
 func newServer(config Config, settings component.TelemetrySettings) *healthCheckExtension {
 	hc := &healthCheckExtension{
-		config:   config,
-		logger:   settings.Logger,
-		state:    healthcheck.New(),
-		settings: settings,
+		config:          config,
+		logger:          settings.Logger,
+		state:           healthcheck.New(),
+		settings:        settings,
+		componentStatus: newComponentStatusTracker(),
 	}
 
 	hc.state.SetLogger(settings.Logger)
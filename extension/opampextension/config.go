@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oklog/ulid/v2"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config contains the configuration for the opamp extension. Trying to mirror
+// the OpAMP supervisor config for some consistency.
+type Config struct {
+	Server *OpAMPServer `mapstructure:"server"`
+
+	// InstanceUID is a ULID formatted as a 26 character string in canonical
+	// representation. Auto-generated on start if missing.
+	InstanceUID string `mapstructure:"instance_uid"`
+
+	// Capabilities contains options to enable a particular OpAMP capability.
+	Capabilities Capabilities `mapstructure:"capabilities"`
+}
+
+// OpAMPServer contains the OpAMP transport configuration.
+type OpAMPServer struct {
+	WS *OpAMPWebsocket `mapstructure:"ws"`
+}
+
+// OpAMPWebsocket contains the OpAMP websocket transport configuration.
+type OpAMPWebsocket struct {
+	Endpoint   string                         `mapstructure:"endpoint"`
+	Headers    map[string]configopaque.String `mapstructure:"headers"`
+	TLSSetting configtls.TLSClientSetting     `mapstructure:"tls"`
+}
+
+// Capabilities contains the OpAMP capabilities reported by the extension.
+type Capabilities struct {
+	// ReportsEffectiveConfig enables the OpAMP ReportsEffectiveConfig capability. Default: true.
+	ReportsEffectiveConfig bool `mapstructure:"reports_effective_config"`
+	// ReportsHealth enables the OpAMP ReportsHealth capability, reporting the status of the components. Default: true.
+	ReportsHealth bool `mapstructure:"reports_health"`
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Server == nil || cfg.Server.WS == nil || cfg.Server.WS.Endpoint == "" {
+		return errors.New("opamp server websocket endpoint must be provided")
+	}
+
+	if cfg.InstanceUID != "" {
+		if _, err := ulid.ParseStrict(cfg.InstanceUID); err != nil {
+			return fmt.Errorf("opamp instance_uid is invalid: %w", err)
+		}
+	}
+
+	return nil
+}
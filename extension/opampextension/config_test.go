@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub("opamp")
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	assert.Equal(t,
+		&Config{
+			Server: &OpAMPServer{
+				WS: &OpAMPWebsocket{
+					Endpoint: "wss://127.0.0.1:4320/v1/opamp",
+					Headers: map[string]configopaque.String{
+						"Authorization": "Secret-Key",
+					},
+				},
+			},
+			InstanceUID: "01BX5ZZKBKACTAV9WEVGEMMVRZ",
+			Capabilities: Capabilities{
+				ReportsEffectiveConfig: true,
+				ReportsHealth:          false,
+			},
+		}, cfg)
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *Config
+		expectedErr string
+	}{
+		{
+			name:        "missing server",
+			cfg:         &Config{},
+			expectedErr: "opamp server websocket endpoint must be provided",
+		},
+		{
+			name: "missing endpoint",
+			cfg: &Config{
+				Server: &OpAMPServer{WS: &OpAMPWebsocket{}},
+			},
+			expectedErr: "opamp server websocket endpoint must be provided",
+		},
+		{
+			name: "invalid instance_uid",
+			cfg: &Config{
+				Server:      &OpAMPServer{WS: &OpAMPWebsocket{Endpoint: "ws://127.0.0.1:4320/v1/opamp"}},
+				InstanceUID: "01BX5ZZKBKACTAV9WEVGEMMVRZFGH",
+			},
+			expectedErr: "opamp instance_uid is invalid: ulid: bad data size when unmarshaling",
+		},
+		{
+			name: "valid",
+			cfg: &Config{
+				Server: &OpAMPServer{WS: &OpAMPWebsocket{Endpoint: "ws://127.0.0.1:4320/v1/opamp"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package opampextension implements an extension managing the collector
+// from an OpAMP server, without a supervisor process.
+package opampextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension"
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.Equal(t, &Config{
+		Capabilities: Capabilities{
+			ReportsEffectiveConfig: true,
+			ReportsHealth:          true,
+		},
+	}, cfg)
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+
+	ext, err := createExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+}
+
+func TestFactory_CreateExtension_instanceUID(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.InstanceUID = "01BX5ZZKBKACTAV9WEVGEMMVRZ"
+	ext, err := createExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "01BX5ZZKBKACTAV9WEVGEMMVRZ", ext.(*opampAgent).instanceID.String())
+}
@@ -0,0 +1,337 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/open-telemetry/opamp-go/client"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// telemetryKey is the key of the own telemetry settings of the collector in its configuration.
+const telemetryKey = "service::telemetry"
+
+// opampAgent manages the collector it runs in from an OpAMP server. It reports the description
+// of the collector, its effective configuration and the health of its components.
+type opampAgent struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	agentType    string
+	agentVersion string
+	startTime    time.Time
+
+	mu              sync.Mutex
+	host            component.Host
+	instanceID      ulid.ULID
+	effectiveConfig *confmap.Conf
+	statuses        map[*component.InstanceID]*component.StatusEvent
+
+	opampClient client.OpAMPClient
+}
+
+var (
+	_ extension.ConfigWatcher = (*opampAgent)(nil)
+	_ extension.StatusWatcher = (*opampAgent)(nil)
+)
+
+func newOpampAgent(cfg *Config, set extension.CreateSettings) (*opampAgent, error) {
+	uid := ulid.Make()
+	if cfg.InstanceUID != "" {
+		var err error
+		if uid, err = ulid.ParseStrict(cfg.InstanceUID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &opampAgent{
+		cfg:          cfg,
+		logger:       set.Logger,
+		agentType:    set.BuildInfo.Command,
+		agentVersion: set.BuildInfo.Version,
+		startTime:    time.Now(),
+		instanceID:   uid,
+		statuses:     make(map[*component.InstanceID]*component.StatusEvent),
+	}, nil
+}
+
+func (o *opampAgent) Start(_ context.Context, host component.Host) error {
+	header := http.Header{}
+	for k, v := range o.cfg.Server.WS.Headers {
+		header.Set(k, string(v))
+	}
+
+	tlsConfig, err := o.cfg.Server.WS.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return err
+	}
+
+	opampClient := client.NewWebSocket(o.logger.Sugar())
+
+	o.mu.Lock()
+	o.host = host
+	settings := types.StartSettings{
+		OpAMPServerURL: o.cfg.Server.WS.Endpoint,
+		Header:         header,
+		TLSConfig:      tlsConfig,
+		InstanceUid:    o.instanceID.String(),
+		Callbacks: types.CallbacksStruct{
+			OnConnectFunc: func() {
+				o.logger.Debug("Connected to the OpAMP server")
+			},
+			OnConnectFailedFunc: func(err error) {
+				o.logger.Error("Failed to connect to the OpAMP server", zap.Error(err))
+			},
+			OnErrorFunc: func(err *protobufs.ServerErrorResponse) {
+				o.logger.Error("OpAMP server returned an error response", zap.String("message", err.ErrorMessage))
+			},
+			OnMessageFunc: o.onMessage,
+			GetEffectiveConfigFunc: func(_ context.Context) (*protobufs.EffectiveConfig, error) {
+				return o.composeEffectiveConfig(), nil
+			},
+		},
+		Capabilities: o.capabilities(),
+	}
+	err = opampClient.SetAgentDescription(o.createAgentDescription())
+	if err == nil && o.cfg.Capabilities.ReportsHealth {
+		err = opampClient.SetHealth(o.createHealth())
+	}
+	o.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	o.logger.Debug("Starting OpAMP client")
+	if err = opampClient.Start(context.Background(), settings); err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	o.opampClient = opampClient
+	o.mu.Unlock()
+	return nil
+}
+
+func (o *opampAgent) Shutdown(ctx context.Context) error {
+	o.mu.Lock()
+	opampClient := o.opampClient
+	o.opampClient = nil
+	o.mu.Unlock()
+	if opampClient == nil {
+		return nil
+	}
+
+	o.logger.Debug("Stopping OpAMP client")
+	return opampClient.Stop(ctx)
+}
+
+// NotifyConfig is called with the effective configuration of the collector once it has started,
+// the configuration and the own telemetry settings it contains are reported to the OpAMP server.
+func (o *opampAgent) NotifyConfig(ctx context.Context, conf *confmap.Conf) error {
+	o.mu.Lock()
+	o.effectiveConfig = conf
+	opampClient := o.opampClient
+	var err error
+	if opampClient != nil {
+		err = opampClient.SetAgentDescription(o.createAgentDescription())
+	}
+	o.mu.Unlock()
+	if opampClient == nil || err != nil {
+		return err
+	}
+
+	if o.cfg.Capabilities.ReportsEffectiveConfig {
+		return opampClient.UpdateEffectiveConfig(ctx)
+	}
+	return nil
+}
+
+// ComponentStatusChanged keeps the status of the components of the collector, their aggregated
+// status is reported to the OpAMP server as the health of the agent.
+func (o *opampAgent) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.statuses[source] = event
+
+	if o.opampClient == nil || !o.cfg.Capabilities.ReportsHealth {
+		return
+	}
+	if err := o.opampClient.SetHealth(o.createHealth()); err != nil {
+		o.logger.Error("Failed to report health to the OpAMP server", zap.Error(err))
+	}
+}
+
+func (o *opampAgent) onMessage(_ context.Context, msg *types.MessageData) {
+	if msg.AgentIdentification != nil {
+		instanceID, err := ulid.Parse(msg.AgentIdentification.NewInstanceUid)
+		if err != nil {
+			o.logger.Error("Failed to parse instance ULID", zap.Error(err))
+			return
+		}
+
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		o.logger.Debug("Agent identity is changing",
+			zap.String("old_id", o.instanceID.String()),
+			zap.String("new_id", instanceID.String()))
+		o.instanceID = instanceID
+		if o.opampClient == nil {
+			return
+		}
+		if err = o.opampClient.SetAgentDescription(o.createAgentDescription()); err != nil {
+			o.logger.Error("Failed to send agent description to the OpAMP server", zap.Error(err))
+		}
+	}
+}
+
+func (o *opampAgent) capabilities() protobufs.AgentCapabilities {
+	capabilities := protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus
+	if o.cfg.Capabilities.ReportsEffectiveConfig {
+		capabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig
+	}
+	if o.cfg.Capabilities.ReportsHealth {
+		capabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsHealth
+	}
+	return capabilities
+}
+
+// createAgentDescription must be called with the mutex held.
+func (o *opampAgent) createAgentDescription() *protobufs.AgentDescription {
+	hostname, _ := os.Hostname()
+
+	nonIdentifyingAttributes := []*protobufs.KeyValue{
+		keyVal("os.type", runtime.GOOS),
+		keyVal("host.arch", runtime.GOARCH),
+		keyVal("host.name", hostname),
+	}
+	nonIdentifyingAttributes = append(nonIdentifyingAttributes, o.telemetryAttributes()...)
+
+	return &protobufs.AgentDescription{
+		IdentifyingAttributes: []*protobufs.KeyValue{
+			keyVal("service.name", o.agentType),
+			keyVal("service.version", o.agentVersion),
+			keyVal("service.instance.id", o.instanceID.String()),
+		},
+		NonIdentifyingAttributes: nonIdentifyingAttributes,
+	}
+}
+
+// telemetryAttributes returns the own telemetry settings of the collector as attributes named
+// after their path in the configuration, for example "service.telemetry.metrics.level".
+// Only scalar settings are reported, in the order of their names.
+func (o *opampAgent) telemetryAttributes() []*protobufs.KeyValue {
+	if o.effectiveConfig == nil || !o.effectiveConfig.IsSet(telemetryKey) {
+		return nil
+	}
+	telemetry, err := o.effectiveConfig.Sub(telemetryKey)
+	if err != nil {
+		return nil
+	}
+
+	var attrs []*protobufs.KeyValue
+	for _, key := range telemetry.AllKeys() {
+		switch value := telemetry.Get(key).(type) {
+		case string, bool, int, int64, float64:
+			name := "service.telemetry." + strings.ReplaceAll(key, confmap.KeyDelimiter, ".")
+			attrs = append(attrs, keyVal(name, fmt.Sprint(value)))
+		}
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Key < attrs[j].Key
+	})
+	return attrs
+}
+
+// createHealth aggregates the status of the components, must be called with the mutex held.
+func (o *opampAgent) createHealth() *protobufs.AgentHealth {
+	health := &protobufs.AgentHealth{
+		Healthy:           true,
+		StartTimeUnixNano: uint64(o.startTime.UnixNano()),
+	}
+	if len(o.statuses) == 0 {
+		return health
+	}
+
+	events := make(map[string]*component.StatusEvent, len(o.statuses))
+	var errs []string
+	for source, event := range o.statuses {
+		name := kindString(source.Kind) + ":" + source.ID.String()
+		events[name] = event
+		if event.Err() != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s: %v", name, event.Status(), event.Err()))
+		}
+	}
+	sort.Strings(errs)
+
+	health.Healthy = !component.StatusIsError(component.AggregateStatusEvent(events).Status())
+	health.LastError = strings.Join(errs, "; ")
+	return health
+}
+
+func (o *opampAgent) composeEffectiveConfig() *protobufs.EffectiveConfig {
+	o.mu.Lock()
+	conf := o.effectiveConfig
+	host := o.host
+	o.mu.Unlock()
+
+	var body []byte
+	if conf != nil {
+		raw := conf.ToStringMap()
+		redactConfig(raw, host)
+		var err error
+		if body, err = yaml.Marshal(raw); err != nil {
+			o.logger.Error("Failed to marshal the effective config", zap.Error(err))
+		}
+	}
+
+	return &protobufs.EffectiveConfig{
+		ConfigMap: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {Body: body},
+			},
+		},
+	}
+}
+
+func keyVal(key, val string) *protobufs.KeyValue {
+	return &protobufs.KeyValue{
+		Key: key,
+		Value: &protobufs.AnyValue{
+			Value: &protobufs.AnyValue_StringValue{StringValue: val},
+		},
+	}
+}
+
+func kindString(kind component.Kind) string {
+	switch kind {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	case component.KindConnector:
+		return "connector"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/oklog/ulid/v2"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"google.golang.org/protobuf/proto"
+)
+
+// testServer is a stand-in OpAMP server recording the messages sent by agents over websocket.
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	messages []*protobufs.AgentToServer
+	// reply returns the response to a message, the instance UID is echoed if it returns nil.
+	reply func(*protobufs.AgentToServer) *protobufs.ServerToAgent
+}
+
+func newTestServer(t *testing.T, reply func(*protobufs.AgentToServer) *protobufs.ServerToAgent) *testServer {
+	s := &testServer{reply: reply}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			mt, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if mt != websocket.BinaryMessage || len(data) == 0 {
+				continue
+			}
+			// Messages are prefixed with a varint header, always 0 in the current protocol.
+			msg := &protobufs.AgentToServer{}
+			if err = proto.Unmarshal(data[1:], msg); err != nil {
+				continue
+			}
+
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+
+			var resp *protobufs.ServerToAgent
+			if s.reply != nil {
+				resp = s.reply(msg)
+			}
+			if resp == nil {
+				resp = &protobufs.ServerToAgent{InstanceUid: msg.InstanceUid}
+			}
+			out, err := proto.Marshal(resp)
+			if err != nil {
+				return
+			}
+			if err = conn.WriteMessage(websocket.BinaryMessage, append([]byte{0}, out...)); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testServer) endpoint() string {
+	return "ws://" + strings.TrimPrefix(s.URL, "http://") + "/v1/opamp"
+}
+
+// received reports whether a message matching the given predicate was received.
+func (s *testServer) received(match func(*protobufs.AgentToServer) bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, msg := range s.messages {
+		if match(msg) {
+			return true
+		}
+	}
+	return false
+}
+
+func attributeValue(attrs []*protobufs.KeyValue, key string) string {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value.GetStringValue()
+		}
+	}
+	return ""
+}
+
+func newTestAgent(t *testing.T, endpoint string) *opampAgent {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Server = &OpAMPServer{WS: &OpAMPWebsocket{Endpoint: endpoint}}
+	cfg.InstanceUID = "01BX5ZZKBKACTAV9WEVGEMMVRZ"
+	agent, err := newOpampAgent(cfg, extensiontest.NewNopCreateSettings())
+	require.NoError(t, err)
+	return agent
+}
+
+func TestOpampAgent(t *testing.T) {
+	server := newTestServer(t, nil)
+	agent := newTestAgent(t, server.endpoint())
+	require.NoError(t, agent.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, agent.Shutdown(context.Background()))
+	}()
+
+	assert.Eventually(t, func() bool {
+		return server.received(func(msg *protobufs.AgentToServer) bool {
+			desc := msg.AgentDescription
+			return desc != nil &&
+				attributeValue(desc.IdentifyingAttributes, "service.instance.id") == "01BX5ZZKBKACTAV9WEVGEMMVRZ" &&
+				attributeValue(desc.IdentifyingAttributes, "service.name") == "otelcol"
+		})
+	}, 5*time.Second, 10*time.Millisecond)
+
+	exporter := &component.InstanceID{
+		ID:          component.NewID("otlp"),
+		Kind:        component.KindExporter,
+		PipelineIDs: map[component.ID]struct{}{component.NewID("traces"): {}},
+	}
+	agent.ComponentStatusChanged(exporter, component.NewStatusEvent(component.StatusOK))
+	agent.ComponentStatusChanged(exporter, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+	assert.Eventually(t, func() bool {
+		return server.received(func(msg *protobufs.AgentToServer) bool {
+			return msg.Health != nil && !msg.Health.Healthy &&
+				strings.Contains(msg.Health.LastError, "exporter:otlp") &&
+				strings.Contains(msg.Health.LastError, "connection refused")
+		})
+	}, 5*time.Second, 10*time.Millisecond)
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"service": map[string]any{
+			"telemetry": map[string]any{
+				"metrics": map[string]any{"level": "detailed"},
+			},
+		},
+	})
+	require.NoError(t, agent.NotifyConfig(context.Background(), conf))
+	assert.Eventually(t, func() bool {
+		return server.received(func(msg *protobufs.AgentToServer) bool {
+			cfg := msg.GetEffectiveConfig().GetConfigMap().GetConfigMap()[""]
+			return cfg != nil && strings.Contains(string(cfg.Body), "level: detailed")
+		})
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return server.received(func(msg *protobufs.AgentToServer) bool {
+			desc := msg.AgentDescription
+			return desc != nil && attributeValue(desc.NonIdentifyingAttributes, "service.telemetry.metrics.level") == "detailed"
+		})
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestOpampAgent_agentIdentification(t *testing.T) {
+	newID := ulid.Make().String()
+	server := newTestServer(t, func(msg *protobufs.AgentToServer) *protobufs.ServerToAgent {
+		if msg.InstanceUid == newID {
+			return nil
+		}
+		return &protobufs.ServerToAgent{
+			InstanceUid:         msg.InstanceUid,
+			AgentIdentification: &protobufs.AgentIdentification{NewInstanceUid: newID},
+		}
+	})
+	agent := newTestAgent(t, server.endpoint())
+	require.NoError(t, agent.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, agent.Shutdown(context.Background()))
+	}()
+
+	assert.Eventually(t, func() bool {
+		return server.received(func(msg *protobufs.AgentToServer) bool {
+			return msg.AgentDescription != nil &&
+				attributeValue(msg.AgentDescription.IdentifyingAttributes, "service.instance.id") == newID
+		})
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestOpampAgent_startError(t *testing.T) {
+	agent := newTestAgent(t, "ws://127.0.0.1:4320/v1/opamp")
+	agent.cfg.Server.WS.TLSSetting.CAFile = "/doesnotexist"
+	assert.ErrorContains(t, agent.Start(context.Background(), componenttest.NewNopHost()), "failed to load TLS config")
+	assert.NoError(t, agent.Shutdown(context.Background()))
+}
+
+func TestCreateHealth(t *testing.T) {
+	agent := newTestAgent(t, "ws://127.0.0.1:4320/v1/opamp")
+	assert.True(t, agent.createHealth().Healthy)
+
+	receiver := &component.InstanceID{ID: component.NewID("otlp"), Kind: component.KindReceiver}
+	exporter := &component.InstanceID{ID: component.NewIDWithName("otlp", "2"), Kind: component.KindExporter}
+	agent.ComponentStatusChanged(receiver, component.NewStatusEvent(component.StatusOK))
+	agent.ComponentStatusChanged(exporter, component.NewStatusEvent(component.StatusOK))
+	health := agent.createHealth()
+	assert.True(t, health.Healthy)
+	assert.Empty(t, health.LastError)
+
+	agent.ComponentStatusChanged(exporter, component.NewPermanentErrorEvent(errors.New("invalid credentials")))
+	agent.ComponentStatusChanged(receiver, component.NewRecoverableErrorEvent(errors.New("address in use")))
+	health = agent.createHealth()
+	assert.False(t, health.Healthy)
+	assert.Equal(t,
+		"exporter:otlp/2: StatusPermanentError: invalid credentials; receiver:otlp: StatusRecoverableError: address in use",
+		health.LastError)
+}
+
+func TestTelemetryAttributes(t *testing.T) {
+	agent := newTestAgent(t, "ws://127.0.0.1:4320/v1/opamp")
+	assert.Empty(t, agent.telemetryAttributes())
+
+	agent.effectiveConfig = confmap.NewFromStringMap(map[string]any{
+		"service": map[string]any{
+			"telemetry": map[string]any{
+				"logs":    map[string]any{"level": "debug", "output_paths": []any{"stderr"}},
+				"metrics": map[string]any{"level": "basic", "address": ":8888"},
+			},
+		},
+	})
+	attrs := agent.telemetryAttributes()
+	require.Len(t, attrs, 3)
+	assert.Equal(t, "service.telemetry.logs.level", attrs[0].Key)
+	assert.Equal(t, "debug", attrs[0].Value.GetStringValue())
+	assert.Equal(t, "service.telemetry.metrics.address", attrs[1].Key)
+	assert.Equal(t, ":8888", attrs[1].Value.GetStringValue())
+	assert.Equal(t, "service.telemetry.metrics.level", attrs[2].Key)
+	assert.Equal(t, "basic", attrs[2].Value.GetStringValue())
+}
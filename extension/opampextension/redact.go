@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension"
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// redactedValue replaces the values which must not be reported, as configopaque does.
+const redactedValue = "[REDACTED]"
+
+// componentKinds are the sections of the configuration holding the configuration of components.
+var componentKinds = map[string]component.Kind{
+	"receivers":  component.KindReceiver,
+	"processors": component.KindProcessor,
+	"exporters":  component.KindExporter,
+	"extensions": component.KindExtension,
+	"connectors": component.KindConnector,
+}
+
+// redactConfig replaces the opaque settings of the components in the configuration, such as passwords
+// or API keys, with [REDACTED]. The raw configuration doesn't tell them apart, so the configuration of
+// every component is unmarshalled into the config of its factory, which marshals its opaque settings
+// redacted. Components whose configuration can't be unmarshalled are redacted altogether.
+func redactConfig(conf map[string]any, host component.Host) {
+	for section, kind := range componentKinds {
+		components, ok := conf[section].(map[string]any)
+		if !ok {
+			continue
+		}
+		for key, value := range components {
+			raw, ok := value.(map[string]any)
+			if !ok {
+				// components without any setting use the default config
+				continue
+			}
+			redacted, ok := marshalComponentConfig(host, kind, key, raw)
+			if !ok {
+				components[key] = redactedValue
+				continue
+			}
+			redactValues(raw, redacted)
+		}
+	}
+}
+
+// marshalComponentConfig unmarshals the raw configuration of the component into the config of its
+// factory and marshals it back.
+func marshalComponentConfig(host component.Host, kind component.Kind, key string, raw map[string]any) (map[string]any, bool) {
+	if host == nil {
+		return nil, false
+	}
+	var id component.ID
+	if err := id.UnmarshalText([]byte(key)); err != nil {
+		return nil, false
+	}
+	factory := host.GetFactory(kind, id.Type())
+	if factory == nil {
+		return nil, false
+	}
+
+	cfg := factory.CreateDefaultConfig()
+	if err := component.UnmarshalConfig(confmap.NewFromStringMap(raw), cfg); err != nil {
+		return nil, false
+	}
+	marshaled := confmap.New()
+	if err := marshaled.Marshal(cfg); err != nil {
+		return nil, false
+	}
+	return marshaled.ToStringMap(), true
+}
+
+// redactValues replaces the values of raw which are redacted in marshaled.
+func redactValues(raw map[string]any, marshaled map[string]any) {
+	for key, value := range raw {
+		switch m := marshaled[key].(type) {
+		case string:
+			if m == redactedValue {
+				raw[key] = redactedValue
+			}
+		case map[string]any:
+			if v, ok := value.(map[string]any); ok {
+				redactValues(v, m)
+			}
+		case []any:
+			v, ok := value.([]any)
+			if !ok || len(v) != len(m) {
+				continue
+			}
+			for i := range v {
+				vi, ok1 := v[i].(map[string]any)
+				mi, ok2 := m[i].(map[string]any)
+				switch {
+				case ok1 && ok2:
+					redactValues(vi, mi)
+				case m[i] == redactedValue:
+					v[i] = redactedValue
+				}
+			}
+		}
+	}
+}
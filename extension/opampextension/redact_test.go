@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension/internal/metadata"
+)
+
+// factoryHost is a host knowing the factory of the opamp extension only.
+type factoryHost struct {
+	component.Host
+}
+
+func (h factoryHost) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
+	if kind == component.KindExtension && componentType == metadata.Type {
+		return NewFactory()
+	}
+	return nil
+}
+
+func secretConfig() map[string]any {
+	return map[string]any{
+		"extensions": map[string]any{
+			"opamp": map[string]any{
+				"server": map[string]any{
+					"ws": map[string]any{
+						"endpoint": "wss://127.0.0.1:4320/v1/opamp",
+						"headers":  map[string]any{"Authorization": "Bearer secret"},
+					},
+				},
+			},
+			"opamp/default": nil,
+		},
+		"exporters": map[string]any{
+			"unknown": map[string]any{"api_key": "secret"},
+		},
+		"service": map[string]any{
+			"extensions": []any{"opamp", "opamp/default"},
+		},
+	}
+}
+
+func TestRedactConfig(t *testing.T) {
+	conf := secretConfig()
+	redactConfig(conf, factoryHost{Host: componenttest.NewNopHost()})
+
+	assert.Equal(t, map[string]any{
+		"extensions": map[string]any{
+			"opamp": map[string]any{
+				"server": map[string]any{
+					"ws": map[string]any{
+						"endpoint": "wss://127.0.0.1:4320/v1/opamp",
+						"headers":  map[string]any{"Authorization": redactedValue},
+					},
+				},
+			},
+			"opamp/default": nil,
+		},
+		// the settings of components without a factory can't be told apart
+		"exporters": map[string]any{
+			"unknown": redactedValue,
+		},
+		"service": map[string]any{
+			"extensions": []any{"opamp", "opamp/default"},
+		},
+	}, conf)
+}
+
+func TestComposeEffectiveConfig_redacted(t *testing.T) {
+	agent := newTestAgent(t, "ws://127.0.0.1:4320/v1/opamp")
+	agent.host = factoryHost{Host: componenttest.NewNopHost()}
+	agent.effectiveConfig = confmap.NewFromStringMap(secretConfig())
+
+	body := string(agent.composeEffectiveConfig().GetConfigMap().GetConfigMap()[""].Body)
+	require.NotEmpty(t, body)
+	assert.NotContains(t, body, "secret")
+	assert.Contains(t, body, "Authorization: '[REDACTED]'")
+	assert.Contains(t, body, "endpoint: wss://127.0.0.1:4320/v1/opamp")
+}
@@ -5,10 +5,13 @@ go 1.20
 require (
 	github.com/IBM/sarama v1.41.2
 	github.com/aws/aws-sdk-go v1.45.24
+	github.com/linkedin/goavro/v2 v2.9.8
 	github.com/stretchr/testify v1.8.4
 	github.com/xdg-go/scram v1.1.2
+	go.opentelemetry.io/collector/config/configopaque v0.87.0
 	go.opentelemetry.io/collector/config/configtls v0.87.0
 	go.uber.org/multierr v1.11.0
+	google.golang.org/protobuf v1.31.0
 )
 
 require (
@@ -33,7 +36,6 @@ require (
 	github.com/rcrowley/go-metrics v0.0.0-20201227073835-cf1acfcdf475 // indirect
 	github.com/xdg-go/pbkdf2 v1.0.0 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
-	go.opentelemetry.io/collector/config/configopaque v0.87.0 // indirect
 	golang.org/x/crypto v0.14.0 // indirect
 	golang.org/x/net v0.17.0 // indirect
 	golang.org/x/sys v0.13.0 // indirect
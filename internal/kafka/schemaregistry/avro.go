@@ -0,0 +1,396 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+)
+
+// avroLogicalTypes are the logical types goavro converts to Go types, it ignores the others.
+var avroLogicalTypes = map[string]bool{
+	"timestamp-millis": true,
+	"timestamp-micros": true,
+	"time-millis":      true,
+	"time-micros":      true,
+	"date":             true,
+	"decimal":          true,
+}
+
+// avroSchema encodes records with an Avro schema. goavro represents records with Go types
+// that depend on the schema, e.g. unions are maps from the name of the type of their value to
+// their value, so records are converted walking the schema.
+type avroSchema struct {
+	codec *goavro.Codec
+	// schema is the parsed JSON schema, whose named types have been renamed to their full name.
+	schema any
+	// names are the named types by full and short name.
+	names map[string]map[string]any
+}
+
+func newAvroSchema(spec string) (*avroSchema, error) {
+	codec, err := goavro.NewCodec(spec)
+	if err != nil {
+		return nil, err
+	}
+	s := &avroSchema{
+		codec: codec,
+		names: make(map[string]map[string]any),
+	}
+	if err = json.Unmarshal([]byte(spec), &s.schema); err != nil {
+		return nil, err
+	}
+	s.registerNames(s.schema, "")
+	return s, nil
+}
+
+// registerNames renames the named types of schema to their full name and registers them.
+func (s *avroSchema) registerNames(schema any, namespace string) {
+	switch schema := schema.(type) {
+	case []any:
+		for _, member := range schema {
+			s.registerNames(member, namespace)
+		}
+	case map[string]any:
+		switch schema["type"] {
+		case "record", "error", "enum", "fixed":
+			name, _ := schema["name"].(string)
+			if ns, ok := schema["namespace"].(string); ok && !strings.Contains(name, ".") {
+				namespace = ns
+			}
+			fullName := name
+			if i := strings.LastIndex(name, "."); i >= 0 {
+				namespace = name[:i]
+			} else if namespace != "" {
+				fullName = namespace + "." + name
+			}
+			schema["name"] = fullName
+			s.names[fullName] = schema
+			s.names[fullName[strings.LastIndex(fullName, ".")+1:]] = schema
+
+			fields, _ := schema["fields"].([]any)
+			for _, field := range fields {
+				if field, ok := field.(map[string]any); ok {
+					s.registerNames(field["type"], namespace)
+				}
+			}
+		case "array":
+			s.registerNames(schema["items"], namespace)
+		case "map":
+			s.registerNames(schema["values"], namespace)
+		default:
+			s.registerNames(schema["type"], namespace)
+		}
+	}
+}
+
+// typeName returns the name goavro gives to the values of a union member.
+func (s *avroSchema) typeName(schema any) string {
+	switch schema := schema.(type) {
+	case string:
+		if named, ok := s.names[schema]; ok {
+			return named["name"].(string)
+		}
+		return schema
+	case map[string]any:
+		switch schema["type"] {
+		case "record", "error", "enum", "fixed":
+			return schema["name"].(string)
+		}
+		if logicalType, ok := schema["logicalType"].(string); ok && avroLogicalTypes[logicalType] {
+			return fmt.Sprintf("%s.%s", s.typeName(schema["type"]), logicalType)
+		}
+		return s.typeName(schema["type"])
+	}
+	return ""
+}
+
+func (s *avroSchema) decode(payload []byte) (any, error) {
+	native, _, err := s.codec.NativeFromBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	return s.toRaw(s.schema, native), nil
+}
+
+func (s *avroSchema) encode(buf []byte, record any) ([]byte, error) {
+	native, err := s.fromRaw(s.schema, record)
+	if err != nil {
+		return nil, err
+	}
+	return s.codec.BinaryFromNative(buf, native)
+}
+
+// toRaw converts a value decoded by goavro.
+func (s *avroSchema) toRaw(schema any, value any) any {
+	switch schema := schema.(type) {
+	case []any:
+		// Unions are decoded as a map from the name of the type of the value to the value.
+		if union, ok := value.(map[string]any); ok {
+			for name, v := range union {
+				for _, member := range schema {
+					if s.typeName(member) == name {
+						return s.toRaw(member, v)
+					}
+				}
+			}
+		}
+	case string:
+		if named, ok := s.names[schema]; ok {
+			return s.toRaw(named, value)
+		}
+	case map[string]any:
+		switch schema["type"] {
+		case "record", "error":
+			fields, _ := schema["fields"].([]any)
+			record, _ := value.(map[string]any)
+			raw := make(map[string]any, len(record))
+			for _, field := range fields {
+				field := field.(map[string]any)
+				name := field["name"].(string)
+				if v, ok := record[name]; ok {
+					raw[name] = s.toRaw(field["type"], v)
+				}
+			}
+			return raw
+		case "array":
+			items, _ := value.([]any)
+			raw := make([]any, len(items))
+			for i, item := range items {
+				raw[i] = s.toRaw(schema["items"], item)
+			}
+			return raw
+		case "map":
+			entries, _ := value.(map[string]any)
+			raw := make(map[string]any, len(entries))
+			for k, v := range entries {
+				raw[k] = s.toRaw(schema["values"], v)
+			}
+			return raw
+		case "enum", "fixed":
+		default:
+			return s.toRaw(schema["type"], value)
+		}
+	}
+	return avroPrimitiveToRaw(value)
+}
+
+func avroPrimitiveToRaw(value any) any {
+	switch v := value.(type) {
+	case int32:
+		return int64(v)
+	case float32:
+		return float64(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.String()
+	case *big.Rat:
+		f, _ := v.Float64()
+		return f
+	}
+	return value
+}
+
+// fromRaw converts a value to the Go type goavro encodes for schema.
+func (s *avroSchema) fromRaw(schema any, value any) (any, error) {
+	switch schema := schema.(type) {
+	case []any:
+		if value == nil {
+			for _, member := range schema {
+				if member == "null" {
+					return nil, nil
+				}
+			}
+			return nil, fmt.Errorf("cannot encode null as avro union %v", schema)
+		}
+		// The value is encoded as the first member it can be converted to.
+		for _, member := range schema {
+			if member == "null" {
+				continue
+			}
+			if native, err := s.fromRaw(member, value); err == nil {
+				return goavro.Union(s.typeName(member), native), nil
+			}
+		}
+		return nil, fmt.Errorf("cannot encode %T as avro union %v", value, schema)
+	case string:
+		if named, ok := s.names[schema]; ok {
+			return s.fromRaw(named, value)
+		}
+		return avroPrimitiveFromRaw(schema, value)
+	case map[string]any:
+		if logicalType, ok := schema["logicalType"].(string); ok && avroLogicalTypes[logicalType] {
+			return avroLogicalFromRaw(logicalType, value)
+		}
+		switch schema["type"] {
+		case "record", "error":
+			record, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("cannot encode %T as avro record %s", value, schema["name"])
+			}
+			fields, _ := schema["fields"].([]any)
+			native := make(map[string]any, len(fields))
+			for _, field := range fields {
+				field := field.(map[string]any)
+				name := field["name"].(string)
+				v, ok := record[name]
+				if !ok {
+					// goavro uses the default value of the field, if it has one.
+					continue
+				}
+				n, err := s.fromRaw(field["type"], v)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", name, err)
+				}
+				native[name] = n
+			}
+			return native, nil
+		case "array":
+			items, ok := value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("cannot encode %T as avro array", value)
+			}
+			native := make([]any, len(items))
+			for i, item := range items {
+				n, err := s.fromRaw(schema["items"], item)
+				if err != nil {
+					return nil, err
+				}
+				native[i] = n
+			}
+			return native, nil
+		case "map":
+			entries, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("cannot encode %T as avro map", value)
+			}
+			native := make(map[string]any, len(entries))
+			for k, v := range entries {
+				n, err := s.fromRaw(schema["values"], v)
+				if err != nil {
+					return nil, err
+				}
+				native[k] = n
+			}
+			return native, nil
+		case "enum":
+			symbol, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("cannot encode %T as avro enum %s", value, schema["name"])
+			}
+			symbols, _ := schema["symbols"].([]any)
+			for _, sym := range symbols {
+				if sym == symbol {
+					return symbol, nil
+				}
+			}
+			return nil, fmt.Errorf("%q is not a symbol of avro enum %s", symbol, schema["name"])
+		case "fixed":
+			return avroPrimitiveFromRaw("bytes", value)
+		}
+		return s.fromRaw(schema["type"], value)
+	}
+	return nil, fmt.Errorf("invalid avro schema %v", schema)
+}
+
+func avroPrimitiveFromRaw(typ string, value any) (any, error) {
+	switch typ {
+	case "null":
+		if value == nil {
+			return nil, nil
+		}
+	case "boolean":
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case "int":
+		if i, ok := rawInt(value); ok && i >= math.MinInt32 && i <= math.MaxInt32 {
+			return int32(i), nil
+		}
+	case "long":
+		if i, ok := rawInt(value); ok {
+			return i, nil
+		}
+	case "float":
+		if f, ok := rawFloat(value); ok {
+			return float32(f), nil
+		}
+	case "double":
+		if f, ok := rawFloat(value); ok {
+			return f, nil
+		}
+	case "bytes":
+		switch b := value.(type) {
+		case []byte:
+			return b, nil
+		case string:
+			return []byte(b), nil
+		}
+	case "string":
+		if str, ok := value.(string); ok {
+			return str, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot encode %T as avro %s", value, typ)
+}
+
+// avroLogicalFromRaw converts values to the Go types of the supported Avro logical types, from
+// the representation avroPrimitiveToRaw gives them in records or from the underlying Avro type.
+func avroLogicalFromRaw(logicalType string, value any) (any, error) {
+	switch logicalType {
+	case "timestamp-millis", "timestamp-micros", "date":
+		switch v := value.(type) {
+		case string:
+			for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+				if t, err := time.Parse(layout, v); err == nil {
+					return t, nil
+				}
+			}
+		default:
+			if i, ok := rawInt(v); ok {
+				switch logicalType {
+				case "timestamp-millis":
+					return time.UnixMilli(i).UTC(), nil
+				case "timestamp-micros":
+					return time.UnixMicro(i).UTC(), nil
+				default:
+					return time.Unix(i*24*60*60, 0).UTC(), nil
+				}
+			}
+		}
+	case "time-millis", "time-micros":
+		switch v := value.(type) {
+		case string:
+			if d, err := time.ParseDuration(v); err == nil {
+				return d, nil
+			}
+		default:
+			if i, ok := rawInt(v); ok {
+				if logicalType == "time-millis" {
+					return time.Duration(i) * time.Millisecond, nil
+				}
+				return time.Duration(i) * time.Microsecond, nil
+			}
+		}
+	case "decimal":
+		switch v := value.(type) {
+		case string:
+			if r, ok := new(big.Rat).SetString(v); ok {
+				return r, nil
+			}
+		default:
+			if f, ok := rawFloat(v); ok {
+				return new(big.Rat).SetFloat64(f), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("cannot encode %T as avro %s", value, logicalType)
+}
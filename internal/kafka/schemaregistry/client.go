@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Format is the serialization format of records, named after the schema types of the schema registry.
+type Format string
+
+const (
+	FormatAvro     Format = "AVRO"
+	FormatProtobuf Format = "PROTOBUF"
+)
+
+const (
+	defaultTimeout         = 10 * time.Second
+	defaultLatestSchemaTTL = 5 * time.Minute
+
+	contentType = "application/vnd.schemaregistry.v1+json"
+)
+
+// schemaResponse is the schema returned by the schema registry.
+type schemaResponse struct {
+	ID         int         `json:"id"`
+	SchemaType Format      `json:"schemaType"`
+	Schema     string      `json:"schema"`
+	References []reference `json:"references"`
+}
+
+// reference is a schema a schema imports, e.g. a Protobuf file.
+type reference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// schema is a schema of the registry compiled for its format.
+type schema struct {
+	id       int
+	format   Format
+	avro     *avroSchema
+	protobuf *protobufSchema
+}
+
+// client looks up schemas in a schema registry. Schemas are immutable and cached by ID,
+// the latest schema of a subject is cached too and looked up again once its TTL elapsed.
+type client struct {
+	endpoint        string
+	username        string
+	password        string
+	httpClient      *http.Client
+	latestSchemaTTL time.Duration
+
+	mu        sync.Mutex
+	byID      map[int]*schema
+	bySubject map[string]cachedSchema
+}
+
+// cachedSchema is the latest schema of a subject and when it was looked up.
+type cachedSchema struct {
+	schema    *schema
+	fetchedAt time.Time
+}
+
+func newClient(cfg Config) (*client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.TLS != nil {
+		tlsConfig, err := cfg.TLS.LoadTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	latestSchemaTTL := cfg.LatestSchemaTTL
+	if latestSchemaTTL == 0 {
+		latestSchemaTTL = defaultLatestSchemaTTL
+	}
+
+	return &client{
+		endpoint: strings.TrimSuffix(cfg.Endpoint, "/"),
+		username: cfg.Username,
+		password: string(cfg.Password),
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+		},
+		latestSchemaTTL: latestSchemaTTL,
+		byID:            make(map[int]*schema),
+		bySubject:       make(map[string]cachedSchema),
+	}, nil
+}
+
+// schemaByID returns the schema with the given ID.
+func (c *client) schemaByID(ctx context.Context, id int) (*schema, error) {
+	c.mu.Lock()
+	s, ok := c.byID[id]
+	c.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+
+	path := fmt.Sprintf("/schemas/ids/%d", id)
+	resp, err := c.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	resp.ID = id
+	if s, err = c.compile(ctx, path, resp); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byID[id] = s
+	return s, nil
+}
+
+// latestSchema returns the latest schema of the given subject. Once the TTL of the cached
+// latest schema elapsed, it is looked up again. If that fails, the cached schema keeps being
+// used until the TTL elapses again, so that an unavailable registry doesn't stop serialization.
+func (c *client) latestSchema(ctx context.Context, subject string) (*schema, error) {
+	now := time.Now()
+	c.mu.Lock()
+	cached, ok := c.bySubject[subject]
+	c.mu.Unlock()
+	if ok && now.Sub(cached.fetchedAt) < c.latestSchemaTTL {
+		return cached.schema, nil
+	}
+
+	s, err := c.fetchLatestSchema(ctx, subject)
+	if err != nil {
+		if !ok {
+			return nil, err
+		}
+		s = cached.schema
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bySubject[subject] = cachedSchema{schema: s, fetchedAt: now}
+	c.byID[s.id] = s
+	return s, nil
+}
+
+// fetchLatestSchema looks up the latest schema of the given subject in the registry.
+// Schemas are immutable, so a schema already known by ID is not compiled again.
+func (c *client) fetchLatestSchema(ctx context.Context, subject string) (*schema, error) {
+	path := subjectVersionPath(subject, "latest")
+	resp, err := c.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	s, ok := c.byID[resp.ID]
+	c.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+	return c.compile(ctx, path, resp)
+}
+
+func (c *client) compile(ctx context.Context, path string, resp *schemaResponse) (*schema, error) {
+	s := &schema{
+		id:     resp.ID,
+		format: resp.SchemaType,
+	}
+	var err error
+	switch resp.SchemaType {
+	case FormatAvro:
+		if len(resp.References) > 0 {
+			return nil, fmt.Errorf("schema %d: avro schema references are not supported", resp.ID)
+		}
+		s.avro, err = newAvroSchema(resp.Schema)
+	case FormatProtobuf:
+		s.protobuf, err = c.protobufSchema(ctx, path)
+	default:
+		return nil, fmt.Errorf("schema %d: unsupported schema type %q", resp.ID, resp.SchemaType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("schema %d: %w", resp.ID, err)
+	}
+	return s, nil
+}
+
+// get requests a schema from the registry.
+func (c *client) get(ctx context.Context, path string) (*schemaResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", contentType)
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(httpResp.Body, 1024))
+		return nil, fmt.Errorf("schema registry request %s failed with status %d: %s", path, httpResp.StatusCode, body)
+	}
+
+	var resp schemaResponse
+	if err = json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode schema registry response to %s: %w", path, err)
+	}
+	// The registry omits the type of Avro schemas, the first format it supported.
+	if resp.SchemaType == "" {
+		resp.SchemaType = FormatAvro
+	}
+	return &resp, nil
+}
+
+func subjectVersionPath(subject string, version string) string {
+	return "/subjects/" + url.PathEscape(subject) + "/versions/" + version
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config configures the schema registry records are serialized with.
+type Config struct {
+	// Endpoint is the URL of the schema registry, e.g. http://localhost:8081.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Username and Password authenticate to the schema registry with HTTP basic authentication.
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+
+	// TLS configures the connection to a schema registry served over HTTPS.
+	TLS *configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// Timeout of the requests to the schema registry (default 10s).
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Subject whose latest schema records are serialized with.
+	// Only used to serialize records, deserialized records carry the ID of their schema.
+	Subject string `mapstructure:"subject"`
+
+	// LatestSchemaTTL is how long the latest schema of the subject is used before it is
+	// looked up again, so that new versions of the schema are picked up (default 5m).
+	LatestSchemaTTL time.Duration `mapstructure:"latest_schema_ttl"`
+
+	// AttributeFields are the top level fields of records mapped to log attributes,
+	// the other fields are mapped to the log body.
+	AttributeFields []string `mapstructure:"attribute_fields"`
+}
+
+// ValidateDeserializer checks the configuration can be used to deserialize records.
+// It is not named Validate as the schema registry is only used by some encodings.
+func (cfg *Config) ValidateDeserializer() error {
+	if cfg.Endpoint == "" {
+		return errors.New("schema_registry.endpoint is required")
+	}
+	return nil
+}
+
+// ValidateSerializer checks the configuration can be used to serialize records.
+func (cfg *Config) ValidateSerializer() error {
+	if err := cfg.ValidateDeserializer(); err != nil {
+		return err
+	}
+	if cfg.Subject == "" {
+		return errors.New("schema_registry.subject is required")
+	}
+	return nil
+}
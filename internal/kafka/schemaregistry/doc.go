@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schemaregistry serializes Avro and Protobuf records in the Confluent wire format,
+// with the schemas of a Confluent compatible schema registry.
+//
+// Records are represented with the types pcommon.Value.FromRaw accepts and
+// pcommon.Value.AsRaw returns: map[string]any, []any, string, int64, float64, bool and []byte.
+package schemaregistry // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
@@ -0,0 +1,365 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	// Register the well-known types, which schemas import without referencing them.
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/fieldmaskpb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// serializedFormat asks the registry for Protobuf schemas as a serialized FileDescriptorProto
+// rather than as .proto source, so that they do not need to be parsed.
+const serializedFormat = "?format=serialized"
+
+// protobufSchema is a Protobuf file, a record may be any of its message types.
+type protobufSchema struct {
+	file protoreflect.FileDescriptor
+}
+
+// protobufSchema builds the file of the schema at path, with the files it references.
+func (c *client) protobufSchema(ctx context.Context, path string) (*protobufSchema, error) {
+	file, err := c.protobufFile(ctx, path, "", new(protoregistry.Files))
+	if err != nil {
+		return nil, err
+	}
+	return &protobufSchema{file: file}, nil
+}
+
+// protobufFile builds the file of the schema at path and registers it, named name if not empty, in files.
+func (c *client) protobufFile(ctx context.Context, path string, name string, files *protoregistry.Files) (protoreflect.FileDescriptor, error) {
+	resp, err := c.get(ctx, path+serializedFormat)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range resp.References {
+		if _, err = files.FindFileByPath(ref.Name); err == nil {
+			continue
+		}
+		if _, err = c.protobufFile(ctx, subjectVersionPath(ref.Subject, strconv.Itoa(ref.Version)), ref.Name, files); err != nil {
+			return nil, fmt.Errorf("reference %s: %w", ref.Name, err)
+		}
+	}
+
+	serialized, err := base64.StdEncoding.DecodeString(resp.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode serialized protobuf schema: %w", err)
+	}
+	fileProto := &descriptorpb.FileDescriptorProto{}
+	if err = proto.Unmarshal(serialized, fileProto); err != nil {
+		return nil, fmt.Errorf("failed to decode serialized protobuf schema: %w", err)
+	}
+	// Files are imported by the name of their reference.
+	if name != "" {
+		fileProto.Name = proto.String(name)
+	} else if fileProto.GetName() == "" {
+		fileProto.Name = proto.String("schema.proto")
+	}
+
+	file, err := protodesc.NewFile(fileProto, resolver{files: files})
+	if err != nil {
+		return nil, err
+	}
+	if err = files.RegisterFile(file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// resolver resolves the imports of a file from the referenced files, then from the well-known types.
+type resolver struct {
+	files *protoregistry.Files
+}
+
+func (r resolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if file, err := r.files.FindFileByPath(path); err == nil {
+		return file, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r resolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if desc, err := r.files.FindDescriptorByName(name); err == nil {
+		return desc, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+// message returns the message type at the given path of indexes in the file.
+func (s *protobufSchema) message(indexes []int) (protoreflect.MessageDescriptor, error) {
+	messages := s.file.Messages()
+	var message protoreflect.MessageDescriptor
+	for _, index := range indexes {
+		if index >= messages.Len() {
+			return nil, fmt.Errorf("message index %v not found in %s", indexes, s.file.Path())
+		}
+		message = messages.Get(index)
+		messages = message.Messages()
+	}
+	return message, nil
+}
+
+func (s *protobufSchema) decode(data []byte) (any, error) {
+	indexes, payload, err := readMessageIndexes(data)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := s.message(indexes)
+	if err != nil {
+		return nil, err
+	}
+	message := dynamicpb.NewMessage(desc)
+	if err = proto.Unmarshal(payload, message); err != nil {
+		return nil, err
+	}
+	return protobufMessageToRaw(message), nil
+}
+
+// encode appends record, encoded with the first message type of the file, to buf.
+func (s *protobufSchema) encode(buf []byte, record any) ([]byte, error) {
+	if s.file.Messages().Len() == 0 {
+		return nil, fmt.Errorf("%s has no message type", s.file.Path())
+	}
+	fields, ok := record.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("cannot encode %T as protobuf message", record)
+	}
+	message, err := protobufMessageFromRaw(s.file.Messages().Get(0), fields)
+	if err != nil {
+		return nil, err
+	}
+	buf = appendMessageIndexes(buf, []int{0})
+	return proto.MarshalOptions{}.MarshalAppend(buf, message)
+}
+
+// protobufMessageToRaw converts the populated fields of a message, keyed by their name.
+func protobufMessageToRaw(message protoreflect.Message) map[string]any {
+	raw := make(map[string]any)
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.IsList():
+			list := value.List()
+			items := make([]any, list.Len())
+			for i := range items {
+				items[i] = protobufValueToRaw(field, list.Get(i))
+			}
+			raw[string(field.Name())] = items
+		case field.IsMap():
+			entries := make(map[string]any, value.Map().Len())
+			value.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+				entries[key.String()] = protobufValueToRaw(field.MapValue(), value)
+				return true
+			})
+			raw[string(field.Name())] = entries
+		default:
+			raw[string(field.Name())] = protobufValueToRaw(field, value)
+		}
+		return true
+	})
+	return raw
+}
+
+func protobufValueToRaw(field protoreflect.FieldDescriptor, value protoreflect.Value) any {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return value.Bool()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return value.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return int64(value.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return value.Float()
+	case protoreflect.StringKind:
+		return value.String()
+	case protoreflect.BytesKind:
+		return value.Bytes()
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return int64(value.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return protobufMessageToRaw(value.Message())
+	}
+	return nil
+}
+
+// protobufMessageFromRaw builds a message from the fields of a record, keyed by their name or JSON name.
+// Fields the message type does not have are ignored.
+func protobufMessageFromRaw(desc protoreflect.MessageDescriptor, raw map[string]any) (*dynamicpb.Message, error) {
+	message := dynamicpb.NewMessage(desc)
+	for key, value := range raw {
+		field := desc.Fields().ByName(protoreflect.Name(key))
+		if field == nil {
+			field = desc.Fields().ByJSONName(key)
+		}
+		if field == nil || value == nil {
+			continue
+		}
+
+		switch {
+		case field.IsList():
+			items, ok := value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("field %s: cannot encode %T as repeated field", key, value)
+			}
+			list := message.Mutable(field).List()
+			for _, item := range items {
+				v, err := protobufValueFromRaw(field, item)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", key, err)
+				}
+				list.Append(v)
+			}
+		case field.IsMap():
+			entries, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("field %s: cannot encode %T as map field", key, value)
+			}
+			m := message.Mutable(field).Map()
+			for k, entry := range entries {
+				mapKey, err := protobufMapKey(field.MapKey(), k)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", key, err)
+				}
+				v, err := protobufValueFromRaw(field.MapValue(), entry)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", key, err)
+				}
+				m.Set(mapKey, v)
+			}
+		default:
+			v, err := protobufValueFromRaw(field, value)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", key, err)
+			}
+			message.Set(field, v)
+		}
+	}
+	return message, nil
+}
+
+func protobufValueFromRaw(field protoreflect.FieldDescriptor, value any) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		if b, ok := value.(bool); ok {
+			return protoreflect.ValueOfBool(b), nil
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if i, ok := rawInt(value); ok && i >= math.MinInt32 && i <= math.MaxInt32 {
+			return protoreflect.ValueOfInt32(int32(i)), nil
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if i, ok := rawInt(value); ok {
+			return protoreflect.ValueOfInt64(i), nil
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if i, ok := rawInt(value); ok && i >= 0 && i <= math.MaxUint32 {
+			return protoreflect.ValueOfUint32(uint32(i)), nil
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if i, ok := rawInt(value); ok && i >= 0 {
+			return protoreflect.ValueOfUint64(uint64(i)), nil
+		}
+	case protoreflect.FloatKind:
+		if f, ok := rawFloat(value); ok {
+			return protoreflect.ValueOfFloat32(float32(f)), nil
+		}
+	case protoreflect.DoubleKind:
+		if f, ok := rawFloat(value); ok {
+			return protoreflect.ValueOfFloat64(f), nil
+		}
+	case protoreflect.StringKind:
+		if s, ok := value.(string); ok {
+			return protoreflect.ValueOfString(s), nil
+		}
+	case protoreflect.BytesKind:
+		switch b := value.(type) {
+		case []byte:
+			return protoreflect.ValueOfBytes(b), nil
+		case string:
+			return protoreflect.ValueOfBytes([]byte(b)), nil
+		}
+	case protoreflect.EnumKind:
+		switch v := value.(type) {
+		case string:
+			if enumValue := field.Enum().Values().ByName(protoreflect.Name(v)); enumValue != nil {
+				return protoreflect.ValueOfEnum(enumValue.Number()), nil
+			}
+		default:
+			if i, ok := rawInt(v); ok && i >= math.MinInt32 && i <= math.MaxInt32 {
+				return protoreflect.ValueOfEnum(protoreflect.EnumNumber(i)), nil
+			}
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if fields, ok := value.(map[string]any); ok {
+			message, err := protobufMessageFromRaw(field.Message(), fields)
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+			return protoreflect.ValueOfMessage(message), nil
+		}
+	}
+	return protoreflect.Value{}, fmt.Errorf("cannot encode %T as protobuf %s", value, field.Kind())
+}
+
+func protobufMapKey(field protoreflect.FieldDescriptor, key string) (protoreflect.MapKey, error) {
+	var value protoreflect.Value
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		value = protoreflect.ValueOfString(key)
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(key)
+		if err != nil {
+			return protoreflect.MapKey{}, err
+		}
+		value = protoreflect.ValueOfBool(b)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := strconv.ParseInt(key, 10, 32)
+		if err != nil {
+			return protoreflect.MapKey{}, err
+		}
+		value = protoreflect.ValueOfInt32(int32(i))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return protoreflect.MapKey{}, err
+		}
+		value = protoreflect.ValueOfInt64(i)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		i, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return protoreflect.MapKey{}, err
+		}
+		value = protoreflect.ValueOfUint32(uint32(i))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		i, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return protoreflect.MapKey{}, err
+		}
+		value = protoreflect.ValueOfUint64(i)
+	default:
+		return protoreflect.MapKey{}, fmt.Errorf("unsupported map key kind %s", field.Kind())
+	}
+	return value.MapKey(), nil
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schemaregistrytest provides a stand-in for a schema registry in tests.
+package schemaregistrytest // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry/schemaregistrytest"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
+)
+
+// Reference is a schema a registered schema imports.
+type Reference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+type registeredSchema struct {
+	Subject    string                `json:"subject"`
+	Version    int                   `json:"version"`
+	ID         int                   `json:"id"`
+	SchemaType schemaregistry.Format `json:"schemaType,omitempty"`
+	Schema     string                `json:"schema"`
+	References []Reference           `json:"references,omitempty"`
+}
+
+// Registry serves the schemas registered with it like a schema registry does.
+// Protobuf schemas are only served in the serialized format.
+type Registry struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	schemas []registeredSchema
+}
+
+// NewRegistry starts a Registry, stopped at the end of the test.
+func NewRegistry(tb testing.TB) *Registry {
+	r := &Registry{}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.handle))
+	tb.Cleanup(r.Close)
+	return r
+}
+
+// RegisterAvro registers an Avro schema as the next version of subject and returns its ID.
+func (r *Registry) RegisterAvro(subject string, schema string) int {
+	return r.register(subject, "", schema, nil)
+}
+
+// RegisterProtobuf registers a Protobuf file as the next version of subject and returns its ID.
+func (r *Registry) RegisterProtobuf(tb testing.TB, subject string, file *descriptorpb.FileDescriptorProto, references ...Reference) int {
+	serialized, err := proto.Marshal(file)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return r.register(subject, schemaregistry.FormatProtobuf, base64.StdEncoding.EncodeToString(serialized), references)
+}
+
+func (r *Registry) register(subject string, schemaType schemaregistry.Format, schema string, references []Reference) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	version := 1
+	for _, s := range r.schemas {
+		if s.Subject == subject {
+			version = s.Version + 1
+		}
+	}
+	id := len(r.schemas) + 1
+	r.schemas = append(r.schemas, registeredSchema{
+		Subject:    subject,
+		Version:    version,
+		ID:         id,
+		SchemaType: schemaType,
+		Schema:     schema,
+		References: references,
+	})
+	return id
+}
+
+func (r *Registry) handle(w http.ResponseWriter, req *http.Request) {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	var found *registeredSchema
+	switch {
+	case req.Method != http.MethodGet:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	case len(parts) == 3 && parts[0] == "schemas" && parts[1] == "ids":
+		found = r.find(func(s registeredSchema) bool { return strconv.Itoa(s.ID) == parts[2] })
+	case len(parts) == 4 && parts[0] == "subjects" && parts[2] == "versions":
+		found = r.find(func(s registeredSchema) bool {
+			return s.Subject == parts[1] && (parts[3] == "latest" || strconv.Itoa(s.Version) == parts[3])
+		})
+	}
+	if found == nil {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":40403,"message":"Schema not found"}`))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+	_ = json.NewEncoder(w).Encode(found)
+}
+
+// find returns the latest schema matching the predicate.
+func (r *Registry) find(match func(registeredSchema) bool) *registeredSchema {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.schemas) - 1; i >= 0; i-- {
+		if match(r.schemas[i]) {
+			s := r.schemas[i]
+			return &s
+		}
+	}
+	return nil
+}
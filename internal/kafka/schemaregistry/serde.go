@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// Serde serializes and deserializes the records of a format in the Confluent wire format.
+type Serde struct {
+	client  *client
+	format  Format
+	subject string
+}
+
+// NewSerde creates a Serde for records of the given format, with the schemas of the configured registry.
+func NewSerde(cfg Config, format Format) (*Serde, error) {
+	c, err := newClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Serde{
+		client:  c,
+		format:  format,
+		subject: cfg.Subject,
+	}, nil
+}
+
+// Deserialize decodes a message with the schema whose ID it carries.
+func (s *Serde) Deserialize(ctx context.Context, data []byte) (any, error) {
+	id, payload, err := parseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := s.client.schemaByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sch.format != s.format {
+		return nil, fmt.Errorf("schema %d is a %s schema, expected %s", id, sch.format, s.format)
+	}
+
+	switch s.format {
+	case FormatAvro:
+		return sch.avro.decode(payload)
+	default:
+		return sch.protobuf.decode(payload)
+	}
+}
+
+// Serialize encodes a record with the latest schema of the configured subject.
+// Protobuf records are encoded with the first message type of the schema.
+func (s *Serde) Serialize(ctx context.Context, record any) ([]byte, error) {
+	sch, err := s.client.latestSchema(ctx, s.subject)
+	if err != nil {
+		return nil, err
+	}
+	if sch.format != s.format {
+		return nil, fmt.Errorf("latest schema of subject %s is a %s schema, expected %s", s.subject, sch.format, s.format)
+	}
+
+	buf := appendHeader(nil, sch.id)
+	switch s.format {
+	case FormatAvro:
+		return sch.avro.encode(buf, record)
+	default:
+		return sch.protobuf.encode(buf, record)
+	}
+}
+
+// rawInt returns the integer value of a record value, numbers decoded from JSON are floats.
+func rawInt(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt64 && v <= math.MaxInt64 {
+			return int64(v), true
+		}
+	}
+	return 0, false
+}
+
+func rawFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
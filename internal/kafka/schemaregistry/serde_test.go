@@ -0,0 +1,291 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry/schemaregistrytest"
+)
+
+const avroSchema = `{
+  "type": "record",
+  "name": "Order",
+  "namespace": "shop",
+  "fields": [
+    {"name": "id", "type": "long"},
+    {"name": "customer", "type": ["null", "string"]},
+    {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["PENDING", "SHIPPED"]}},
+    {"name": "items", "type": {"type": "array", "items": {
+      "type": "record", "name": "Item", "fields": [
+        {"name": "sku", "type": "string"},
+        {"name": "quantity", "type": "int"}
+      ]}}},
+    {"name": "labels", "type": {"type": "map", "values": "string"}},
+    {"name": "gift", "type": ["null", "Item"], "default": null},
+    {"name": "price", "type": "float"},
+    {"name": "created", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+  ]
+}`
+
+func TestSerdeAvro(t *testing.T) {
+	registry := schemaregistrytest.NewRegistry(t)
+	registry.RegisterAvro("orders-value", `"string"`)
+	id := registry.RegisterAvro("orders-value", avroSchema)
+
+	serde, err := schemaregistry.NewSerde(schemaregistry.Config{
+		Endpoint: registry.URL,
+		Subject:  "orders-value",
+	}, schemaregistry.FormatAvro)
+	require.NoError(t, err)
+
+	record := map[string]any{
+		"id":       int64(42),
+		"customer": "alice",
+		"status":   "SHIPPED",
+		"items": []any{
+			map[string]any{"sku": "book", "quantity": int64(2)},
+		},
+		"labels":  map[string]any{"channel": "web"},
+		"gift":    map[string]any{"sku": "card", "quantity": int64(1)},
+		"price":   12.5,
+		"created": "2023-10-16T12:00:00.5Z",
+		// Fields the schema does not have are not serialized.
+		"unknown": true,
+	}
+	data, err := serde.Serialize(context.Background(), record)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, byte(id)}, data[:5])
+
+	decoded, err := serde.Deserialize(context.Background(), data)
+	require.NoError(t, err)
+	delete(record, "unknown")
+	assert.Equal(t, record, decoded)
+
+	// Missing optional fields use their default value, unions accept null.
+	record = map[string]any{
+		"id":       int64(43),
+		"customer": nil,
+		"status":   "PENDING",
+		"items":    []any{},
+		"labels":   map[string]any{},
+		"price":    float64(1),
+		"created":  int64(1697457600000),
+	}
+	data, err = serde.Serialize(context.Background(), record)
+	require.NoError(t, err)
+	decoded, err = serde.Deserialize(context.Background(), data)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"id":       int64(43),
+		"customer": nil,
+		"status":   "PENDING",
+		"items":    []any{},
+		"labels":   map[string]any{},
+		"gift":     nil,
+		"price":    float64(1),
+		"created":  "2023-10-16T12:00:00Z",
+	}, decoded)
+
+	_, err = serde.Serialize(context.Background(), map[string]any{"id": "not a number"})
+	assert.ErrorContains(t, err, "field id")
+}
+
+func TestSerdeProtobuf(t *testing.T) {
+	registry := schemaregistrytest.NewRegistry(t)
+	registry.RegisterProtobuf(t, "common", &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("ignored.proto"),
+		Package: proto.String("common"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name:  proto.String("Host"),
+			Field: []*descriptorpb.FieldDescriptorProto{field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")},
+		}},
+	})
+	id := registry.RegisterProtobuf(t, "events-value", eventsFile(), schemaregistrytest.Reference{
+		Name:    "common/host.proto",
+		Subject: "common",
+		Version: 1,
+	})
+
+	serde, err := schemaregistry.NewSerde(schemaregistry.Config{
+		Endpoint: registry.URL,
+		Subject:  "events-value",
+	}, schemaregistry.FormatProtobuf)
+	require.NoError(t, err)
+
+	record := map[string]any{
+		"name":  "checkout",
+		"count": int64(3),
+		"tags":  []any{"a", "b"},
+		"sizes": map[string]any{"small": int64(1)},
+		"level": "LEVEL_ERROR",
+		"host":  map[string]any{"name": "web-1"},
+		"created": map[string]any{
+			"seconds": int64(1697457600),
+		},
+	}
+	data, err := serde.Serialize(context.Background(), record)
+	require.NoError(t, err)
+	// Header then the message indexes of the first message type.
+	assert.Equal(t, []byte{0, 0, 0, 0, byte(id), 0}, data[:6])
+
+	decoded, err := serde.Deserialize(context.Background(), data)
+	require.NoError(t, err)
+	assert.Equal(t, record, decoded)
+
+	// Records of other message types carry their indexes, here [2, 0] as zigzag varints.
+	other := append([]byte{0, 0, 0, 0, byte(id), 4, 4, 0}, protowireString(1, "nested")...)
+	decoded, err = serde.Deserialize(context.Background(), other)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"value": "nested"}, decoded)
+}
+
+func TestSerdeLatestSchemaTTL(t *testing.T) {
+	registry := schemaregistrytest.NewRegistry(t)
+	first := registry.RegisterAvro("orders-value", `"string"`)
+
+	serde, err := schemaregistry.NewSerde(schemaregistry.Config{
+		Endpoint:        registry.URL,
+		Subject:         "orders-value",
+		LatestSchemaTTL: 100 * time.Millisecond,
+	}, schemaregistry.FormatAvro)
+	require.NoError(t, err)
+	data, err := serde.Serialize(context.Background(), "order")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, byte(first)}, data[:5])
+
+	// A new version of the schema is used once the TTL of the previous one elapsed.
+	second := registry.RegisterAvro("orders-value", `["null", "string"]`)
+	assert.Eventually(t, func() bool {
+		data, err = serde.Serialize(context.Background(), "order")
+		return err == nil && data[4] == byte(second)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The latest schema keeps being used while the registry is unavailable.
+	registry.Close()
+	time.Sleep(200 * time.Millisecond)
+	data, err = serde.Serialize(context.Background(), "order")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, byte(second)}, data[:5])
+}
+
+func TestSerdeErrors(t *testing.T) {
+	registry := schemaregistrytest.NewRegistry(t)
+	id := registry.RegisterAvro("orders-value", `"string"`)
+
+	serde, err := schemaregistry.NewSerde(schemaregistry.Config{
+		Endpoint: registry.URL,
+		Subject:  "orders-value",
+	}, schemaregistry.FormatProtobuf)
+	require.NoError(t, err)
+
+	_, err = serde.Deserialize(context.Background(), []byte("plain text"))
+	assert.ErrorContains(t, err, "wire format")
+
+	_, err = serde.Deserialize(context.Background(), []byte{0, 0, 0, 0, byte(id), 0})
+	assert.ErrorContains(t, err, "is a AVRO schema, expected PROTOBUF")
+
+	_, err = serde.Deserialize(context.Background(), []byte{0, 0, 0, 0, 99, 0})
+	assert.ErrorContains(t, err, "status 404")
+
+	_, err = serde.Serialize(context.Background(), map[string]any{})
+	assert.ErrorContains(t, err, "expected PROTOBUF")
+}
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     typ.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+func repeated(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return f
+}
+
+// eventsFile is the descriptor of:
+//
+//	syntax = "proto3";
+//	package shop;
+//	import "common/host.proto";
+//	import "google/protobuf/timestamp.proto";
+//	message Event {
+//	  string name = 1;
+//	  int64 count = 2;
+//	  repeated string tags = 3;
+//	  map<string, int32> sizes = 4;
+//	  Level level = 5;
+//	  common.Host host = 6;
+//	  google.protobuf.Timestamp created = 7;
+//	}
+//	enum Level { LEVEL_UNSPECIFIED = 0; LEVEL_ERROR = 1; }
+//	message Unused {}
+//	message Other { message Nested { string value = 1; } }
+func eventsFile() *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("events.proto"),
+		Package:    proto.String("shop"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"common/host.proto", "google/protobuf/timestamp.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Event"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					field("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+					repeated(field("tags", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")),
+					repeated(field("sizes", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".shop.Event.SizesEntry")),
+					field("level", 5, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".shop.Level"),
+					field("host", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".common.Host"),
+					field("created", 7, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp"),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name: proto.String("SizesEntry"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+						field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+					},
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				}},
+			},
+			{Name: proto.String("Unused")},
+			{
+				Name: proto.String("Other"),
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name:  proto.String("Nested"),
+					Field: []*descriptorpb.FieldDescriptorProto{field("value", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")},
+				}},
+			},
+		},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Level"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("LEVEL_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("LEVEL_ERROR"), Number: proto.Int32(1)},
+			},
+		}},
+	}
+}
+
+// protowireString encodes a string field.
+func protowireString(number byte, value string) []byte {
+	return append([]byte{number<<3 | 2, byte(len(value))}, value...)
+}
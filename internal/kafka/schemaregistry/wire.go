@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// The Confluent wire format prefixes the encoded record with a magic byte and the ID of its schema,
+// a 4 bytes big endian integer.
+const (
+	magicByte = 0
+	headerLen = 5
+)
+
+var errInvalidWireFormat = errors.New("message is not in the schema registry wire format")
+
+// parseHeader returns the schema ID and the payload of a message.
+func parseHeader(data []byte) (int, []byte, error) {
+	if len(data) < headerLen || data[0] != magicByte {
+		return 0, nil, errInvalidWireFormat
+	}
+	return int(binary.BigEndian.Uint32(data[1:headerLen])), data[headerLen:], nil
+}
+
+func appendHeader(buf []byte, id int) []byte {
+	buf = append(buf, magicByte)
+	return binary.BigEndian.AppendUint32(buf, uint32(id))
+}
+
+// readMessageIndexes reads the path to the message type of a Protobuf record in its schema,
+// which follows the header: the number of indexes, then the indexes, as zigzag varints.
+// The first message type of the schema, by far the most common, is encoded as a single 0.
+func readMessageIndexes(data []byte) ([]int, []byte, error) {
+	n, l := binary.Varint(data)
+	if l <= 0 || n < 0 || n > int64(len(data)) {
+		return nil, nil, errors.New("invalid protobuf message indexes")
+	}
+	data = data[l:]
+	if n == 0 {
+		return []int{0}, data, nil
+	}
+
+	indexes := make([]int, n)
+	for i := range indexes {
+		index, l := binary.Varint(data)
+		if l <= 0 || index < 0 {
+			return nil, nil, errors.New("invalid protobuf message indexes")
+		}
+		indexes[i] = int(index)
+		data = data[l:]
+	}
+	return indexes, data, nil
+}
+
+func appendMessageIndexes(buf []byte, indexes []int) []byte {
+	if len(indexes) == 1 && indexes[0] == 0 {
+		return append(buf, 0)
+	}
+	buf = binary.AppendVarint(buf, int64(len(indexes)))
+	for _, index := range indexes {
+		buf = binary.AppendVarint(buf, int64(index))
+	}
+	return buf
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package schemaregistry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeader(t *testing.T) {
+	data := append(appendHeader(nil, 258), "payload"...)
+	assert.Equal(t, []byte{0, 0, 0, 1, 2}, data[:headerLen])
+
+	id, payload, err := parseHeader(data)
+	require.NoError(t, err)
+	assert.Equal(t, 258, id)
+	assert.Equal(t, []byte("payload"), payload)
+
+	_, _, err = parseHeader([]byte{1, 0, 0, 0, 1})
+	assert.ErrorIs(t, err, errInvalidWireFormat)
+	_, _, err = parseHeader([]byte{0, 0})
+	assert.ErrorIs(t, err, errInvalidWireFormat)
+}
+
+func TestMessageIndexes(t *testing.T) {
+	tests := []struct {
+		indexes []int
+		encoded []byte
+	}{
+		{indexes: []int{0}, encoded: []byte{0}},
+		{indexes: []int{1}, encoded: []byte{2, 2}},
+		{indexes: []int{2, 0}, encoded: []byte{4, 4, 0}},
+	}
+	for _, tt := range tests {
+		encoded := appendMessageIndexes(nil, tt.indexes)
+		assert.Equal(t, tt.encoded, encoded)
+
+		indexes, rest, err := readMessageIndexes(append(encoded, "payload"...))
+		require.NoError(t, err)
+		assert.Equal(t, tt.indexes, indexes)
+		assert.Equal(t, []byte("payload"), rest)
+	}
+
+	_, _, err := readMessageIndexes([]byte{100})
+	assert.Error(t, err)
+}
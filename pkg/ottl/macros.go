@@ -0,0 +1,558 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.uber.org/multierr"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	statementMacroName = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+	conditionMacroName = regexp.MustCompile(`^[A-Z][a-zA-Z0-9_]*$`)
+	macroParameterName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	reservedWords      = map[string]bool{"nil": true, "true": true, "false": true, "and": true, "or": true, "not": true, "where": true}
+)
+
+// Macro is a user defined, parameterized statement or condition.
+// Macros are expanded by the Parser before statements are built, so the expanded
+// statements are type checked against the functions and paths of the context they are used in.
+type Macro struct {
+	// Name is used to invoke the macro. Statement macros are invoked like editors and their
+	// names must start with a lowercase letter. Condition macros are invoked like converters
+	// within conditions and their names must start with an uppercase letter.
+	Name string `mapstructure:"name"`
+	// Parameters are the names by which the macro body refers to the arguments of an invocation.
+	// A parameter shadows any path with the same name.
+	Parameters []string `mapstructure:"parameters"`
+	// Statement is the body of a statement macro. It may have a where clause, which is
+	// combined with the where clause of the invocation.
+	Statement string `mapstructure:"statement"`
+	// Condition is the body of a condition macro.
+	Condition string `mapstructure:"condition"`
+}
+
+// Macros is a validated set of macro definitions that can be passed to a Parser with WithMacros.
+type Macros struct {
+	definitions map[string]*Macro
+}
+
+// NewMacros validates the macro definitions and returns them as a set.
+// An error is returned if a definition is malformed, if its body has invalid syntax
+// or if macros invoke each other in a cycle.
+func NewMacros(macros []Macro) (*Macros, error) {
+	m := &Macros{definitions: make(map[string]*Macro, len(macros))}
+	var errs error
+	for i := range macros {
+		def := macros[i]
+		if err := validateMacro(&def); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid macro %q: %w", def.Name, err))
+			continue
+		}
+		if _, ok := m.definitions[def.Name]; ok {
+			errs = multierr.Append(errs, fmt.Errorf("duplicate macro %q", def.Name))
+			continue
+		}
+		m.definitions[def.Name] = &def
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	if err := m.checkForCycles(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func validateMacro(def *Macro) error {
+	switch {
+	case def.Name == "":
+		return errors.New("name must not be empty")
+	case reservedWords[def.Name]:
+		return fmt.Errorf("name %q is a reserved word", def.Name)
+	case def.Statement != "" && def.Condition != "":
+		return errors.New("only one of statement or condition can be set")
+	case def.Statement != "":
+		if !statementMacroName.MatchString(def.Name) {
+			return errors.New("statement macro names must start with a lowercase letter and contain only letters, digits and underscores")
+		}
+		if _, err := parseStatement(def.Statement); err != nil {
+			return err
+		}
+	case def.Condition != "":
+		if !conditionMacroName.MatchString(def.Name) {
+			return errors.New("condition macro names must start with an uppercase letter and contain only letters, digits and underscores")
+		}
+		if _, err := parseCondition(def.Condition); err != nil {
+			return err
+		}
+	default:
+		return errors.New("one of statement or condition must be set")
+	}
+
+	seen := make(map[string]bool, len(def.Parameters))
+	for _, param := range def.Parameters {
+		if !macroParameterName.MatchString(param) || reservedWords[param] {
+			return fmt.Errorf("invalid parameter name %q, parameter names must be lowercase letters, digits and underscores", param)
+		}
+		if seen[param] {
+			return fmt.Errorf("duplicate parameter %q", param)
+		}
+		seen[param] = true
+	}
+	return nil
+}
+
+// references returns the names of the macros invoked by the body of def.
+func (m *Macros) references(def *Macro) []string {
+	var refs []string
+	w := &astWalker{
+		visitConverter: func(c *converter) error {
+			if _, ok := m.definitions[c.Function]; ok {
+				refs = append(refs, c.Function)
+			}
+			return nil
+		},
+	}
+	if def.Statement != "" {
+		// The body was validated by NewMacros.
+		parsed, _ := parseStatement(def.Statement)
+		if _, ok := m.definitions[parsed.Editor.Function]; ok {
+			refs = append(refs, parsed.Editor.Function)
+		}
+		_ = w.walkStatement(parsed)
+	} else {
+		parsed, _ := parseCondition(def.Condition)
+		_ = w.walkBooleanExpression(parsed)
+	}
+	return refs
+}
+
+func (m *Macros) checkForCycles() error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, len(m.definitions))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("macros invoke each other in a cycle: %s", strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, ref := range m.references(m.definitions[name]) {
+			if err := visit(ref, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+
+	for name := range m.definitions {
+		if err := visit(name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookup returns the definition of the macro with the given name, if any.
+// Macros cannot shadow functions, so an error is returned if isFunction reports a function with the same name.
+func (m *Macros) lookup(name string, isFunction func(string) bool) (*Macro, error) {
+	def, ok := m.definitions[name]
+	if !ok {
+		return nil, nil
+	}
+	if isFunction(name) {
+		return nil, fmt.Errorf("macro %q has the same name as a function", name)
+	}
+	return def, nil
+}
+
+// expandStatement replaces the macros invoked in the parsed statement with their bodies.
+func (m *Macros) expandStatement(parsed *parsedStatement, isFunction func(string) bool) (*parsedStatement, error) {
+	if err := m.expander(isFunction).walkStatement(parsed); err != nil {
+		return nil, err
+	}
+
+	def, err := m.lookup(parsed.Editor.Function, isFunction)
+	if err != nil || def == nil {
+		return parsed, err
+	}
+	if def.Statement == "" {
+		return nil, fmt.Errorf("condition macro %q cannot be used as a statement", def.Name)
+	}
+
+	bindings, err := def.bind(parsed.Editor.Arguments)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invocation of macro %q: %w", def.Name, err)
+	}
+	body, err := parseStatement(def.Statement)
+	if err != nil {
+		return nil, fmt.Errorf("invalid body of macro %q: %w", def.Name, err)
+	}
+	if err = substituteParameters(bindings).walkStatement(body); err != nil {
+		return nil, fmt.Errorf("invalid invocation of macro %q: %w", def.Name, err)
+	}
+	body, err = m.expandStatement(body, isFunction)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invocation of macro %q: %w", def.Name, err)
+	}
+
+	switch {
+	case parsed.WhereClause == nil:
+	case body.WhereClause == nil:
+		body.WhereClause = parsed.WhereClause
+	default:
+		body.WhereClause = &booleanExpression{
+			Left: &term{
+				Left:  &booleanValue{SubExpr: body.WhereClause},
+				Right: []*opAndBooleanValue{{Operator: "and", Value: &booleanValue{SubExpr: parsed.WhereClause}}},
+			},
+		}
+	}
+	return body, nil
+}
+
+// expander returns a walker that replaces condition macro invocations with their bodies.
+func (m *Macros) expander(isFunction func(string) bool) *astWalker {
+	w := &astWalker{}
+	w.visitConverter = func(c *converter) error {
+		def, err := m.lookup(c.Function, isFunction)
+		if err != nil {
+			return err
+		}
+		if def != nil {
+			return fmt.Errorf("macro %q can only be used as a condition", c.Function)
+		}
+		return nil
+	}
+	w.visitBooleanValue = func(b *booleanValue) (bool, error) {
+		if b.ConstExpr == nil || b.ConstExpr.Converter == nil {
+			return false, nil
+		}
+		c := b.ConstExpr.Converter
+		def, err := m.lookup(c.Function, isFunction)
+		if err != nil || def == nil {
+			return false, err
+		}
+		if def.Condition == "" {
+			return true, fmt.Errorf("statement macro %q cannot be used as a condition", def.Name)
+		}
+		if c.Keys != nil {
+			return true, fmt.Errorf("macro %q cannot be indexed", def.Name)
+		}
+		if err = w.walkArguments(c.Arguments); err != nil {
+			return true, err
+		}
+
+		bindings, err := def.bind(c.Arguments)
+		if err != nil {
+			return true, fmt.Errorf("invalid invocation of macro %q: %w", def.Name, err)
+		}
+		body, err := parseCondition(def.Condition)
+		if err != nil {
+			return true, fmt.Errorf("invalid body of macro %q: %w", def.Name, err)
+		}
+		if err = substituteParameters(bindings).walkBooleanExpression(body); err != nil {
+			return true, fmt.Errorf("invalid invocation of macro %q: %w", def.Name, err)
+		}
+		if err = w.walkBooleanExpression(body); err != nil {
+			return true, fmt.Errorf("invalid invocation of macro %q: %w", def.Name, err)
+		}
+		b.ConstExpr = nil
+		b.SubExpr = body
+		return true, nil
+	}
+	return w
+}
+
+// bind maps the parameters of the macro to the arguments of an invocation.
+func (def *Macro) bind(args []argument) (map[string]value, error) {
+	if len(args) != len(def.Parameters) {
+		return nil, fmt.Errorf("incorrect number of arguments. Expected: %d Received: %d", len(def.Parameters), len(args))
+	}
+	bindings := make(map[string]value, len(args))
+	seenNamed := false
+	for i, arg := range args {
+		if arg.Name == "" {
+			if seenNamed {
+				return nil, errors.New("unnamed argument used after named argument")
+			}
+			bindings[def.Parameters[i]] = arg.Value
+			continue
+		}
+		seenNamed = true
+		if !slices.Contains(def.Parameters, arg.Name) {
+			return nil, fmt.Errorf("no parameter named %q", arg.Name)
+		}
+		if _, ok := bindings[arg.Name]; ok {
+			return nil, fmt.Errorf("parameter %q is set more than once", arg.Name)
+		}
+		bindings[arg.Name] = arg.Value
+	}
+	return bindings, nil
+}
+
+// substituteParameters returns a walker that replaces references to macro parameters with the
+// arguments bound to them. Arguments are not walked again, as they belong to the scope of the invocation.
+func substituteParameters(bindings map[string]value) *astWalker {
+	return &astWalker{
+		visitValue: func(v *value) (bool, error) {
+			if v.Literal == nil || v.Literal.Path == nil {
+				return false, nil
+			}
+			arg, ok := bindings[v.Literal.Path.Fields[0].Name]
+			if !ok {
+				return false, nil
+			}
+			if isBareParameter(v.Literal.Path) {
+				*v = arg
+				return true, nil
+			}
+			literal, err := bindPath(v.Literal.Path, arg)
+			if err != nil {
+				return true, err
+			}
+			v.Literal = literal
+			return true, nil
+		},
+		visitMathValue: func(m *mathValue) (bool, error) {
+			if m.Literal == nil || m.Literal.Path == nil {
+				return false, nil
+			}
+			name := m.Literal.Path.Fields[0].Name
+			arg, ok := bindings[name]
+			if !ok {
+				return false, nil
+			}
+			if !isBareParameter(m.Literal.Path) {
+				literal, err := bindPath(m.Literal.Path, arg)
+				if err != nil {
+					return true, err
+				}
+				m.Literal = literal
+				return true, nil
+			}
+			switch {
+			case arg.Literal != nil:
+				m.Literal = arg.Literal
+			case arg.MathExpression != nil:
+				m.Literal = nil
+				m.SubExpression = arg.MathExpression
+			default:
+				return true, fmt.Errorf("parameter %q is used in a math expression, but its argument is %s", name, describeValue(arg))
+			}
+			return true, nil
+		},
+	}
+}
+
+func isBareParameter(p *Path) bool {
+	return len(p.Fields) == 1 && len(p.Fields[0].Keys) == 0
+}
+
+// bindPath replaces the parameter at the start of the path with the path or converter bound to it,
+// so that `target["key"]` invoked with `attributes` becomes `attributes["key"]`.
+func bindPath(p *Path, arg value) (*mathExprLiteral, error) {
+	param := p.Fields[0]
+	switch {
+	case arg.Literal != nil && arg.Literal.Path != nil:
+		fields := make([]Field, 0, len(arg.Literal.Path.Fields)+len(p.Fields)-1)
+		fields = append(fields, arg.Literal.Path.Fields...)
+		if len(param.Keys) > 0 {
+			last := &fields[len(fields)-1]
+			last.Keys = append(append([]Key{}, last.Keys...), param.Keys...)
+		}
+		fields = append(fields, p.Fields[1:]...)
+		return &mathExprLiteral{Path: &Path{Fields: fields}}, nil
+	case arg.Literal != nil && arg.Literal.Converter != nil && len(p.Fields) == 1:
+		c := *arg.Literal.Converter
+		if len(param.Keys) > 0 {
+			c.Keys = append(append([]Key{}, c.Keys...), param.Keys...)
+		}
+		return &mathExprLiteral{Converter: &c}, nil
+	default:
+		return nil, fmt.Errorf("parameter %q is used as a path, but its argument is %s", param.Name, describeValue(arg))
+	}
+}
+
+// describeValue returns a short description of the kind of a value for error messages.
+func describeValue(v value) string {
+	switch {
+	case v.IsNil != nil:
+		return "nil"
+	case v.Literal != nil && v.Literal.Converter != nil:
+		return "a converter"
+	case v.Literal != nil && v.Literal.Path != nil:
+		return "a path"
+	case v.Literal != nil && v.Literal.Int != nil:
+		return "an int"
+	case v.Literal != nil && v.Literal.Float != nil:
+		return "a float"
+	case v.MathExpression != nil:
+		return "a math expression"
+	case v.Bytes != nil:
+		return "a byte slice"
+	case v.String != nil:
+		return "a string"
+	case v.Bool != nil:
+		return "a boolean"
+	case v.Enum != nil:
+		return "an enum"
+	case v.FunctionName != nil:
+		return "a function name"
+	case v.List != nil:
+		return "a list"
+	default:
+		return "an unsupported value"
+	}
+}
+
+// astWalker traverses a parsed statement or condition. Its hooks are called on a node before its
+// children are walked. A hook returning true has replaced the node, so its children are skipped.
+type astWalker struct {
+	visitValue        func(*value) (bool, error)
+	visitMathValue    func(*mathValue) (bool, error)
+	visitBooleanValue func(*booleanValue) (bool, error)
+	visitConverter    func(*converter) error
+}
+
+func (w *astWalker) walkStatement(s *parsedStatement) error {
+	if err := w.walkArguments(s.Editor.Arguments); err != nil {
+		return err
+	}
+	if s.WhereClause != nil {
+		return w.walkBooleanExpression(s.WhereClause)
+	}
+	return nil
+}
+
+func (w *astWalker) walkArguments(args []argument) error {
+	for i := range args {
+		if err := w.walkValue(&args[i].Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *astWalker) walkValue(v *value) error {
+	if w.visitValue != nil {
+		if done, err := w.visitValue(v); done || err != nil {
+			return err
+		}
+	}
+	switch {
+	case v.Literal != nil:
+		return w.walkMathExprLiteral(v.Literal)
+	case v.MathExpression != nil:
+		return w.walkMathExpression(v.MathExpression)
+	case v.List != nil:
+		for i := range v.List.Values {
+			if err := w.walkValue(&v.List.Values[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *astWalker) walkMathExprLiteral(l *mathExprLiteral) error {
+	switch {
+	case l.Editor != nil:
+		return w.walkArguments(l.Editor.Arguments)
+	case l.Converter != nil:
+		return w.walkConverter(l.Converter)
+	}
+	return nil
+}
+
+func (w *astWalker) walkConverter(c *converter) error {
+	if w.visitConverter != nil {
+		if err := w.visitConverter(c); err != nil {
+			return err
+		}
+	}
+	return w.walkArguments(c.Arguments)
+}
+
+func (w *astWalker) walkMathExpression(m *mathExpression) error {
+	terms := []*addSubTerm{m.Left}
+	for _, r := range m.Right {
+		terms = append(terms, r.Term)
+	}
+	for _, t := range terms {
+		values := []*mathValue{t.Left}
+		for _, r := range t.Right {
+			values = append(values, r.Value)
+		}
+		for _, v := range values {
+			if err := w.walkMathValue(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *astWalker) walkMathValue(m *mathValue) error {
+	if w.visitMathValue != nil {
+		if done, err := w.visitMathValue(m); done || err != nil {
+			return err
+		}
+	}
+	if m.Literal != nil {
+		return w.walkMathExprLiteral(m.Literal)
+	}
+	return w.walkMathExpression(m.SubExpression)
+}
+
+func (w *astWalker) walkBooleanExpression(b *booleanExpression) error {
+	terms := []*term{b.Left}
+	for _, r := range b.Right {
+		terms = append(terms, r.Term)
+	}
+	for _, t := range terms {
+		values := []*booleanValue{t.Left}
+		for _, r := range t.Right {
+			values = append(values, r.Value)
+		}
+		for _, v := range values {
+			if err := w.walkBooleanValue(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *astWalker) walkBooleanValue(b *booleanValue) error {
+	if w.visitBooleanValue != nil {
+		if done, err := w.visitBooleanValue(b); done || err != nil {
+			return err
+		}
+	}
+	switch {
+	case b.Comparison != nil:
+		if err := w.walkValue(&b.Comparison.Left); err != nil {
+			return err
+		}
+		return w.walkValue(&b.Comparison.Right)
+	case b.ConstExpr != nil && b.ConstExpr.Converter != nil:
+		return w.walkConverter(b.ConstExpr.Converter)
+	case b.SubExpr != nil:
+		return w.walkBooleanExpression(b.SubExpr)
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func testMacros(t *testing.T) *Macros {
+	macros, err := NewMacros([]Macro{
+		{
+			Name:       "upper_method",
+			Parameters: []string{"target"},
+			Statement:  `set(target["http.method"], ConvertCase(target["http.method"], "upper")) where target["http.method"] != nil`,
+		},
+		{
+			Name:       "set_to",
+			Parameters: []string{"target", "val"},
+			Statement:  `set(target, val)`,
+		},
+		{
+			Name:       "double",
+			Parameters: []string{"target", "n"},
+			Statement:  `set(target, n * 2)`,
+		},
+		{
+			Name:       "drop_health_checks",
+			Parameters: []string{"target"},
+			Statement:  `set_to(name, "drop") where IsHealthCheck(target)`,
+		},
+		{
+			Name:       "IsHealthCheck",
+			Parameters: []string{"path"},
+			Condition:  `path == "/health" or path == "/ready"`,
+		},
+		{
+			Name:       "IsInternal",
+			Parameters: []string{"target"},
+			Condition:  `IsHealthCheck(target["http.target"]) and target["internal"] == true`,
+		},
+	})
+	require.NoError(t, err)
+	return macros
+}
+
+func noFunctions(string) bool {
+	return false
+}
+
+func Test_Macros_expandStatement(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		expected  string
+	}{
+		{
+			name:      "no macros",
+			statement: `set(name, "a") where name == "b"`,
+			expected:  `set(name, "a") where name == "b"`,
+		},
+		{
+			name:      "path parameter with keys",
+			statement: `upper_method(attributes)`,
+			expected:  `set(attributes["http.method"], ConvertCase(attributes["http.method"], "upper")) where attributes["http.method"] != nil`,
+		},
+		{
+			name:      "path parameter with fields and keys",
+			statement: `upper_method(resource.attributes["http"])`,
+			expected:  `set(resource.attributes["http"]["http.method"], ConvertCase(resource.attributes["http"]["http.method"], "upper")) where resource.attributes["http"]["http.method"] != nil`,
+		},
+		{
+			name:      "converter parameter with keys",
+			statement: `upper_method(ParseJSON(body))`,
+			expected:  `set(ParseJSON(body)["http.method"], ConvertCase(ParseJSON(body)["http.method"], "upper")) where ParseJSON(body)["http.method"] != nil`,
+		},
+		{
+			name:      "where clauses are combined",
+			statement: `upper_method(attributes) where name == "a"`,
+			expected:  `set(attributes["http.method"], ConvertCase(attributes["http.method"], "upper")) where (attributes["http.method"] != nil) and (name == "a")`,
+		},
+		{
+			name:      "bare parameters",
+			statement: `set_to(name, Concat(["a", "b"], "-"))`,
+			expected:  `set(name, Concat(["a", "b"], "-"))`,
+		},
+		{
+			name:      "named arguments",
+			statement: `set_to(val = "a", target = name)`,
+			expected:  `set(name, "a")`,
+		},
+		{
+			name:      "literal in math expression",
+			statement: `double(name, 3)`,
+			expected:  `set(name, 3 * 2)`,
+		},
+		{
+			name:      "math expression in math expression",
+			statement: `double(name, 1 + 2)`,
+			expected:  `set(name, (1 + 2) * 2)`,
+		},
+		{
+			name:      "condition macro",
+			statement: `set(name, "drop") where not IsHealthCheck(attributes["http.target"])`,
+			expected:  `set(name, "drop") where not (attributes["http.target"] == "/health" or attributes["http.target"] == "/ready")`,
+		},
+		{
+			name:      "nested condition macros",
+			statement: `set(name, "drop") where IsInternal(attributes) or name == "a"`,
+			expected:  `set(name, "drop") where ((attributes["http.target"] == "/health" or attributes["http.target"] == "/ready") and attributes["internal"] == true) or name == "a"`,
+		},
+		{
+			name:      "nested statement macros",
+			statement: `drop_health_checks(attributes["http.target"])`,
+			expected:  `set(name, "drop") where (attributes["http.target"] == "/health" or attributes["http.target"] == "/ready")`,
+		},
+		{
+			name:      "parameters are not substituted in arguments",
+			statement: `set_to(val, "x")`,
+			expected:  `set(val, "x")`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseStatement(tt.statement)
+			require.NoError(t, err)
+			expected, err := parseStatement(tt.expected)
+			require.NoError(t, err)
+
+			expanded, err := testMacros(t).expandStatement(parsed, noFunctions)
+			require.NoError(t, err)
+			assert.Equal(t, expected, expanded)
+		})
+	}
+}
+
+func Test_Macros_expandStatement_error(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		expected  string
+	}{
+		{
+			name:      "too few arguments",
+			statement: `set_to(name)`,
+			expected:  "incorrect number of arguments",
+		},
+		{
+			name:      "too many arguments",
+			statement: `upper_method(attributes, name)`,
+			expected:  "incorrect number of arguments",
+		},
+		{
+			name:      "unnamed argument after named argument",
+			statement: `set_to(target = name, "a")`,
+			expected:  "unnamed argument used after named argument",
+		},
+		{
+			name:      "unknown named argument",
+			statement: `set_to(name, value = "a")`,
+			expected:  `no parameter named "value"`,
+		},
+		{
+			name:      "repeated named argument",
+			statement: `set_to(name, target = name)`,
+			expected:  `parameter "target" is set more than once`,
+		},
+		{
+			name:      "string used as a path",
+			statement: `upper_method("attributes")`,
+			expected:  `parameter "target" is used as a path, but its argument is a string`,
+		},
+		{
+			name:      "string used in a math expression",
+			statement: `double(name, "3")`,
+			expected:  `parameter "n" is used in a math expression, but its argument is a string`,
+		},
+		{
+			name:      "condition macro used as a value",
+			statement: `set(name, IsHealthCheck(name))`,
+			expected:  `macro "IsHealthCheck" can only be used as a condition`,
+		},
+		{
+			name:      "indexed condition macro",
+			statement: `set(name, "a") where IsHealthCheck(name)["a"]`,
+			expected:  `macro "IsHealthCheck" cannot be indexed`,
+		},
+		{
+			name:      "invalid condition macro invocation",
+			statement: `set(name, "a") where IsHealthCheck()`,
+			expected:  `invalid invocation of macro "IsHealthCheck"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseStatement(tt.statement)
+			require.NoError(t, err)
+
+			_, err = testMacros(t).expandStatement(parsed, noFunctions)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func Test_NewMacros_error(t *testing.T) {
+	tests := []struct {
+		name     string
+		macros   []Macro
+		expected string
+	}{
+		{
+			name:     "empty name",
+			macros:   []Macro{{Statement: `set(name, "a")`}},
+			expected: "name must not be empty",
+		},
+		{
+			name:     "reserved name",
+			macros:   []Macro{{Name: "not", Statement: `set(name, "a")`}},
+			expected: `name "not" is a reserved word`,
+		},
+		{
+			name:     "no body",
+			macros:   []Macro{{Name: "empty"}},
+			expected: "one of statement or condition must be set",
+		},
+		{
+			name:     "statement and condition",
+			macros:   []Macro{{Name: "both", Statement: `set(name, "a")`, Condition: `name == "a"`}},
+			expected: "only one of statement or condition can be set",
+		},
+		{
+			name:     "uppercase statement macro",
+			macros:   []Macro{{Name: "SetName", Statement: `set(name, "a")`}},
+			expected: "statement macro names must start with a lowercase letter",
+		},
+		{
+			name:     "lowercase condition macro",
+			macros:   []Macro{{Name: "is_name", Condition: `name == "a"`}},
+			expected: "condition macro names must start with an uppercase letter",
+		},
+		{
+			name:     "invalid statement",
+			macros:   []Macro{{Name: "set_name", Statement: `set(name, "a"`}},
+			expected: "statement has invalid syntax",
+		},
+		{
+			name:     "invalid condition",
+			macros:   []Macro{{Name: "IsName", Condition: `name ==`}},
+			expected: "condition has invalid syntax",
+		},
+		{
+			name:     "invalid parameter name",
+			macros:   []Macro{{Name: "set_name", Parameters: []string{"newName"}, Statement: `set(name, "a")`}},
+			expected: `invalid parameter name "newName"`,
+		},
+		{
+			name:     "reserved parameter name",
+			macros:   []Macro{{Name: "set_name", Parameters: []string{"nil"}, Statement: `set(name, "a")`}},
+			expected: `invalid parameter name "nil"`,
+		},
+		{
+			name:     "duplicate parameter",
+			macros:   []Macro{{Name: "set_name", Parameters: []string{"a", "a"}, Statement: `set(name, a)`}},
+			expected: `duplicate parameter "a"`,
+		},
+		{
+			name: "duplicate macro",
+			macros: []Macro{
+				{Name: "set_name", Statement: `set(name, "a")`},
+				{Name: "set_name", Statement: `set(name, "b")`},
+			},
+			expected: `duplicate macro "set_name"`,
+		},
+		{
+			name:     "recursive statement macro",
+			macros:   []Macro{{Name: "loop", Statement: `loop()`}},
+			expected: "macros invoke each other in a cycle: loop -> loop",
+		},
+		{
+			name: "cycle between condition macros",
+			macros: []Macro{
+				{Name: "IsA", Parameters: []string{"v"}, Condition: `IsB(v)`},
+				{Name: "IsB", Parameters: []string{"v"}, Condition: `v == 1 or IsA(v)`},
+			},
+			expected: "macros invoke each other in a cycle",
+		},
+		{
+			name: "cycle between statement and condition macros",
+			macros: []Macro{
+				{Name: "set_name", Statement: `set(name, "a") where IsName()`},
+				{Name: "IsName", Condition: `IsNamed(Concat([name], ""))`},
+				{Name: "IsNamed", Parameters: []string{"v"}, Condition: `v == "a" and IsName()`},
+			},
+			expected: "macros invoke each other in a cycle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			macros, err := NewMacros(tt.macros)
+			assert.ErrorContains(t, err, tt.expected)
+			assert.Nil(t, macros)
+		})
+	}
+}
+
+func Test_Parser_WithMacros(t *testing.T) {
+	macros, err := NewMacros([]Macro{
+		{
+			Name:       "mark",
+			Parameters: []string{"target"},
+			Statement:  `testing_getsetter(target) where IsName(target)`,
+		},
+		{
+			Name:       "IsName",
+			Parameters: []string{"v"},
+			Condition:  `v == "a"`,
+		},
+		{
+			Name:      "testing_string",
+			Statement: `testing_getsetter(name)`,
+		},
+	})
+	require.NoError(t, err)
+
+	p, err := NewParser[any](
+		CreateFactoryMap[any](
+			createFactory[any]("testing_getsetter", &getSetterArguments{}, functionWithGetSetter),
+			createFactory[any]("testing_string", &stringArguments{}, functionWithString),
+		),
+		testParsePath,
+		componenttest.NewNopTelemetrySettings(),
+		WithMacros[any](macros),
+	)
+	require.NoError(t, err)
+
+	statement, err := p.ParseStatement(`mark(name)`)
+	require.NoError(t, err)
+
+	result, condition, err := statement.Execute(context.Background(), "a")
+	require.NoError(t, err)
+	assert.True(t, condition)
+	assert.Equal(t, "anything", result)
+
+	_, condition, err = statement.Execute(context.Background(), "b")
+	require.NoError(t, err)
+	assert.False(t, condition)
+
+	_, err = p.ParseStatement(`mark(dur1) where IsName(undefined)`)
+	assert.ErrorContains(t, err, "bad path")
+
+	_, err = p.ParseStatement(`testing_string()`)
+	assert.ErrorContains(t, err, `macro "testing_string" has the same name as a function`)
+}
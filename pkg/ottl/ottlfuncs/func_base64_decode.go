@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type Base64DecodeArguments[K any] struct {
+	Target  ottl.Getter[K]
+	Variant ottl.Optional[string]
+}
+
+func NewBase64DecodeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Base64Decode", &Base64DecodeArguments[K]{}, createBase64DecodeFunction[K])
+}
+
+func createBase64DecodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*Base64DecodeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("Base64DecodeFactory args must be of type *Base64DecodeArguments[K]")
+	}
+
+	return base64Decode(args.Target, args.Variant)
+}
+
+func base64Decode[K any](target ottl.Getter[K], variant ottl.Optional[string]) (ottl.ExprFunc[K], error) {
+	enc, err := lookupBase64Variant(variant)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		buf, err := bytesOf(val)
+		if err != nil {
+			return nil, err
+		}
+		decoded := make([]byte, enc.DecodedLen(len(buf)))
+		n, err := enc.Decode(decoded, buf)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode base64: %w", err)
+		}
+		return string(decoded[:n]), nil
+	}, nil
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_base64Decode(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		variant  ottl.Optional[string]
+		expected string
+	}{
+		{
+			name:     "string",
+			value:    "aGVsbG8gd29ybGQ=",
+			expected: "hello world",
+		},
+		{
+			name:     "bytes",
+			value:    []byte("aGVsbG8gd29ybGQ="),
+			expected: "hello world",
+		},
+		{
+			name:     "empty string",
+			value:    "",
+			expected: "",
+		},
+		{
+			name:     "raw",
+			value:    "aGVsbG8gd29ybGQ",
+			variant:  ottl.NewTestingOptional[string]("base64-raw"),
+			expected: "hello world",
+		},
+		{
+			name:     "url",
+			value:    "-_8B",
+			variant:  ottl.NewTestingOptional[string]("base64-url"),
+			expected: string([]byte{0xfb, 0xff, 0x01}),
+		},
+		{
+			name:     "raw url",
+			value:    "-_8",
+			variant:  ottl.NewTestingOptional[string]("base64-raw-url"),
+			expected: string([]byte{0xfb, 0xff}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := base64Decode[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.variant)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_base64Decode_error(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		variant ottl.Optional[string]
+	}{
+		{
+			name:  "int",
+			value: 1,
+		},
+		{
+			name:  "nil",
+			value: nil,
+		},
+		{
+			name:  "invalid characters",
+			value: "not base64!",
+		},
+		{
+			name:  "missing padding",
+			value: "aGVsbG8gd29ybGQ",
+		},
+		{
+			name:    "standard alphabet with url variant",
+			value:   "+/8B",
+			variant: ottl.NewTestingOptional[string]("base64-url"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := base64Decode[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.variant)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func Test_base64Decode_invalid_variant(t *testing.T) {
+	exprFunc, err := base64Decode[any](&ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return "aGVsbG8=", nil
+		},
+	}, ottl.NewTestingOptional[string]("base64url"))
+	assert.ErrorContains(t, err, "unsupported base64 variant")
+	assert.Nil(t, exprFunc)
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const defaultBase64Variant = "base64"
+
+// base64Variants holds the base64 encodings supported by the Base64Encode and Base64Decode functions.
+var base64Variants = map[string]*base64.Encoding{
+	"base64":         base64.StdEncoding,
+	"base64-raw":     base64.RawStdEncoding,
+	"base64-url":     base64.URLEncoding,
+	"base64-raw-url": base64.RawURLEncoding,
+}
+
+type Base64EncodeArguments[K any] struct {
+	Target  ottl.Getter[K]
+	Variant ottl.Optional[string]
+}
+
+func NewBase64EncodeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Base64Encode", &Base64EncodeArguments[K]{}, createBase64EncodeFunction[K])
+}
+
+func createBase64EncodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*Base64EncodeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("Base64EncodeFactory args must be of type *Base64EncodeArguments[K]")
+	}
+
+	return base64Encode(args.Target, args.Variant)
+}
+
+func base64Encode[K any](target ottl.Getter[K], variant ottl.Optional[string]) (ottl.ExprFunc[K], error) {
+	enc, err := lookupBase64Variant(variant)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		buf, err := bytesOf(val)
+		if err != nil {
+			return nil, err
+		}
+		return enc.EncodeToString(buf), nil
+	}, nil
+}
+
+func lookupBase64Variant(variant ottl.Optional[string]) (*base64.Encoding, error) {
+	name := defaultBase64Variant
+	if !variant.IsEmpty() {
+		name = variant.Get()
+	}
+	enc, ok := base64Variants[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported base64 variant %q, must be one of base64, base64-raw, base64-url or base64-raw-url", name)
+	}
+	return enc, nil
+}
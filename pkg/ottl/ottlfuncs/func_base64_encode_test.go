@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_base64Encode(t *testing.T) {
+	bytesValue := pcommon.NewValueBytes()
+	bytesValue.Bytes().FromRaw([]byte{0xfb, 0xff, 0x01})
+
+	tests := []struct {
+		name     string
+		value    any
+		variant  ottl.Optional[string]
+		expected string
+	}{
+		{
+			name:     "string",
+			value:    "hello world",
+			expected: "aGVsbG8gd29ybGQ=",
+		},
+		{
+			name:     "bytes",
+			value:    []byte{0xfb, 0xff, 0x01},
+			expected: "+/8B",
+		},
+		{
+			name:     "pcommon bytes value",
+			value:    bytesValue,
+			expected: "+/8B",
+		},
+		{
+			name:     "empty string",
+			value:    "",
+			expected: "",
+		},
+		{
+			name:     "raw",
+			value:    "hello world",
+			variant:  ottl.NewTestingOptional[string]("base64-raw"),
+			expected: "aGVsbG8gd29ybGQ",
+		},
+		{
+			name:     "url",
+			value:    []byte{0xfb, 0xff, 0x01},
+			variant:  ottl.NewTestingOptional[string]("base64-url"),
+			expected: "-_8B",
+		},
+		{
+			name:     "raw url",
+			value:    []byte{0xfb, 0xff},
+			variant:  ottl.NewTestingOptional[string]("base64-raw-url"),
+			expected: "-_8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := base64Encode[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.variant)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_base64Encode_error(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{
+			name:  "int",
+			value: 1,
+		},
+		{
+			name:  "nil",
+			value: nil,
+		},
+		{
+			name:  "map value",
+			value: pcommon.NewValueMap(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := base64Encode[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, ottl.Optional[string]{})
+			require.NoError(t, err)
+
+			_, err = exprFunc(context.Background(), nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_base64Encode_invalid_variant(t *testing.T) {
+	exprFunc, err := base64Encode[any](&ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return "hello", nil
+		},
+	}, ottl.NewTestingOptional[string]("base32"))
+	assert.ErrorContains(t, err, "unsupported base64 variant")
+	assert.Nil(t, exprFunc)
+}
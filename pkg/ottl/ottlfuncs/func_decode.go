@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// encodingOverrides maps common encoding names that are missing from, or ambiguous in, the IANA index.
+var encodingOverrides = map[string]encoding.Encoding{
+	"":         unicode.UTF8,
+	"ascii":    unicode.UTF8,
+	"us-ascii": unicode.UTF8,
+	"utf8":     unicode.UTF8,
+	"utf-8":    unicode.UTF8,
+	"utf16":    unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM),
+	"utf-16":   unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM),
+}
+
+type DecodeArguments[K any] struct {
+	Target   ottl.Getter[K]
+	Encoding string
+}
+
+func NewDecodeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Decode", &DecodeArguments[K]{}, createDecodeFunction[K])
+}
+
+func createDecodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*DecodeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("DecodeFactory args must be of type *DecodeArguments[K]")
+	}
+
+	return decode(args.Target, args.Encoding)
+}
+
+// decode converts the target from the given character encoding to a UTF-8 string.
+func decode[K any](target ottl.Getter[K], encodingName string) (ottl.ExprFunc[K], error) {
+	enc, err := lookupEncoding(encodingName)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		buf, err := bytesOf(val)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := enc.NewDecoder().Bytes(buf)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode %s: %w", encodingName, err)
+		}
+		return string(decoded), nil
+	}, nil
+}
+
+func lookupEncoding(name string) (encoding.Encoding, error) {
+	if enc, ok := encodingOverrides[strings.ToLower(name)]; ok {
+		return enc, nil
+	}
+	enc, err := ianaindex.IANA.Encoding(name)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported encoding %q: %w", name, err)
+	}
+	if enc == nil {
+		return nil, fmt.Errorf("no charmap defined for encoding %q", name)
+	}
+	return enc, nil
+}
+
+// bytesOf returns the raw bytes of a string or byte slice value.
+func bytesOf(val interface{}) ([]byte, error) {
+	switch v := val.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case pcommon.Value:
+		switch v.Type() {
+		case pcommon.ValueTypeStr:
+			return []byte(v.Str()), nil
+		case pcommon.ValueTypeBytes:
+			return v.Bytes().AsRaw(), nil
+		}
+		return nil, ottl.TypeError(fmt.Sprintf("expected string or bytes but got %v", v.Type()))
+	case nil:
+		return nil, ottl.TypeError("expected string or bytes but got nil")
+	default:
+		return nil, ottl.TypeError(fmt.Sprintf("expected string or bytes but got %T", val))
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_decode(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		encoding string
+		expected string
+	}{
+		{
+			name:     "utf-8",
+			value:    "héllo",
+			encoding: "utf-8",
+			expected: "héllo",
+		},
+		{
+			name:     "iso-8859-1",
+			value:    []byte{0x68, 0xe9, 0x6c, 0x6c, 0x6f},
+			encoding: "ISO-8859-1",
+			expected: "héllo",
+		},
+		{
+			name:     "windows-1252",
+			value:    []byte{0x80, 0x31, 0x30},
+			encoding: "windows-1252",
+			expected: "€10",
+		},
+		{
+			name:     "utf-16",
+			value:    []byte{0x68, 0x00, 0xe9, 0x00},
+			encoding: "UTF-16",
+			expected: "hé",
+		},
+		{
+			name:     "shift_jis",
+			value:    []byte{0x82, 0xa0},
+			encoding: "Shift_JIS",
+			expected: "あ",
+		},
+		{
+			name:     "empty",
+			value:    "",
+			encoding: "ISO-8859-1",
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := decode[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.encoding)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_decode_error(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{
+			name:  "int",
+			value: 1,
+		},
+		{
+			name:  "nil",
+			value: nil,
+		},
+		{
+			name:  "map value",
+			value: pcommon.NewValueMap(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := decode[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, "ISO-8859-1")
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func Test_decode_invalid_encoding(t *testing.T) {
+	exprFunc, err := decode[any](&ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return "hello", nil
+		},
+	}, "not-an-encoding")
+	assert.ErrorContains(t, err, "unsupported encoding")
+	assert.Nil(t, exprFunc)
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// maxDecompressedSize bounds the output of Decompress to protect against decompression bombs.
+const maxDecompressedSize = 64 << 20
+
+var decompressors = map[string]func(io.Reader) (io.ReadCloser, error){
+	"gzip": func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	},
+	"zlib": zlib.NewReader,
+	"deflate": func(r io.Reader) (io.ReadCloser, error) {
+		return flate.NewReader(r), nil
+	},
+}
+
+type DecompressArguments[K any] struct {
+	Target ottl.Getter[K]
+	Format string
+}
+
+func NewDecompressFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Decompress", &DecompressArguments[K]{}, createDecompressFunction[K])
+}
+
+func createDecompressFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*DecompressArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("DecompressFactory args must be of type *DecompressArguments[K]")
+	}
+
+	return decompress(args.Target, args.Format)
+}
+
+func decompress[K any](target ottl.Getter[K], format string) (ottl.ExprFunc[K], error) {
+	newReader, ok := decompressors[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported compression format %q, must be one of gzip, zlib or deflate", format)
+	}
+
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		buf, err := bytesOf(val)
+		if err != nil {
+			return nil, err
+		}
+		r, err := newReader(bytes.NewReader(buf))
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress %s: %w", format, err)
+		}
+		defer r.Close()
+
+		decompressed, err := io.ReadAll(io.LimitReader(r, maxDecompressedSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress %s: %w", format, err)
+		}
+		if len(decompressed) > maxDecompressedSize {
+			return nil, fmt.Errorf("decompressed %s data exceeds %d bytes", format, maxDecompressedSize)
+		}
+		return string(decompressed), nil
+	}, nil
+}
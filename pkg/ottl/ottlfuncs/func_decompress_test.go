@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func compressForTest(t *testing.T, format string, data []byte) []byte {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch format {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "zlib":
+		w = zlib.NewWriter(&buf)
+	case "deflate":
+		fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+		require.NoError(t, err)
+		w = fw
+	}
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func Test_decompress(t *testing.T) {
+	for _, format := range []string{"gzip", "zlib", "deflate"} {
+		t.Run(format, func(t *testing.T) {
+			compressed := compressForTest(t, format, []byte("hello world"))
+			exprFunc, err := decompress[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return compressed, nil
+				},
+			}, format)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, "hello world", result)
+		})
+	}
+}
+
+func Test_decompress_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		format string
+	}{
+		{
+			name:   "not compressed",
+			value:  []byte("hello world"),
+			format: "gzip",
+		},
+		{
+			name:   "truncated",
+			value:  compressForTest(t, "zlib", []byte("hello world"))[:5],
+			format: "zlib",
+		},
+		{
+			name:   "wrong format",
+			value:  compressForTest(t, "gzip", []byte("hello world")),
+			format: "zlib",
+		},
+		{
+			name:   "too large",
+			value:  compressForTest(t, "gzip", make([]byte, maxDecompressedSize+1)),
+			format: "gzip",
+		},
+		{
+			name:   "int",
+			value:  1,
+			format: "gzip",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := decompress[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.format)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func Test_decompress_invalid_format(t *testing.T) {
+	exprFunc, err := decompress[any](&ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return []byte{}, nil
+		},
+	}, "brotli")
+	assert.ErrorContains(t, err, "unsupported compression format")
+	assert.Nil(t, exprFunc)
+}
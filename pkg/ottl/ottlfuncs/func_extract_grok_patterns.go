@@ -0,0 +1,271 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// maxGrokNesting is the maximum depth of patterns referencing other patterns, which protects
+// against patterns referencing themselves.
+const maxGrokNesting = 32
+
+// grokReference matches the references to other patterns, in the %{NAME}, %{NAME:field} and
+// %{NAME:field:type} forms.
+var grokReference = regexp.MustCompile(`%{(\w+)(?::([^:}]+))?(?::(int|float|string))?}`)
+
+// grokName matches the valid names for patterns.
+var grokName = regexp.MustCompile(`^\w+$`)
+
+// grokGroupPrefix prefixes the names of the capture groups of named references, which are
+// followed by the index of the field they hold.
+const grokGroupPrefix = "_grok"
+
+type ExtractGrokPatternsArguments[K any] struct {
+	Target             ottl.StringGetter[K]
+	Pattern            string
+	PatternDefinitions ottl.Optional[[]string]
+}
+
+func NewExtractGrokPatternsFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ExtractGrokPatterns", &ExtractGrokPatternsArguments[K]{}, createExtractGrokPatternsFunction[K])
+}
+
+func createExtractGrokPatternsFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ExtractGrokPatternsArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ExtractGrokPatternsFactory args must be of type *ExtractGrokPatternsArguments[K]")
+	}
+
+	return extractGrokPatterns(args.Target, args.Pattern, args.PatternDefinitions)
+}
+
+// grokField is a field extracted by a grok pattern, along with the type it's converted to.
+type grokField struct {
+	name      string
+	fieldType string
+}
+
+func extractGrokPatterns[K any](target ottl.StringGetter[K], pattern string, definitions ottl.Optional[[]string]) (ottl.ExprFunc[K], error) {
+	library := grokPatterns
+	if !definitions.IsEmpty() {
+		library = make(map[string]string, len(grokPatterns)+len(definitions.Get()))
+		for name, def := range grokPatterns {
+			library[name] = def
+		}
+		for _, def := range definitions.Get() {
+			name, expr, found := strings.Cut(def, "=")
+			if !found || !grokName.MatchString(name) {
+				return nil, fmt.Errorf("the pattern definition %q supplied to ExtractGrokPatterns must be in the NAME=pattern form", def)
+			}
+			library[name] = expr
+		}
+	}
+
+	var fields []grokField
+	expanded, err := expandGrokPattern(pattern, library, &fields, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := regexp.Compile(expanded)
+	if err != nil {
+		return nil, fmt.Errorf("the pattern supplied to ExtractGrokPatterns is not a valid pattern: %w", err)
+	}
+
+	subexpFields, err := grokSubexpFields(r, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewMap()
+		matches := r.FindStringSubmatchIndex(val)
+		if matches == nil {
+			return result, nil
+		}
+
+		for i, field := range subexpFields {
+			// fields within optional parts of the pattern that didn't match are left out
+			if field == nil || matches[2*i] < 0 {
+				continue
+			}
+			if err = putGrokField(result, *field, val[matches[2*i]:matches[2*i+1]]); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}, nil
+}
+
+// grokSubexpFields returns the field extracted by each capture group of r, indexed like r.SubexpNames().
+// The capture groups of named references hold the corresponding field of fields, while capture groups
+// named in the pattern itself are extracted as strings under their name.
+func grokSubexpFields(r *regexp.Regexp, fields []grokField) ([]*grokField, error) {
+	references := make(map[string]int, len(fields))
+	for i := range fields {
+		references[grokGroupName(i)] = i
+	}
+
+	subexpFields := make([]*grokField, r.NumSubexp()+1)
+	named := make(map[string]bool)
+	for i, subexp := range r.SubexpNames() {
+		if subexp == "" {
+			continue
+		}
+		if named[subexp] {
+			return nil, fmt.Errorf("the capture group name %q is used more than once in the pattern supplied to ExtractGrokPatterns", subexp)
+		}
+		named[subexp] = true
+		if idx, ok := references[subexp]; ok {
+			subexpFields[i] = &fields[idx]
+		} else {
+			subexpFields[i] = &grokField{name: subexp}
+		}
+	}
+
+	if len(named) == 0 {
+		return nil, fmt.Errorf("at least 1 named pattern, such as %%{WORD:name}, must be supplied in the given pattern")
+	}
+	return subexpFields, nil
+}
+
+func grokGroupName(field int) string {
+	return grokGroupPrefix + strconv.Itoa(field)
+}
+
+// expandGrokPattern replaces the references to other patterns with their definitions. Named references
+// become capture groups named after the index of the field in fields, as field names like "http.method"
+// aren't valid names for capture groups.
+func expandGrokPattern(pattern string, library map[string]string, fields *[]grokField, depth int) (string, error) {
+	if depth > maxGrokNesting {
+		return "", fmt.Errorf("the pattern supplied to ExtractGrokPatterns is nested too deeply, it might reference itself")
+	}
+
+	var err error
+	expanded := grokReference.ReplaceAllStringFunc(pattern, func(ref string) string {
+		if err != nil {
+			return ""
+		}
+
+		parts := grokReference.FindStringSubmatch(ref)
+		def, found := library[parts[1]]
+		if !found {
+			err = fmt.Errorf("the pattern %q supplied to ExtractGrokPatterns is not defined", parts[1])
+			return ""
+		}
+
+		var sub string
+		sub, err = expandGrokPattern(def, library, fields, depth+1)
+		if err != nil {
+			return ""
+		}
+
+		if parts[2] == "" {
+			return "(?:" + sub + ")"
+		}
+		*fields = append(*fields, grokField{name: parts[2], fieldType: parts[3]})
+		return fmt.Sprintf("(?P<%s>%s)", grokGroupName(len(*fields)-1), sub)
+	})
+	return expanded, err
+}
+
+func putGrokField(result pcommon.Map, field grokField, value string) error {
+	switch field.fieldType {
+	case "int":
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("the value %q extracted for %q is not an int: %w", value, field.name, err)
+		}
+		result.PutInt(field.name, i)
+	case "float":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("the value %q extracted for %q is not a float: %w", value, field.name, err)
+		}
+		result.PutDouble(field.name, f)
+	default:
+		result.PutStr(field.name, value)
+	}
+	return nil
+}
+
+// grokPatterns is the library of patterns available to ExtractGrokPatterns, based on the
+// patterns shipped with Logstash, adapted to the RE2 syntax.
+var grokPatterns = map[string]string{
+	// basic
+	"USERNAME":       `[a-zA-Z0-9._-]+`,
+	"USER":           `%{USERNAME}`,
+	"EMAILLOCALPART": `[a-zA-Z0-9!#$%&'*+\-/=?^_{|}~]+(?:\.[a-zA-Z0-9!#$%&'*+\-/=?^_{|}~]+)*`,
+	"EMAILADDRESS":   `%{EMAILLOCALPART}@%{HOSTNAME}`,
+	"INT":            `[+-]?[0-9]+`,
+	"BASE10NUM":      `[+-]?(?:[0-9]+(?:\.[0-9]+)?|\.[0-9]+)`,
+	"NUMBER":         `%{BASE10NUM}`,
+	"BASE16NUM":      `[+-]?(?:0x)?[0-9A-Fa-f]+`,
+	"POSINT":         `[1-9][0-9]*`,
+	"NONNEGINT":      `[0-9]+`,
+	"WORD":           `\b\w+\b`,
+	"NOTSPACE":       `\S+`,
+	"SPACE":          `\s*`,
+	"DATA":           `.*?`,
+	"GREEDYDATA":     `.*`,
+	"QUOTEDSTRING":   `"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'`,
+	"UUID":           `[A-Fa-f0-9]{8}-(?:[A-Fa-f0-9]{4}-){3}[A-Fa-f0-9]{12}`,
+
+	// networking
+	"MAC":          `(?:[A-Fa-f0-9]{2}[:-]){5}[A-Fa-f0-9]{2}|(?:[A-Fa-f0-9]{4}\.){2}[A-Fa-f0-9]{4}`,
+	"IPV4":         `(?:(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])`,
+	"IPV6":         `(?:[0-9A-Fa-f]{1,4}:){7}[0-9A-Fa-f]{1,4}|(?:[0-9A-Fa-f]{1,4}:){1,7}:|(?:[0-9A-Fa-f]{1,4}:){1,6}:[0-9A-Fa-f]{1,4}|(?:[0-9A-Fa-f]{1,4}:){1,5}(?::[0-9A-Fa-f]{1,4}){1,2}|(?:[0-9A-Fa-f]{1,4}:){1,4}(?::[0-9A-Fa-f]{1,4}){1,3}|(?:[0-9A-Fa-f]{1,4}:){1,3}(?::[0-9A-Fa-f]{1,4}){1,4}|(?:[0-9A-Fa-f]{1,4}:){1,2}(?::[0-9A-Fa-f]{1,4}){1,5}|[0-9A-Fa-f]{1,4}:(?::[0-9A-Fa-f]{1,4}){1,6}|:(?:(?::[0-9A-Fa-f]{1,4}){1,7}|:)`,
+	"IP":           `%{IPV6}|%{IPV4}`,
+	"HOSTNAME":     `\b(?:[0-9A-Za-z][0-9A-Za-z-]{0,62})(?:\.(?:[0-9A-Za-z][0-9A-Za-z-]{0,62}))*\.?\b`,
+	"IPORHOST":     `%{IP}|%{HOSTNAME}`,
+	"HOSTPORT":     `%{IPORHOST}:%{POSINT}`,
+	"URIPROTO":     `[A-Za-z][A-Za-z0-9+\-.]*`,
+	"URIHOST":      `%{IPORHOST}(?::%{POSINT})?`,
+	"URIPATH":      `(?:/[A-Za-z0-9$.+!*'(){},~:;=@#%&_\-]*)+`,
+	"URIPARAM":     `\?[A-Za-z0-9$.+!*'|(){},~@#%&/=:;_?\-\[\]<>]*`,
+	"URIPATHPARAM": `%{URIPATH}(?:%{URIPARAM})?`,
+	"URI":          `%{URIPROTO}://(?:%{USER}(?::[^@]*)?@)?(?:%{URIHOST})?(?:%{URIPATHPARAM})?`,
+
+	// dates and times
+	"MONTH":             `\b(?:[Jj]an(?:uary|uar)?|[Ff]eb(?:ruary|ruar)?|[Mm]ar(?:ch|z)?|[Aa]pr(?:il)?|[Mm]a(?:y|i)?|[Jj]un(?:e|i)?|[Jj]ul(?:y|i)?|[Aa]ug(?:ust)?|[Ss]ep(?:tember)?|[Oo](?:c|k)?t(?:ober)?|[Nn]ov(?:ember)?|[Dd]e(?:c|z)(?:ember)?)\b`,
+	"MONTHNUM":          `0?[1-9]|1[0-2]`,
+	"MONTHDAY":          `(?:0[1-9])|(?:[12][0-9])|(?:3[01])|[1-9]`,
+	"DAY":               `(?:Mon(?:day)?|Tue(?:sday)?|Wed(?:nesday)?|Thu(?:rsday)?|Fri(?:day)?|Sat(?:urday)?|Sun(?:day)?)`,
+	"YEAR":              `(?:\d\d){1,2}`,
+	"HOUR":              `2[0123]|[01]?[0-9]`,
+	"MINUTE":            `[0-5][0-9]`,
+	"SECOND":            `(?:[0-5]?[0-9]|60)(?:[:.,][0-9]+)?`,
+	"TIME":              `%{HOUR}:%{MINUTE}(?::%{SECOND})?`,
+	"DATE_US":           `%{MONTHNUM}[/-]%{MONTHDAY}[/-]%{YEAR}`,
+	"DATE_EU":           `%{MONTHDAY}[./-]%{MONTHNUM}[./-]%{YEAR}`,
+	"ISO8601_TIMEZONE":  `Z|[+-]%{HOUR}(?::?%{MINUTE})`,
+	"TIMESTAMP_ISO8601": `%{YEAR}-%{MONTHNUM}-%{MONTHDAY}[T ]%{HOUR}:?%{MINUTE}(?::?%{SECOND})?(?:%{ISO8601_TIMEZONE})?`,
+	"SYSLOGTIMESTAMP":   `%{MONTH} +%{MONTHDAY} %{TIME}`,
+	"HTTPDATE":          `%{MONTHDAY}/%{MONTH}/%{YEAR}:%{TIME} %{INT}`,
+
+	// logs
+	"LOGLEVEL":          `[Aa]lert|ALERT|[Tt]race|TRACE|[Dd]ebug|DEBUG|[Nn]otice|NOTICE|[Ii]nfo(?:rmation)?|INFO(?:RMATION)?|[Ww]arn(?:ing)?|WARN(?:ING)?|[Ee]rr(?:or)?|ERR(?:OR)?|[Cc]rit(?:ical)?|CRIT(?:ICAL)?|[Ff]atal|FATAL|[Ss]evere|SEVERE|EMERG(?:ENCY)?|[Ee]merg(?:ency)?`,
+	"PROG":              `[\x21-\x5a\x5c\x5e-\x7e]+`,
+	"SYSLOGPROG":        `%{PROG:program}(?:\[%{POSINT:pid}\])?`,
+	"SYSLOGHOST":        `%{IPORHOST}`,
+	"SYSLOGBASE":        `%{SYSLOGTIMESTAMP:timestamp} %{SYSLOGHOST:logsource} %{SYSLOGPROG}:`,
+	"HTTPDUSER":         `%{EMAILADDRESS}|%{USER}`,
+	"COMMONAPACHELOG":   `%{IPORHOST:clientip} %{HTTPDUSER:ident} %{HTTPDUSER:auth} \[%{HTTPDATE:timestamp}\] "(?:%{WORD:verb} %{NOTSPACE:request}(?: HTTP/%{NUMBER:httpversion})?|%{DATA:rawrequest})" %{NUMBER:response} (?:%{NUMBER:bytes}|-)`,
+	"COMBINEDAPACHELOG": `%{COMMONAPACHELOG} %{QUOTEDSTRING:referrer} %{QUOTEDSTRING:agent}`,
+}
@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_extractGrokPatterns(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		pattern     string
+		definitions ottl.Optional[[]string]
+		expected    map[string]any
+	}{
+		{
+			name:    "simple patterns",
+			target:  "2023-10-16T12:30:00.123Z WARN 10.0.0.1 took 12.5ms",
+			pattern: "%{TIMESTAMP_ISO8601:timestamp} %{LOGLEVEL:level} %{IP:client.address} took %{NUMBER:duration}ms",
+			expected: map[string]any{
+				"timestamp":      "2023-10-16T12:30:00.123Z",
+				"level":          "WARN",
+				"client.address": "10.0.0.1",
+				"duration":       "12.5",
+			},
+		},
+		{
+			name:    "typed fields",
+			target:  "status=200 took=12.5",
+			pattern: "status=%{INT:status:int} took=%{NUMBER:duration:float}",
+			expected: map[string]any{
+				"status":   int64(200),
+				"duration": 12.5,
+			},
+		},
+		{
+			name:    "nested named patterns",
+			target:  "Mar  7 00:05:01 myhost CRON[12345]: (root) CMD (command)",
+			pattern: "%{SYSLOGBASE} %{GREEDYDATA:message}",
+			expected: map[string]any{
+				"timestamp": "Mar  7 00:05:01",
+				"logsource": "myhost",
+				"program":   "CRON",
+				"pid":       "12345",
+				"message":   "(root) CMD (command)",
+			},
+		},
+		{
+			name:    "optional fields not matched",
+			target:  "Mar  7 00:05:01 myhost kernel: message",
+			pattern: "%{SYSLOGBASE} %{GREEDYDATA:message}",
+			expected: map[string]any{
+				"timestamp": "Mar  7 00:05:01",
+				"logsource": "myhost",
+				"program":   "kernel",
+				"message":   "message",
+			},
+		},
+		{
+			name:    "apache combined log",
+			target:  `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08"`,
+			pattern: "%{COMBINEDAPACHELOG}",
+			expected: map[string]any{
+				"clientip":    "127.0.0.1",
+				"ident":       "-",
+				"auth":        "frank",
+				"timestamp":   "10/Oct/2000:13:55:36 -0700",
+				"verb":        "GET",
+				"request":     "/apache_pb.gif",
+				"httpversion": "1.0",
+				"response":    "200",
+				"bytes":       "2326",
+				"referrer":    `"http://www.example.com/start.html"`,
+				"agent":       `"Mozilla/4.08"`,
+			},
+		},
+		{
+			name:        "custom pattern definitions",
+			target:      "order ORD-12345 shipped",
+			pattern:     "order %{ORDERID:order.id} %{WORD:status}",
+			definitions: ottl.NewTestingOptional[[]string]([]string{"ORDERID=ORD-%{POSINT}"}),
+			expected: map[string]any{
+				"order.id": "ORD-12345",
+				"status":   "shipped",
+			},
+		},
+		{
+			name:        "overridden pattern definitions",
+			target:      "level=verbose",
+			pattern:     "level=%{LOGLEVEL:level}",
+			definitions: ottl.NewTestingOptional[[]string]([]string{"LOGLEVEL=verbose|quiet"}),
+			expected: map[string]any{
+				"level": "verbose",
+			},
+		},
+		{
+			name:    "user-named capture groups",
+			target:  "user=alice status=200 took=12.5",
+			pattern: `user=(?P<user>\w+) status=%{INT:status:int} took=(?P<duration>[0-9.]+)`,
+			expected: map[string]any{
+				"user":     "alice",
+				"status":   int64(200),
+				"duration": "12.5",
+			},
+		},
+		{
+			name:        "user-named capture groups in definitions",
+			target:      "order ORD-12345 shipped",
+			pattern:     "order %{ORDER:order}",
+			definitions: ottl.NewTestingOptional[[]string]([]string{"ORDER=ORD-(?P<order_number>%{POSINT}) %{WORD:status}"}),
+			expected: map[string]any{
+				"order":        "ORD-12345 shipped",
+				"order_number": "12345",
+				"status":       "shipped",
+			},
+		},
+		{
+			name:    "unmatched optional groups",
+			target:  "GET /index.html",
+			pattern: `%{WORD:verb} %{URIPATH:path}(?:\?(?P<query>\S+))?(?: HTTP/%{NUMBER:version})?`,
+			expected: map[string]any{
+				"verb": "GET",
+				"path": "/index.html",
+			},
+		},
+		{
+			name:     "no match",
+			target:   "foobar",
+			pattern:  "^%{INT:number}$",
+			expected: map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := extractGrokPatterns[any](target, tt.pattern, tt.definitions)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_extractGrokPatterns_validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		pattern     string
+		definitions ottl.Optional[[]string]
+	}{
+		{
+			name:    "bad regex",
+			pattern: "%{WORD:word}(",
+		},
+		{
+			name:    "no named pattern",
+			pattern: "%{WORD} %{INT}",
+		},
+		{
+			name:    "undefined pattern",
+			pattern: "%{UNKNOWN:field}",
+		},
+		{
+			name:        "recursive pattern",
+			pattern:     "%{LOOP:field}",
+			definitions: ottl.NewTestingOptional[[]string]([]string{"LOOP=a%{LOOP}"}),
+		},
+		{
+			name:    "duplicate capture group name",
+			pattern: "(?P<word>a) %{WORD:field} (?P<word>b)",
+		},
+		{
+			name:    "capture group named like a reference",
+			pattern: "(?P<_grok0>a) %{WORD:field}",
+		},
+		{
+			name:        "invalid definition",
+			pattern:     "%{WORD:field}",
+			definitions: ottl.NewTestingOptional[[]string]([]string{"NO_EQUAL_SIGN"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return "foobar", nil
+				},
+			}
+			exprFunc, err := extractGrokPatterns[any](target, tt.pattern, tt.definitions)
+			assert.Error(t, err)
+			assert.Nil(t, exprFunc)
+		})
+	}
+}
+
+func Test_extractGrokPatterns_bad_input(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  any
+		pattern string
+	}{
+		{
+			name:    "target is non-string",
+			target:  123,
+			pattern: "%{GREEDYDATA:line}",
+		},
+		{
+			name:    "target is nil",
+			target:  nil,
+			pattern: "%{GREEDYDATA:line}",
+		},
+		{
+			name:    "typed field with invalid value",
+			target:  "99999999999999999999",
+			pattern: "%{INT:number:int}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := extractGrokPatterns[any](target, tt.pattern, ottl.Optional[[]string]{})
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type HexDecodeArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewHexDecodeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("HexDecode", &HexDecodeArguments[K]{}, createHexDecodeFunction[K])
+}
+
+func createHexDecodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*HexDecodeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("HexDecodeFactory args must be of type *HexDecodeArguments[K]")
+	}
+
+	return hexDecode(args.Target), nil
+}
+
+func hexDecode[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := hex.DecodeString(val)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode hex: %w", err)
+		}
+		return string(decoded), nil
+	}
+}
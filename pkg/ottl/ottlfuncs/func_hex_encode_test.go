@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_hexEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{
+			name:     "string",
+			value:    "hello",
+			expected: "68656c6c6f",
+		},
+		{
+			name:     "bytes",
+			value:    []byte{0x00, 0xab, 0xff},
+			expected: "00abff",
+		},
+		{
+			name:     "empty string",
+			value:    "",
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := hexEncode[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_hexEncode_error(t *testing.T) {
+	exprFunc := hexEncode[any](&ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return int64(1), nil
+		},
+	})
+	result, err := exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
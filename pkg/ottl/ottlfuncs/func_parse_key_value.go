@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	defaultKeyValueDelimiter     = "="
+	defaultKeyValuePairDelimiter = " "
+)
+
+type ParseKeyValueArguments[K any] struct {
+	Target        ottl.StringGetter[K]
+	Delimiter     ottl.Optional[string]
+	PairDelimiter ottl.Optional[string]
+}
+
+func NewParseKeyValueFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseKeyValue", &ParseKeyValueArguments[K]{}, createParseKeyValueFunction[K])
+}
+
+func createParseKeyValueFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseKeyValueArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseKeyValueFactory args must be of type *ParseKeyValueArguments[K]")
+	}
+
+	return parseKeyValue[K](args.Target, args.Delimiter, args.PairDelimiter)
+}
+
+// parseKeyValue returns a `pcommon.Map` struct that is a result of parsing the target string as key value pairs.
+// Keys and values can be enclosed in double or single quotes, in which case they can contain the delimiters.
+func parseKeyValue[K any](target ottl.StringGetter[K], d ottl.Optional[string], p ottl.Optional[string]) (ottl.ExprFunc[K], error) {
+	delimiter := defaultKeyValueDelimiter
+	if !d.IsEmpty() {
+		if d.Get() == "" {
+			return nil, fmt.Errorf("delimiter cannot be set to an empty string")
+		}
+		delimiter = d.Get()
+	}
+
+	pairDelimiter := defaultKeyValuePairDelimiter
+	if !p.IsEmpty() {
+		if p.Get() == "" {
+			return nil, fmt.Errorf("pair delimiter cannot be set to an empty string")
+		}
+		pairDelimiter = p.Get()
+	}
+
+	if delimiter == pairDelimiter {
+		return nil, fmt.Errorf("pair delimiter %q cannot be equal to delimiter %q", pairDelimiter, delimiter)
+	}
+
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if source == "" {
+			return nil, fmt.Errorf("cannot parse from empty string")
+		}
+
+		result := pcommon.NewMap()
+		for _, pair := range splitOutsideQuotes(source, pairDelimiter, -1) {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+
+			kv := splitOutsideQuotes(pair, delimiter, 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("cannot split %q into 2 items, got %d item(s)", pair, len(kv))
+			}
+
+			result.PutStr(unquote(strings.TrimSpace(kv[0])), unquote(strings.TrimSpace(kv[1])))
+		}
+		return result, nil
+	}, nil
+}
+
+// splitOutsideQuotes splits the input by the delimiter into at most n parts (all of them when n < 0),
+// ignoring delimiters enclosed in double or single quotes.
+func splitOutsideQuotes(input string, delimiter string, n int) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(input); i++ {
+		switch {
+		case quote != 0:
+			if input[i] == quote {
+				quote = 0
+			}
+		case (input[i] == '"' || input[i] == '\'') && (i == 0 || !isAlphanumeric(input[i-1])):
+			// quotes within words, such as apostrophes, don't start a quoted section
+			quote = input[i]
+		case strings.HasPrefix(input[i:], delimiter) && (n < 0 || len(parts) < n-1):
+			parts = append(parts, input[start:i])
+			i += len(delimiter) - 1
+			start = i + 1
+		}
+	}
+	return append(parts, input[start:])
+}
+
+func isAlphanumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// unquote removes the double or single quotes enclosing the value, if any.
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
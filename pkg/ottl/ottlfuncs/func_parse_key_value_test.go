@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseKeyValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		delimiter     ottl.Optional[string]
+		pairDelimiter ottl.Optional[string]
+		expected      map[string]any
+	}{
+		{
+			name:   "simple pairs",
+			target: "name=test id=1 level=info",
+			expected: map[string]any{
+				"name":  "test",
+				"id":    "1",
+				"level": "info",
+			},
+		},
+		{
+			name:   "extra whitespace",
+			target: "  name=test    id=1 ",
+			expected: map[string]any{
+				"name": "test",
+				"id":   "1",
+			},
+		},
+		{
+			name:   "quoted values",
+			target: `msg="user logged in" user='Joe Doe' path="/a=b"`,
+			expected: map[string]any{
+				"msg":  "user logged in",
+				"user": "Joe Doe",
+				"path": "/a=b",
+			},
+		},
+		{
+			name:   "quoted keys",
+			target: `"first name"=Joe`,
+			expected: map[string]any{
+				"first name": "Joe",
+			},
+		},
+		{
+			name:   "apostrophes in values",
+			target: `msg=it's country=UK`,
+			expected: map[string]any{
+				"msg":     "it's",
+				"country": "UK",
+			},
+		},
+		{
+			name:   "delimiter in value",
+			target: "query=a=b",
+			expected: map[string]any{
+				"query": "a=b",
+			},
+		},
+		{
+			name:   "empty value",
+			target: "name= id=1",
+			expected: map[string]any{
+				"name": "",
+				"id":   "1",
+			},
+		},
+		{
+			name:          "custom delimiters",
+			target:        `name:test|msg:"a|b"|id:1`,
+			delimiter:     ottl.NewTestingOptional[string](":"),
+			pairDelimiter: ottl.NewTestingOptional[string]("|"),
+			expected: map[string]any{
+				"name": "test",
+				"msg":  "a|b",
+				"id":   "1",
+			},
+		},
+		{
+			name:          "multi-character delimiters",
+			target:        "name=>test, id=>1",
+			delimiter:     ottl.NewTestingOptional[string]("=>"),
+			pairDelimiter: ottl.NewTestingOptional[string](", "),
+			expected: map[string]any{
+				"name": "test",
+				"id":   "1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := parseKeyValue[any](target, tt.delimiter, tt.pairDelimiter)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_parseKeyValue_bad_input(t *testing.T) {
+	tests := []struct {
+		name   string
+		target any
+	}{
+		{
+			name:   "not a string",
+			target: 1,
+		},
+		{
+			name:   "nil",
+			target: nil,
+		},
+		{
+			name:   "empty string",
+			target: "",
+		},
+		{
+			name:   "missing delimiter",
+			target: "name=test orphan",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := parseKeyValue[any](target, ottl.Optional[string]{}, ottl.Optional[string]{})
+			require.NoError(t, err)
+
+			_, err = exprFunc(context.Background(), nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_parseKeyValue_validation(t *testing.T) {
+	tests := []struct {
+		name          string
+		delimiter     ottl.Optional[string]
+		pairDelimiter ottl.Optional[string]
+	}{
+		{
+			name:      "empty delimiter",
+			delimiter: ottl.NewTestingOptional[string](""),
+		},
+		{
+			name:          "empty pair delimiter",
+			pairDelimiter: ottl.NewTestingOptional[string](""),
+		},
+		{
+			name:          "same delimiters",
+			delimiter:     ottl.NewTestingOptional[string]("|"),
+			pairDelimiter: ottl.NewTestingOptional[string]("|"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return "name=test", nil
+				},
+			}
+			exprFunc, err := parseKeyValue[any](target, tt.delimiter, tt.pairDelimiter)
+			assert.Error(t, err)
+			assert.Nil(t, exprFunc)
+		})
+	}
+}
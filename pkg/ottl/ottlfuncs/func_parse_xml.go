@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	xmlAttributePrefix = "@"
+	xmlTextKey         = "#text"
+)
+
+type ParseXMLArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewParseXMLFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseXML", &ParseXMLArguments[K]{}, createParseXMLFunction[K])
+}
+
+func createParseXMLFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseXMLArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseXMLFactory args must be of type *ParseXMLArguments[K]")
+	}
+
+	return parseXML(args.Target), nil
+}
+
+// parseXML returns a `pcommon.Map` struct that is a result of parsing the target string as XML.
+// The map has a single key, the name of the root element, and each element is converted as follows:
+//
+//	element with text only         -> string
+//	element with attributes        -> map, with the attributes prefixed by "@"
+//	element with child elements    -> map, with a key for each child element name
+//	repeated child elements        -> slice, in the order they appear
+//	text of elements that are maps -> "#text" key
+func parseXML[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		targetVal, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		root, err := decodeXML(targetVal)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewMap()
+		err = result.FromRaw(map[string]any{root.name: root.value()})
+		return result, err
+	}
+}
+
+// xmlElement is an XML element as read from the document, keeping the order of its children.
+type xmlElement struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlElement
+	text     strings.Builder
+}
+
+func decodeXML(input string) (*xmlElement, error) {
+	decoder := xml.NewDecoder(strings.NewReader(input))
+
+	var root *xmlElement
+	var stack []*xmlElement
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &xmlElement{name: t.Name.Local, attrs: t.Attr}
+			switch {
+			case len(stack) > 0:
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, element)
+			case root != nil:
+				return nil, errors.New("invalid XML: more than one root element")
+			default:
+				root = element
+			}
+			stack = append(stack, element)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("invalid XML: no root element found")
+	}
+	return root, nil
+}
+
+func (e *xmlElement) value() any {
+	text := strings.TrimSpace(e.text.String())
+	if len(e.attrs) == 0 && len(e.children) == 0 {
+		return text
+	}
+
+	result := make(map[string]any, len(e.attrs)+len(e.children)+1)
+	for _, attr := range e.attrs {
+		result[xmlAttributePrefix+attr.Name.Local] = attr.Value
+	}
+
+	// children sharing the same name are grouped into a slice
+	var names []string
+	grouped := map[string][]any{}
+	for _, child := range e.children {
+		if _, ok := grouped[child.name]; !ok {
+			names = append(names, child.name)
+		}
+		grouped[child.name] = append(grouped[child.name], child.value())
+	}
+	for _, name := range names {
+		if values := grouped[name]; len(values) == 1 {
+			result[name] = values[0]
+		} else {
+			result[name] = values
+		}
+	}
+
+	if text != "" {
+		result[xmlTextKey] = text
+	}
+	return result
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected map[string]any
+	}{
+		{
+			name:   "text only",
+			target: `<Log>some text</Log>`,
+			expected: map[string]any{
+				"Log": "some text",
+			},
+		},
+		{
+			name:   "empty element",
+			target: `<Log/>`,
+			expected: map[string]any{
+				"Log": "",
+			},
+		},
+		{
+			name:   "attributes",
+			target: `<Log level="info" id="1">some text</Log>`,
+			expected: map[string]any{
+				"Log": map[string]any{
+					"@level": "info",
+					"@id":    "1",
+					"#text":  "some text",
+				},
+			},
+		},
+		{
+			name: "nested elements",
+			target: `<?xml version="1.0" encoding="UTF-8"?>
+<Log>
+  <!-- a comment -->
+  <User>
+    <ID>00001</ID>
+    <Name type="first">Joe</Name>
+  </User>
+  <Text>User did a thing</Text>
+</Log>`,
+			expected: map[string]any{
+				"Log": map[string]any{
+					"User": map[string]any{
+						"ID": "00001",
+						"Name": map[string]any{
+							"@type": "first",
+							"#text": "Joe",
+						},
+					},
+					"Text": "User did a thing",
+				},
+			},
+		},
+		{
+			name:   "repeated elements",
+			target: `<Log><Tag>a</Tag><Other>b</Other><Tag>c</Tag></Log>`,
+			expected: map[string]any{
+				"Log": map[string]any{
+					"Tag":   []any{"a", "c"},
+					"Other": "b",
+				},
+			},
+		},
+		{
+			name:   "namespaces",
+			target: `<ev:Event xmlns:ev="http://example.com/event"><ev:System ev:id="4624"/></ev:Event>`,
+			expected: map[string]any{
+				"Event": map[string]any{
+					"@ev": "http://example.com/event",
+					"System": map[string]any{
+						"@id": "4624",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := parseXML[any](target)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_ParseXML_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target any
+	}{
+		{
+			name:   "not a string",
+			target: 1,
+		},
+		{
+			name:   "invalid XML",
+			target: `<Log><Unclosed></Log>`,
+		},
+		{
+			name:   "no root element",
+			target: `just text`,
+		},
+		{
+			name:   "multiple root elements",
+			target: `<Log>a</Log><Log>b</Log>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(ctx context.Context, tCtx any) (interface{}, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := parseXML[any](target)
+			_, err := exprFunc(context.Background(), nil)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type URLEncodeArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewURLEncodeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("URLEncode", &URLEncodeArguments[K]{}, createURLEncodeFunction[K])
+}
+
+func createURLEncodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*URLEncodeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("URLEncodeFactory args must be of type *URLEncodeArguments[K]")
+	}
+
+	return urlEncode(args.Target), nil
+}
+
+func urlEncode[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		return url.QueryEscape(val), nil
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_urlEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{
+			name:     "reserved characters",
+			value:    "a b&c=d/e?",
+			expected: "a+b%26c%3Dd%2Fe%3F",
+		},
+		{
+			name:     "unicode",
+			value:    "héllo",
+			expected: "h%C3%A9llo",
+		},
+		{
+			name:     "unreserved characters",
+			value:    "abc-_.~",
+			expected: "abc-_.~",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := urlEncode[any](&ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_urlEncode_error(t *testing.T) {
+	exprFunc := urlEncode[any](&ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (interface{}, error) {
+			return 1, nil
+		},
+	})
+	result, err := exprFunc(context.Background(), nil)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
@@ -34,11 +34,18 @@ func StandardConverters[K any]() map[string]ottl.Factory[K] {
 func converters[K any]() []ottl.Factory[K] {
 	return []ottl.Factory[K]{
 		// Converters
+		NewBase64DecodeFactory[K](),
+		NewBase64EncodeFactory[K](),
 		NewConcatFactory[K](),
 		NewConvertCaseFactory[K](),
+		NewDecodeFactory[K](),
+		NewDecompressFactory[K](),
 		NewDurationFactory[K](),
+		NewExtractGrokPatternsFactory[K](),
 		NewExtractPatternsFactory[K](),
 		NewFnvFactory[K](),
+		NewHexDecodeFactory[K](),
+		NewHexEncodeFactory[K](),
 		NewHoursFactory[K](),
 		NewIntFactory[K](),
 		NewIsMapFactory[K](),
@@ -52,6 +59,8 @@ func converters[K any]() []ottl.Factory[K] {
 		NewNanosecondsFactory[K](),
 		NewNowFactory[K](),
 		NewParseJSONFactory[K](),
+		NewParseKeyValueFactory[K](),
+		NewParseXMLFactory[K](),
 		NewSecondsFactory[K](),
 		NewSHA1Factory[K](),
 		NewSHA256Factory[K](),
@@ -65,6 +74,8 @@ func converters[K any]() []ottl.Factory[K] {
 		NewUnixMilliFactory[K](),
 		NewUnixNanoFactory[K](),
 		NewUnixSecondsFactory[K](),
+		NewURLDecodeFactory[K](),
+		NewURLEncodeFactory[K](),
 		NewUUIDFactory[K](),
 	}
 }
@@ -36,6 +36,7 @@ type Parser[K any] struct {
 	functions         map[string]Factory[K]
 	pathParser        PathExpressionParser[K]
 	enumParser        EnumParser
+	macros            *Macros
 	telemetrySettings component.TelemetrySettings
 }
 
@@ -97,6 +98,14 @@ func WithEnumParser[K any](parser EnumParser) Option[K] {
 	}
 }
 
+// WithMacros makes the given macros available to the statements parsed by the Parser.
+// Invocations of the macros are expanded before the statements are built.
+func WithMacros[K any](macros *Macros) Option[K] {
+	return func(p *Parser[K]) {
+		p.macros = macros
+	}
+}
+
 // ParseStatements parses string statements into ottl.Statement objects ready for execution.
 // Returns a slice of statements and a nil error on successful parsing.
 // If parsing fails, returns an empty slice  with a multierr error containing
@@ -126,6 +135,12 @@ func (p *Parser[K]) ParseStatement(statement string) (*Statement[K], error) {
 	if err != nil {
 		return nil, err
 	}
+	if p.macros != nil {
+		parsed, err = p.macros.expandStatement(parsed, p.isFunction)
+		if err != nil {
+			return nil, err
+		}
+	}
 	function, err := p.newFunctionCall(parsed.Editor)
 	if err != nil {
 		return nil, err
@@ -141,6 +156,11 @@ func (p *Parser[K]) ParseStatement(statement string) (*Statement[K], error) {
 	}, nil
 }
 
+func (p *Parser[K]) isFunction(name string) bool {
+	_, ok := p.functions[name]
+	return ok
+}
+
 var parser = newParser[parsedStatement]()
 
 func parseStatement(raw string) (*parsedStatement, error) {
@@ -157,6 +177,22 @@ func parseStatement(raw string) (*parsedStatement, error) {
 	return parsed, nil
 }
 
+var conditionParser = newParser[booleanExpression]()
+
+func parseCondition(raw string) (*booleanExpression, error) {
+	parsed, err := conditionParser.ParseString("", raw)
+
+	if err != nil {
+		return nil, fmt.Errorf("condition has invalid syntax: %w", err)
+	}
+	err = parsed.checkForCustomError()
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
 // newParser returns a parser that can be used to read a string into a parsedStatement. An error will be returned if the string
 // is not formatted for the DSL.
 func newParser[G any]() *participle.Parser[G] {
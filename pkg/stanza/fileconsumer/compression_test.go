@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func gzipCompress(t testing.TB, s string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdCompress(t testing.TB, s string) []byte {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// tarArchive returns a tar archive of the given files, as pairs of names and contents
+func tarArchive(t testing.TB, files ...string) string {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for i := 0; i < len(files); i += 2 {
+		require.NoError(t, w.WriteHeader(&tar.Header{Name: files[i], Mode: 0600, Size: int64(len(files[i+1]))}))
+		_, err := w.Write([]byte(files[i+1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.String()
+}
+
+func writeBytes(t testing.TB, file *os.File, b []byte) {
+	_, err := file.Write(b)
+	require.NoError(t, err)
+}
+
+func TestReadCompressedLogs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		compression string
+		content     func(testing.TB, string) []byte
+	}{
+		{"gzip", "gzip", gzipCompress},
+		{"zstd", "zstd", zstdCompress},
+		{"auto_gzip", "auto", gzipCompress},
+		{"auto_zstd", "auto", zstdCompress},
+		{"auto_plain", "auto", func(_ testing.TB, s string) []byte { return []byte(s) }},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = tc.compression
+			operator, emitCalls := buildTestManager(t, cfg)
+
+			temp := openTemp(t, tempDir)
+			writeBytes(t, temp, tc.content(t, "testlog1\ntestlog2\n"))
+
+			require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+		})
+	}
+}
+
+// TestReadCompressedTarArchive tests that the files of a compressed tar archive are read one after the other
+func TestReadCompressedTarArchive(t *testing.T) {
+	testCases := []struct {
+		name        string
+		compression string
+		content     func(testing.TB, string) []byte
+	}{
+		{"gzip", "gzip", gzipCompress},
+		{"auto_zstd", "auto", zstdCompress},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = tc.compression
+			operator, emitCalls := buildTestManager(t, cfg)
+
+			temp := openTemp(t, tempDir)
+			// the first file does not end with a newline
+			archive := tarArchive(t, "app.log", "testlog1\ntestlog2", "app.log.1", "testlog3\n")
+			writeBytes(t, temp, tc.content(t, archive))
+
+			require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2"), []byte("testlog3")})
+			expectNoTokens(t, emitCalls)
+		})
+	}
+}
+
+// TestReadIncompleteCompressedLogs tests that a compressed file is read once it has been fully written
+func TestReadIncompleteCompressedLogs(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = "gzip"
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	content := gzipCompress(t, "testlog1\ntestlog2\n")
+	temp := openTemp(t, tempDir)
+	writeBytes(t, temp, content[:len(content)/2])
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	expectNoTokensUntil(t, emitCalls, 500*time.Millisecond)
+	writeBytes(t, temp, content[len(content)/2:])
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+	expectNoTokens(t, emitCalls)
+}
+
+// TestCompressedRestartOffsets tests that the offset of a compressed file is persisted,
+// and that only the compressed data appended to the file is read after a restart
+func TestCompressedRestartOffsets(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = "gzip"
+
+	persister := testutil.NewMockPersister("test")
+	logFile := openTemp(t, tempDir)
+
+	operatorOne, emitCallsOne := buildTestManager(t, cfg)
+	writeBytes(t, logFile, gzipCompress(t, "testlog1\n"))
+	require.NoError(t, operatorOne.Start(persister))
+	waitForToken(t, emitCallsOne, []byte("testlog1"))
+	require.NoError(t, operatorOne.Stop())
+
+	// gzip readers transparently read concatenated members
+	writeBytes(t, logFile, gzipCompress(t, "testlog2\n"))
+
+	operatorTwo, emitCallsTwo := buildTestManager(t, cfg)
+	require.NoError(t, operatorTwo.Start(persister))
+	waitForToken(t, emitCallsTwo, []byte("testlog2"))
+	expectNoTokens(t, emitCallsTwo)
+	require.NoError(t, operatorTwo.Stop())
+}
+
+// TestDetectCompressionOfGrowingFile tests that the compression of a file is detected once enough
+// bytes have been written to it, even though it only held a prefix of a magic number when it was found
+func TestDetectCompressionOfGrowingFile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  []byte
+		header   int
+		expected [][]byte
+	}{
+		{"gzip", gzipCompress(t, "testlog1\ntestlog2\n"), 10, [][]byte{[]byte("testlog1"), []byte("testlog2")}},
+		// 0x28, the first byte of the zstd magic number, is an opening parenthesis
+		{"plain", []byte("(testlog1)\ntestlog2\n"), 4, [][]byte{[]byte("(testlog1)"), []byte("testlog2")}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = "auto"
+			cfg.PollInterval = 20 * time.Millisecond
+			// partial lines must not be flushed while the file grows
+			cfg.FlushPeriod = time.Minute
+			operator, emitCalls := buildTestManager(t, cfg)
+
+			temp := openTemp(t, tempDir)
+			writeBytes(t, temp, tc.content[:1])
+
+			require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			// the file grows one byte at a time, over several polls, until its header is complete
+			for i := 1; i < tc.header; i++ {
+				expectNoTokensUntil(t, emitCalls, 3*cfg.PollInterval)
+				writeBytes(t, temp, tc.content[i:i+1])
+			}
+			writeBytes(t, temp, tc.content[tc.header:])
+
+			waitForTokens(t, emitCalls, tc.expected)
+		})
+	}
+}
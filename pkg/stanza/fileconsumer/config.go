@@ -85,6 +85,7 @@ type Config struct {
 	Encoding                string          `mapstructure:"encoding,omitempty"`
 	FlushPeriod             time.Duration   `mapstructure:"force_flush_period,omitempty"`
 	Header                  *HeaderConfig   `mapstructure:"header,omitempty"`
+	Compression             string          `mapstructure:"compression,omitempty"`
 }
 
 type HeaderConfig struct {
@@ -175,6 +176,7 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit emit.Callback, fact
 				IncludeFileNameResolved: c.IncludeFileNameResolved,
 				IncludeFilePathResolved: c.IncludeFilePathResolved,
 				DeleteAtEOF:             c.DeleteAfterRead,
+				Compression:             c.Compression,
 			},
 			FromBeginning:   startAtBeginning,
 			SplitterFactory: factory,
@@ -228,6 +230,16 @@ func (c Config) validate() error {
 		return errors.New("`max_batches` must not be negative")
 	}
 
+	switch c.Compression {
+	case "", reader.CompressionGzip, reader.CompressionZstd, reader.CompressionAuto:
+	default:
+		return fmt.Errorf("invalid `compression` '%s'", c.Compression)
+	}
+
+	if c.Header != nil && c.Compression != "" {
+		return fmt.Errorf("`header` cannot be used with `compression`")
+	}
+
 	enc, err := decode.LookupEncoding(c.Encoding)
 	if err != nil {
 		return err
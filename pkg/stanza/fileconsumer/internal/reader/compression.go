@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/scanner"
+)
+
+const (
+	// CompressionGzip decompresses every file with gzip.
+	CompressionGzip = "gzip"
+	// CompressionZstd decompresses every file with zstd.
+	CompressionZstd = "zstd"
+	// CompressionAuto detects the compression of each file from its magic number.
+	// Files that are not compressed are read as plain text.
+	CompressionAuto = "auto"
+)
+
+var magicNumbers = []struct {
+	compression string
+	magic       []byte
+}{
+	{compression: CompressionGzip, magic: []byte{0x1f, 0x8b}},
+	{compression: CompressionZstd, magic: []byte{0x28, 0xb5, 0x2f, 0xfd}},
+}
+
+// magicLength is the number of bytes needed to tell every magic number apart.
+var magicLength = func() int {
+	n := 0
+	for _, m := range magicNumbers {
+		if len(m.magic) > n {
+			n = len(m.magic)
+		}
+	}
+	return n
+}()
+
+// compression returns the compression of the file, or an empty string if the file is not compressed.
+// False is returned if too few bytes have been written to the file to detect its compression.
+func (r *Reader) compression() (string, bool) {
+	if r.Compression != CompressionAuto {
+		return r.Compression, true
+	}
+
+	// The fingerprint holds the first bytes of the file as they were when it was taken, and is carried
+	// over from previous polls, so the file is read again while the fingerprint is too short to hold
+	// the magic numbers.
+	head := r.Fingerprint.FirstBytes
+	if len(head) < magicLength && r.file != nil {
+		buf := make([]byte, magicLength)
+		n, err := r.file.ReadAt(buf, 0)
+		if err != nil && !errors.Is(err, io.EOF) {
+			r.logger.Errorw("Failed to read the beginning of the file", zap.Error(err))
+			return "", false
+		}
+		head = buf[:n]
+	}
+
+	for _, m := range magicNumbers {
+		if bytes.HasPrefix(head, m.magic) {
+			return m.compression, true
+		}
+		if bytes.HasPrefix(m.magic, head) {
+			return "", false
+		}
+	}
+	return "", true
+}
+
+func newDecompressor(compression string, src io.Reader) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		gr, err := gzip.NewReader(src)
+		if err != nil {
+			return nil, err
+		}
+		return gr, nil
+	case CompressionZstd:
+		zr, err := zstd.NewReader(src, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+}
+
+// decompressor remembers the first error returned by the decompressed stream, other than io.EOF.
+type decompressor struct {
+	io.ReadCloser
+	err error
+}
+
+func (d *decompressor) Read(p []byte) (int, error) {
+	n, err := d.ReadCloser.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) && d.err == nil {
+		d.err = err
+	}
+	return n, err
+}
+
+const (
+	// tarBlockSize is the size of the blocks of a tar archive, and of its headers.
+	tarBlockSize = 512
+	// tarMagicOffset is the position of the magic number in a tar header.
+	tarMagicOffset = 257
+)
+
+// tarMagic starts the magic number of both POSIX and GNU tar headers.
+var tarMagic = []byte("ustar")
+
+// untar returns the content of the regular files of the tar archive read from src, one after the other,
+// or the decompressed stream itself if it is not a tar archive.
+func untar(src io.Reader) (io.Reader, error) {
+	br := bufio.NewReaderSize(src, tarBlockSize)
+	head, err := br.Peek(tarBlockSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(head) < tarBlockSize || !bytes.HasPrefix(head[tarMagicOffset:], tarMagic) {
+		return br, nil
+	}
+	return &tarEntries{src: br, tr: tar.NewReader(br)}, nil
+}
+
+// tarEntries reads the content of the regular files of a tar archive. A newline is added to the content
+// of a file that does not end with one, so that its last log entry is not joined with the next file.
+type tarEntries struct {
+	src     io.Reader
+	tr      *tar.Reader
+	inEntry bool
+	last    byte
+}
+
+func (t *tarEntries) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for {
+		if !t.inEntry {
+			hdr, err := t.tr.Next()
+			if errors.Is(err, io.EOF) {
+				// The padding after the end of the archive is read too, so that the whole stream is checked.
+				if _, err = io.Copy(io.Discard, t.src); err != nil {
+					return 0, err
+				}
+				return 0, io.EOF
+			}
+			if err != nil {
+				return 0, err
+			}
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+			t.inEntry = true
+			t.last = '\n'
+		}
+
+		n, err := t.tr.Read(p)
+		if n > 0 {
+			t.last = p[n-1]
+			return n, nil
+		}
+		if !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+		t.inEntry = false
+		if t.last != '\n' {
+			p[0] = '\n'
+			t.last = '\n'
+			return 1, nil
+		}
+	}
+}
+
+// readCompressedToEnd decompresses the file from the current offset to its end and emits the decompressed tokens.
+//
+// Compressed streams cannot be resumed from an arbitrary position: the offset is the position in the
+// compressed file where the stream being read starts, and DecompressedOffset is the number of decompressed
+// bytes of that stream that have already been emitted. Tar archives are unpacked, and DecompressedOffset is
+// then the position in the content of their files. The offset is moved to the end of the file once the
+// whole stream has been read, so that compressed data appended later on, e.g. another gzip member, is read
+// from there. A stream that is still being written is read again on the next poll, skipping the tokens
+// that have already been emitted.
+func (r *Reader) readCompressedToEnd(ctx context.Context, compression string) {
+	info, err := r.file.Stat()
+	if err != nil {
+		r.logger.Errorw("Failed to stat", zap.Error(err))
+		return
+	}
+	end := info.Size()
+	if r.Offset >= end {
+		return
+	}
+
+	src, err := newDecompressor(compression, io.NewSectionReader(r.file, r.Offset, end-r.Offset))
+	if err != nil {
+		r.logIncomplete(err)
+		return
+	}
+	d := &decompressor{ReadCloser: src}
+	defer func() {
+		if err := d.Close(); err != nil {
+			r.logger.Debugw("Problem closing decompressor", zap.Error(err))
+		}
+		r.decompressed = nil
+	}()
+
+	content, err := untar(d)
+	if err != nil {
+		r.logIncomplete(err)
+		return
+	}
+	r.decompressed = content
+
+	if _, err = io.CopyN(io.Discard, content, r.DecompressedOffset); err != nil {
+		r.logIncomplete(err)
+		return
+	}
+
+	s := scanner.New(r, r.MaxLogSize, scanner.DefaultBufferSize, r.DecompressedOffset, r.splitFunc)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		ok := s.Scan()
+		if d.err != nil {
+			// Tokens scanned after a read error may have been flushed before their end.
+			r.logIncomplete(d.err)
+			return
+		}
+		if !ok {
+			if err := s.Error(); err != nil {
+				r.logger.Errorw("Failed during scan", zap.Error(err))
+				return
+			}
+			break
+		}
+
+		token, err := r.decoder.Decode(s.Bytes())
+		if err != nil {
+			r.logger.Errorw("decode: %w", zap.Error(err))
+		} else if err := r.processFunc(ctx, token, r.FileAttributes); err != nil {
+			r.logger.Errorw("process: %w", zap.Error(err))
+		}
+
+		r.DecompressedOffset = s.Pos()
+	}
+
+	r.Offset = end
+	r.DecompressedOffset = 0
+	// Tokens are not read from the file itself, so the fingerprint is not built while reading.
+	if len(r.Fingerprint.FirstBytes) < r.FingerprintSize {
+		if fp, err := r.NewFingerprintFromFile(); err == nil {
+			r.Fingerprint = fp
+		}
+	}
+	if r.DeleteAtEOF {
+		r.delete()
+	}
+}
+
+func (r *Reader) logIncomplete(err error) {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		r.logger.Debugw("Compressed file is incomplete, it will be read again", zap.Error(err))
+		return
+	}
+	r.logger.Errorw("Failed to decompress", zap.Error(err))
+}
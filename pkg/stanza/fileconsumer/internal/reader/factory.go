@@ -43,10 +43,11 @@ func (f *Factory) Copy(old *Reader, newFile *os.File) (*Reader, error) {
 		lineSplitFunc = f.SplitterFactory.SplitFunc()
 	}
 	return f.build(newFile, &Metadata{
-		Fingerprint:     old.Fingerprint.Copy(),
-		Offset:          old.Offset,
-		FileAttributes:  util.MapCopy(old.FileAttributes),
-		HeaderFinalized: old.HeaderFinalized,
+		Fingerprint:        old.Fingerprint.Copy(),
+		Offset:             old.Offset,
+		FileAttributes:     util.MapCopy(old.FileAttributes),
+		HeaderFinalized:    old.HeaderFinalized,
+		DecompressedOffset: old.DecompressedOffset,
 	}, lineSplitFunc)
 }
 
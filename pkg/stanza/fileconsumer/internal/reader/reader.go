@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -29,6 +30,7 @@ type Config struct {
 	IncludeFileNameResolved bool
 	IncludeFilePathResolved bool
 	DeleteAtEOF             bool
+	Compression             string
 }
 
 type Metadata struct {
@@ -36,6 +38,8 @@ type Metadata struct {
 	Offset          int64
 	FileAttributes  map[string]any
 	HeaderFinalized bool
+	// DecompressedOffset is the position in the decompressed stream starting at Offset of a compressed file.
+	DecompressedOffset int64
 }
 
 // Reader manages a single file
@@ -50,6 +54,8 @@ type Reader struct {
 	decoder       *decode.Decoder
 	headerReader  *header.Reader
 	processFunc   emit.Callback
+	// decompressed is the source of the tokens while a compressed file is read.
+	decompressed io.Reader
 }
 
 // offsetToEnd sets the starting offset
@@ -71,6 +77,15 @@ func (r *Reader) NewFingerprintFromFile() (*fingerprint.Fingerprint, error) {
 
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
+	compression, ok := r.compression()
+	if !ok {
+		return
+	}
+	if compression != "" {
+		r.readCompressedToEnd(ctx, compression)
+		return
+	}
+
 	if _, err := r.file.Seek(r.Offset, 0); err != nil {
 		r.logger.Errorw("Failed to seek", zap.Error(err))
 		return
@@ -161,6 +176,10 @@ func (r *Reader) Close() {
 
 // Read from the file and update the fingerprint if necessary
 func (r *Reader) Read(dst []byte) (int, error) {
+	// The fingerprint of a compressed file is made of compressed bytes
+	if r.decompressed != nil {
+		return r.decompressed.Read(dst)
+	}
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.FingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
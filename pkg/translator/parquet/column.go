@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+// column describes a single column of the schema and how its value is read from a row.
+// A nil value is written as null.
+type column[R any] struct {
+	field arrow.Field
+	value func(R) any
+}
+
+func schemaOf[R any](columns []column[R]) *arrow.Schema {
+	fields := make([]arrow.Field, len(columns))
+	for i, col := range columns {
+		fields[i] = col.field
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+func field(name string, dataType arrow.DataType) arrow.Field {
+	return arrow.Field{Name: name, Type: dataType, Nullable: true}
+}
+
+var (
+	stringType      = arrow.BinaryTypes.String
+	int32Type       = arrow.PrimitiveTypes.Int32
+	int64Type       = arrow.PrimitiveTypes.Int64
+	float64Type     = arrow.PrimitiveTypes.Float64
+	booleanType     = arrow.FixedWidthTypes.Boolean
+	timestampType   = arrow.FixedWidthTypes.Timestamp_ns
+	int64ListType   = arrow.ListOf(arrow.PrimitiveTypes.Int64)
+	float64ListType = arrow.ListOf(arrow.PrimitiveTypes.Float64)
+)
+
+func appendValue(builder array.Builder, value any) error {
+	if value == nil {
+		builder.AppendNull()
+		return nil
+	}
+	switch b := builder.(type) {
+	case *array.StringBuilder:
+		if v, ok := value.(string); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.Int32Builder:
+		if v, ok := value.(int32); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.Int64Builder:
+		if v, ok := value.(int64); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.Float64Builder:
+		if v, ok := value.(float64); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.BooleanBuilder:
+		if v, ok := value.(bool); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.TimestampBuilder:
+		if v, ok := value.(arrow.Timestamp); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.ListBuilder:
+		switch v := value.(type) {
+		case []int64:
+			b.Append(true)
+			b.ValueBuilder().(*array.Int64Builder).AppendValues(v, nil)
+			return nil
+		case []float64:
+			b.Append(true)
+			b.ValueBuilder().(*array.Float64Builder).AppendValues(v, nil)
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot write %T to a %s column", value, builder.Type())
+}
+
+// resourceScope holds the resource and instrumentation scope shared by every row of a batch.
+type resourceScope struct {
+	resource pcommon.Resource
+	scope    pcommon.InstrumentationScope
+}
+
+func resourceColumns[R any](get func(R) resourceScope) []column[R] {
+	return []column[R]{
+		{field("service_name", stringType), func(r R) any {
+			if name, ok := get(r).resource.Attributes().Get(conventions.AttributeServiceName); ok {
+				return name.AsString()
+			}
+			return nil
+		}},
+		{field("resource_attributes", stringType), func(r R) any { return attributesJSON(get(r).resource.Attributes()) }},
+		{field("resource_dropped_attributes_count", int64Type), func(r R) any {
+			return int64(get(r).resource.DroppedAttributesCount())
+		}},
+		{field("scope_name", stringType), func(r R) any { return get(r).scope.Name() }},
+		{field("scope_version", stringType), func(r R) any { return get(r).scope.Version() }},
+		{field("scope_attributes", stringType), func(r R) any { return attributesJSON(get(r).scope.Attributes()) }},
+	}
+}
+
+// attributesJSON encodes attributes as a JSON object so that they can be queried with the JSON
+// functions of the query engine without requiring a schema per attribute key.
+func attributesJSON(attributes pcommon.Map) any {
+	return toJSON(attributes.AsRaw())
+}
+
+func toJSON(v any) any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return string(b)
+}
+
+func timestamp(ts pcommon.Timestamp) any {
+	if ts == 0 {
+		return nil
+	}
+	return arrow.Timestamp(ts)
+}
+
+func traceID(id pcommon.TraceID) any {
+	if id.IsEmpty() {
+		return nil
+	}
+	return hex.EncodeToString(id[:])
+}
+
+func spanID(id pcommon.SpanID) any {
+	if id.IsEmpty() {
+		return nil
+	}
+	return hex.EncodeToString(id[:])
+}
+
+func optionalString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func uint64sToInt64s(s pcommon.UInt64Slice) []int64 {
+	out := make([]int64, s.Len())
+	for i := range out {
+		out[i] = int64(s.At(i))
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type logRow struct {
+	resourceScope
+	record plog.LogRecord
+}
+
+func logRows(ld plog.Logs) []logRow {
+	rows := make([]logRow, 0, ld.LogRecordCount())
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				rows = append(rows, logRow{
+					resourceScope: resourceScope{resource: rl.Resource(), scope: sl.Scope()},
+					record:        sl.LogRecords().At(k),
+				})
+			}
+		}
+	}
+	return rows
+}
+
+var logColumns = append(resourceColumns(func(r logRow) resourceScope { return r.resourceScope }), []column[logRow]{
+	{field("timestamp", timestampType), func(r logRow) any { return timestamp(r.record.Timestamp()) }},
+	{field("observed_timestamp", timestampType), func(r logRow) any { return timestamp(r.record.ObservedTimestamp()) }},
+	{field("trace_id", stringType), func(r logRow) any { return traceID(r.record.TraceID()) }},
+	{field("span_id", stringType), func(r logRow) any { return spanID(r.record.SpanID()) }},
+	{field("flags", int64Type), func(r logRow) any { return int64(r.record.Flags()) }},
+	{field("severity_number", int32Type), func(r logRow) any { return int32(r.record.SeverityNumber()) }},
+	{field("severity_text", stringType), func(r logRow) any { return optionalString(r.record.SeverityText()) }},
+	{field("body", stringType), func(r logRow) any { return bodyString(r.record.Body()) }},
+	{field("attributes", stringType), func(r logRow) any { return attributesJSON(r.record.Attributes()) }},
+	{field("dropped_attributes_count", int64Type), func(r logRow) any { return int64(r.record.DroppedAttributesCount()) }},
+}...)
+
+// bodyString returns string bodies as is and encodes structured bodies as JSON.
+func bodyString(body pcommon.Value) any {
+	if body.Type() == pcommon.ValueTypeEmpty {
+		return nil
+	}
+	return body.AsString()
+}
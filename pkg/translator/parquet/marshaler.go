@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	pq "github.com/apache/arrow/go/v12/parquet"
+	"github.com/apache/arrow/go/v12/parquet/compress"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// defaultRowGroupSize is the maximum number of rows written to a single row group.
+	defaultRowGroupSize = 128 * 1024
+	// defaultCompression is the codec used to compress column chunks.
+	defaultCompression = "snappy"
+)
+
+var codecs = map[string]compress.Compression{
+	"none":   compress.Codecs.Uncompressed,
+	"snappy": compress.Codecs.Snappy,
+	"gzip":   compress.Codecs.Gzip,
+	"zstd":   compress.Codecs.Zstd,
+}
+
+// Config defines how telemetry is laid out in the Parquet files.
+type Config struct {
+	// RowGroupSize is the maximum number of rows in a row group. Larger row groups
+	// compress better, smaller ones allow readers to skip more data.
+	RowGroupSize int64 `mapstructure:"row_group_size"`
+
+	// Compression is the codec used for column chunks: none, snappy, gzip or zstd.
+	Compression string `mapstructure:"compression"`
+}
+
+// DefaultConfig returns the default Parquet settings.
+func DefaultConfig() Config {
+	return Config{
+		RowGroupSize: defaultRowGroupSize,
+		Compression:  defaultCompression,
+	}
+}
+
+// Validate checks if the Parquet configuration is valid.
+func (c Config) Validate() error {
+	if c.RowGroupSize <= 0 {
+		return errors.New("row_group_size must be larger than zero")
+	}
+	if _, ok := codecs[c.Compression]; !ok {
+		return fmt.Errorf("unsupported parquet compression %q", c.Compression)
+	}
+	return nil
+}
+
+// Marshaler encodes traces, logs and metrics as Parquet files. Every call produces a
+// complete file containing one row per span, log record or metric data point.
+type Marshaler struct {
+	properties *pq.WriterProperties
+}
+
+var (
+	_ ptrace.Marshaler  = (*Marshaler)(nil)
+	_ plog.Marshaler    = (*Marshaler)(nil)
+	_ pmetric.Marshaler = (*Marshaler)(nil)
+)
+
+// NewMarshaler returns a Marshaler that writes files according to cfg.
+func NewMarshaler(cfg Config) (*Marshaler, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Marshaler{
+		properties: pq.NewWriterProperties(
+			pq.WithCompression(codecs[cfg.Compression]),
+			pq.WithMaxRowGroupLength(cfg.RowGroupSize),
+		),
+	}, nil
+}
+
+// MarshalTraces writes one row per span.
+func (m *Marshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	return marshalRows(m, spanColumns, spanRows(td))
+}
+
+// MarshalLogs writes one row per log record.
+func (m *Marshaler) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	return marshalRows(m, logColumns, logRows(ld))
+}
+
+// MarshalMetrics writes one row per data point. Columns that do not apply to the
+// type of the metric are null.
+func (m *Marshaler) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	return marshalRows(m, dataPointColumns, dataPointRows(md))
+}
+
+func marshalRows[R any](m *Marshaler, columns []column[R], rows []R) ([]byte, error) {
+	schema := schemaOf(columns)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+
+	for _, row := range rows {
+		for i, col := range columns {
+			if err := appendValue(builder.Field(i), col.value(row)); err != nil {
+				return nil, fmt.Errorf("failed to append column %q: %w", col.field.Name, err)
+			}
+		}
+	}
+	record := builder.NewRecord()
+	defer record.Release()
+
+	var buf bytes.Buffer
+	writer, err := pqarrow.NewFileWriter(schema, &buf, m.properties, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return nil, err
+	}
+	if err = writer.Write(record); err != nil {
+		_ = writer.Close()
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
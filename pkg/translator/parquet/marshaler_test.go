@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var testTime = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+// readRecord reads a Parquet file with a single row group back into a record.
+func readRecord(t *testing.T, b []byte) arrow.Record {
+	reader, err := file.NewParquetReader(bytes.NewReader(b))
+	require.NoError(t, err)
+
+	fileReader, err := pqarrow.NewFileReader(reader, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	table, err := fileReader.ReadTable(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(table.Release)
+
+	tableReader := array.NewTableReader(table, table.NumRows())
+	t.Cleanup(tableReader.Release)
+	require.True(t, tableReader.Next())
+	return tableReader.Record()
+}
+
+func columnByName(t *testing.T, record arrow.Record, name string) arrow.Array {
+	indices := record.Schema().FieldIndices(name)
+	require.Len(t, indices, 1, "column %q", name)
+	return record.Column(indices[0])
+}
+
+func Test_Config_Validate(t *testing.T) {
+	assert.NoError(t, DefaultConfig().Validate())
+	assert.EqualError(t, Config{Compression: "snappy"}.Validate(), "row_group_size must be larger than zero")
+	assert.EqualError(t, Config{RowGroupSize: 1, Compression: "lz4"}.Validate(), `unsupported parquet compression "lz4"`)
+
+	_, err := NewMarshaler(Config{})
+	assert.Error(t, err)
+}
+
+func Test_MarshalTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("tracer")
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(testTime))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(testTime.Add(time.Second)))
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Attributes().PutInt("http.status_code", 500)
+	span.Events().AppendEmpty().SetName("exception")
+
+	m, err := NewMarshaler(DefaultConfig())
+	require.NoError(t, err)
+	b, err := m.MarshalTraces(td)
+	require.NoError(t, err)
+
+	record := readRecord(t, b)
+	require.EqualValues(t, 1, record.NumRows())
+	assert.Equal(t, len(spanColumns), int(record.NumCols()))
+
+	assert.Equal(t, "checkout", columnByName(t, record, "service_name").(*array.String).Value(0))
+	assert.Equal(t, "tracer", columnByName(t, record, "scope_name").(*array.String).Value(0))
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", columnByName(t, record, "trace_id").(*array.String).Value(0))
+	assert.Equal(t, "0102030405060708", columnByName(t, record, "span_id").(*array.String).Value(0))
+	assert.True(t, columnByName(t, record, "parent_span_id").IsNull(0))
+	assert.Equal(t, "Server", columnByName(t, record, "kind").(*array.String).Value(0))
+	assert.Equal(t, "Error", columnByName(t, record, "status_code").(*array.String).Value(0))
+	assert.Equal(t, arrow.Timestamp(testTime.UnixNano()), columnByName(t, record, "start_time").(*array.Timestamp).Value(0))
+	assert.Equal(t, time.Second.Nanoseconds(), columnByName(t, record, "duration_ns").(*array.Int64).Value(0))
+	assert.Equal(t, `{"http.status_code":500}`, columnByName(t, record, "attributes").(*array.String).Value(0))
+	assert.JSONEq(t, `[{"time_unix_nano":0,"name":"exception","attributes":{},"dropped_attributes_count":0}]`,
+		columnByName(t, record, "events").(*array.String).Value(0))
+	assert.Equal(t, `[]`, columnByName(t, record, "links").(*array.String).Value(0))
+}
+
+func Test_MarshalLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText("WARN")
+	lr.Body().SetStr("disk almost full")
+	structured := sl.LogRecords().AppendEmpty()
+	structured.Body().SetEmptyMap().PutStr("msg", "hello")
+
+	m, err := NewMarshaler(DefaultConfig())
+	require.NoError(t, err)
+	b, err := m.MarshalLogs(ld)
+	require.NoError(t, err)
+
+	record := readRecord(t, b)
+	require.EqualValues(t, 2, record.NumRows())
+	assert.Equal(t, len(logColumns), int(record.NumCols()))
+
+	assert.True(t, columnByName(t, record, "service_name").IsNull(0))
+	assert.Equal(t, arrow.Timestamp(testTime.UnixNano()), columnByName(t, record, "timestamp").(*array.Timestamp).Value(0))
+	assert.True(t, columnByName(t, record, "timestamp").IsNull(1))
+	assert.Equal(t, int32(plog.SeverityNumberWarn), columnByName(t, record, "severity_number").(*array.Int32).Value(0))
+	assert.Equal(t, "WARN", columnByName(t, record, "severity_text").(*array.String).Value(0))
+	assert.Equal(t, "disk almost full", columnByName(t, record, "body").(*array.String).Value(0))
+	assert.Equal(t, `{"msg":"hello"}`, columnByName(t, record, "body").(*array.String).Value(1))
+	assert.True(t, columnByName(t, record, "trace_id").IsNull(1))
+}
+
+func Test_MarshalMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1.5)
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("sum")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sumDP := sum.Sum().DataPoints().AppendEmpty()
+	sumDP.SetIntValue(42)
+	sumDP.Attributes().PutStr("host", "a")
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("histogram")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	histogramDP := histogram.Histogram().DataPoints().AppendEmpty()
+	histogramDP.SetCount(3)
+	histogramDP.SetSum(6)
+	histogramDP.SetMin(1)
+	histogramDP.BucketCounts().FromRaw([]uint64{1, 2})
+	histogramDP.ExplicitBounds().FromRaw([]float64{1.5})
+
+	expHistogram := metrics.AppendEmpty()
+	expHistogram.SetName("exponential_histogram")
+	expHistogramDP := expHistogram.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	expHistogramDP.SetScale(2)
+	expHistogramDP.SetZeroCount(1)
+	expHistogramDP.Positive().SetOffset(-1)
+	expHistogramDP.Positive().BucketCounts().FromRaw([]uint64{4, 5})
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("summary")
+	summaryDP := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	summaryDP.SetCount(10)
+	summaryDP.SetSum(100)
+	quantile := summaryDP.QuantileValues().AppendEmpty()
+	quantile.SetQuantile(0.99)
+	quantile.SetValue(20)
+
+	m, err := NewMarshaler(DefaultConfig())
+	require.NoError(t, err)
+	b, err := m.MarshalMetrics(md)
+	require.NoError(t, err)
+
+	record := readRecord(t, b)
+	require.EqualValues(t, 5, record.NumRows())
+	assert.Equal(t, len(dataPointColumns), int(record.NumCols()))
+
+	metricType := columnByName(t, record, "metric_type").(*array.String)
+	assert.Equal(t, []string{"Gauge", "Sum", "Histogram", "ExponentialHistogram", "Summary"},
+		[]string{metricType.Value(0), metricType.Value(1), metricType.Value(2), metricType.Value(3), metricType.Value(4)})
+
+	valueDouble := columnByName(t, record, "value_double").(*array.Float64)
+	assert.Equal(t, 1.5, valueDouble.Value(0))
+	assert.True(t, valueDouble.IsNull(1))
+	valueInt := columnByName(t, record, "value_int").(*array.Int64)
+	assert.True(t, valueInt.IsNull(0))
+	assert.Equal(t, int64(42), valueInt.Value(1))
+	assert.Equal(t, `{"host":"a"}`, columnByName(t, record, "attributes").(*array.String).Value(1))
+
+	isMonotonic := columnByName(t, record, "is_monotonic").(*array.Boolean)
+	assert.True(t, isMonotonic.IsNull(0))
+	assert.True(t, isMonotonic.Value(1))
+	temporality := columnByName(t, record, "aggregation_temporality").(*array.String)
+	assert.True(t, temporality.IsNull(0))
+	assert.Equal(t, "Cumulative", temporality.Value(1))
+	assert.Equal(t, "Delta", temporality.Value(2))
+
+	count := columnByName(t, record, "count").(*array.Int64)
+	assert.True(t, count.IsNull(1))
+	assert.Equal(t, int64(3), count.Value(2))
+	assert.Equal(t, int64(10), count.Value(4))
+	assert.Equal(t, 1.0, columnByName(t, record, "min").(*array.Float64).Value(2))
+	assert.True(t, columnByName(t, record, "max").IsNull(2))
+
+	bucketCounts := columnByName(t, record, "bucket_counts").(*array.List)
+	assert.True(t, bucketCounts.IsNull(1))
+	start, end := bucketCounts.ValueOffsets(2)
+	assert.Equal(t, []int64{1, 2}, bucketCounts.ListValues().(*array.Int64).Int64Values()[start:end])
+
+	assert.Equal(t, int32(2), columnByName(t, record, "scale").(*array.Int32).Value(3))
+	assert.Equal(t, int32(-1), columnByName(t, record, "positive_offset").(*array.Int32).Value(3))
+	positive := columnByName(t, record, "positive_bucket_counts").(*array.List)
+	start, end = positive.ValueOffsets(3)
+	assert.Equal(t, []int64{4, 5}, positive.ListValues().(*array.Int64).Int64Values()[start:end])
+
+	quantiles := columnByName(t, record, "quantiles").(*array.List)
+	start, end = quantiles.ValueOffsets(4)
+	assert.Equal(t, []float64{0.99}, quantiles.ListValues().(*array.Float64).Float64Values()[start:end])
+}
+
+func Test_Marshaler_RowGroupSize(t *testing.T) {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 10; i++ {
+		records.AppendEmpty().Body().SetStr("log")
+	}
+
+	cfg := DefaultConfig()
+	cfg.RowGroupSize = 4
+	m, err := NewMarshaler(cfg)
+	require.NoError(t, err)
+	b, err := m.MarshalLogs(ld)
+	require.NoError(t, err)
+
+	reader, err := file.NewParquetReader(bytes.NewReader(b))
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, reader.NumRows())
+	assert.Equal(t, 3, reader.NumRowGroups())
+}
+
+func Test_Marshaler_Empty(t *testing.T) {
+	m, err := NewMarshaler(DefaultConfig())
+	require.NoError(t, err)
+	b, err := m.MarshalTraces(ptrace.NewTraces())
+	require.NoError(t, err)
+
+	reader, err := file.NewParquetReader(bytes.NewReader(b))
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, reader.NumRows())
+	assert.Equal(t, len(spanColumns), reader.MetaData().Schema.NumColumns())
+}
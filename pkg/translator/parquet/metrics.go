@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// dataPointRow is a single data point of a metric. Only the data point matching the type of
+// the metric is set.
+type dataPointRow struct {
+	resourceScope
+	metric       pmetric.Metric
+	number       pmetric.NumberDataPoint
+	histogram    pmetric.HistogramDataPoint
+	expHistogram pmetric.ExponentialHistogramDataPoint
+	summary      pmetric.SummaryDataPoint
+}
+
+func dataPointRows(md pmetric.Metrics) []dataPointRow {
+	rows := make([]dataPointRow, 0, md.DataPointCount())
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				rows = appendDataPointRows(rows, resourceScope{resource: rm.Resource(), scope: sm.Scope()}, sm.Metrics().At(k))
+			}
+		}
+	}
+	return rows
+}
+
+func appendDataPointRows(rows []dataPointRow, rs resourceScope, metric pmetric.Metric) []dataPointRow {
+	row := dataPointRow{resourceScope: rs, metric: metric}
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			row.number = metric.Gauge().DataPoints().At(i)
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			row.number = metric.Sum().DataPoints().At(i)
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			row.histogram = metric.Histogram().DataPoints().At(i)
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			row.expHistogram = metric.ExponentialHistogram().DataPoints().At(i)
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			row.summary = metric.Summary().DataPoints().At(i)
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// dataPoint is implemented by all data point types.
+type dataPoint interface {
+	StartTimestamp() pcommon.Timestamp
+	Timestamp() pcommon.Timestamp
+	Attributes() pcommon.Map
+	Flags() pmetric.DataPointFlags
+}
+
+func (r dataPointRow) point() dataPoint {
+	switch r.metric.Type() {
+	case pmetric.MetricTypeHistogram:
+		return r.histogram
+	case pmetric.MetricTypeExponentialHistogram:
+		return r.expHistogram
+	case pmetric.MetricTypeSummary:
+		return r.summary
+	default:
+		return r.number
+	}
+}
+
+func (r dataPointRow) temporality() any {
+	switch r.metric.Type() {
+	case pmetric.MetricTypeSum:
+		return r.metric.Sum().AggregationTemporality().String()
+	case pmetric.MetricTypeHistogram:
+		return r.metric.Histogram().AggregationTemporality().String()
+	case pmetric.MetricTypeExponentialHistogram:
+		return r.metric.ExponentialHistogram().AggregationTemporality().String()
+	}
+	return nil
+}
+
+func (r dataPointRow) count() any {
+	switch r.metric.Type() {
+	case pmetric.MetricTypeHistogram:
+		return int64(r.histogram.Count())
+	case pmetric.MetricTypeExponentialHistogram:
+		return int64(r.expHistogram.Count())
+	case pmetric.MetricTypeSummary:
+		return int64(r.summary.Count())
+	}
+	return nil
+}
+
+func (r dataPointRow) sum() any {
+	switch r.metric.Type() {
+	case pmetric.MetricTypeHistogram:
+		if r.histogram.HasSum() {
+			return r.histogram.Sum()
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		if r.expHistogram.HasSum() {
+			return r.expHistogram.Sum()
+		}
+	case pmetric.MetricTypeSummary:
+		return r.summary.Sum()
+	}
+	return nil
+}
+
+func (r dataPointRow) minValue() any {
+	switch r.metric.Type() {
+	case pmetric.MetricTypeHistogram:
+		if r.histogram.HasMin() {
+			return r.histogram.Min()
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		if r.expHistogram.HasMin() {
+			return r.expHistogram.Min()
+		}
+	}
+	return nil
+}
+
+func (r dataPointRow) maxValue() any {
+	switch r.metric.Type() {
+	case pmetric.MetricTypeHistogram:
+		if r.histogram.HasMax() {
+			return r.histogram.Max()
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		if r.expHistogram.HasMax() {
+			return r.expHistogram.Max()
+		}
+	}
+	return nil
+}
+
+func (r dataPointRow) isNumber() bool {
+	return r.metric.Type() == pmetric.MetricTypeGauge || r.metric.Type() == pmetric.MetricTypeSum
+}
+
+func (r dataPointRow) isHistogram() bool {
+	return r.metric.Type() == pmetric.MetricTypeHistogram
+}
+
+func (r dataPointRow) isExpHistogram() bool {
+	return r.metric.Type() == pmetric.MetricTypeExponentialHistogram
+}
+
+func (r dataPointRow) isSummary() bool {
+	return r.metric.Type() == pmetric.MetricTypeSummary
+}
+
+var dataPointColumns = append(resourceColumns(func(r dataPointRow) resourceScope { return r.resourceScope }), []column[dataPointRow]{
+	{field("metric_name", stringType), func(r dataPointRow) any { return r.metric.Name() }},
+	{field("metric_description", stringType), func(r dataPointRow) any { return optionalString(r.metric.Description()) }},
+	{field("metric_unit", stringType), func(r dataPointRow) any { return optionalString(r.metric.Unit()) }},
+	{field("metric_type", stringType), func(r dataPointRow) any { return r.metric.Type().String() }},
+	{field("aggregation_temporality", stringType), dataPointRow.temporality},
+	{field("is_monotonic", booleanType), func(r dataPointRow) any {
+		if r.metric.Type() != pmetric.MetricTypeSum {
+			return nil
+		}
+		return r.metric.Sum().IsMonotonic()
+	}},
+	{field("start_time", timestampType), func(r dataPointRow) any { return timestamp(r.point().StartTimestamp()) }},
+	{field("time", timestampType), func(r dataPointRow) any { return timestamp(r.point().Timestamp()) }},
+	{field("attributes", stringType), func(r dataPointRow) any { return attributesJSON(r.point().Attributes()) }},
+	{field("flags", int64Type), func(r dataPointRow) any { return int64(r.point().Flags()) }},
+
+	// Gauge and Sum
+	{field("value_double", float64Type), func(r dataPointRow) any {
+		if !r.isNumber() || r.number.ValueType() != pmetric.NumberDataPointValueTypeDouble {
+			return nil
+		}
+		return r.number.DoubleValue()
+	}},
+	{field("value_int", int64Type), func(r dataPointRow) any {
+		if !r.isNumber() || r.number.ValueType() != pmetric.NumberDataPointValueTypeInt {
+			return nil
+		}
+		return r.number.IntValue()
+	}},
+
+	// Histogram, ExponentialHistogram and Summary
+	{field("count", int64Type), dataPointRow.count},
+	{field("sum", float64Type), dataPointRow.sum},
+	{field("min", float64Type), dataPointRow.minValue},
+	{field("max", float64Type), dataPointRow.maxValue},
+
+	// Histogram
+	{field("bucket_counts", int64ListType), func(r dataPointRow) any {
+		if !r.isHistogram() {
+			return nil
+		}
+		return uint64sToInt64s(r.histogram.BucketCounts())
+	}},
+	{field("explicit_bounds", float64ListType), func(r dataPointRow) any {
+		if !r.isHistogram() {
+			return nil
+		}
+		return r.histogram.ExplicitBounds().AsRaw()
+	}},
+
+	// ExponentialHistogram
+	{field("scale", int32Type), func(r dataPointRow) any {
+		if !r.isExpHistogram() {
+			return nil
+		}
+		return r.expHistogram.Scale()
+	}},
+	{field("zero_count", int64Type), func(r dataPointRow) any {
+		if !r.isExpHistogram() {
+			return nil
+		}
+		return int64(r.expHistogram.ZeroCount())
+	}},
+	{field("positive_offset", int32Type), func(r dataPointRow) any {
+		if !r.isExpHistogram() {
+			return nil
+		}
+		return r.expHistogram.Positive().Offset()
+	}},
+	{field("positive_bucket_counts", int64ListType), func(r dataPointRow) any {
+		if !r.isExpHistogram() {
+			return nil
+		}
+		return uint64sToInt64s(r.expHistogram.Positive().BucketCounts())
+	}},
+	{field("negative_offset", int32Type), func(r dataPointRow) any {
+		if !r.isExpHistogram() {
+			return nil
+		}
+		return r.expHistogram.Negative().Offset()
+	}},
+	{field("negative_bucket_counts", int64ListType), func(r dataPointRow) any {
+		if !r.isExpHistogram() {
+			return nil
+		}
+		return uint64sToInt64s(r.expHistogram.Negative().BucketCounts())
+	}},
+
+	// Summary
+	{field("quantiles", float64ListType), func(r dataPointRow) any {
+		if !r.isSummary() {
+			return nil
+		}
+		quantiles := make([]float64, r.summary.QuantileValues().Len())
+		for i := range quantiles {
+			quantiles[i] = r.summary.QuantileValues().At(i).Quantile()
+		}
+		return quantiles
+	}},
+	{field("quantile_values", float64ListType), func(r dataPointRow) any {
+		if !r.isSummary() {
+			return nil
+		}
+		values := make([]float64, r.summary.QuantileValues().Len())
+		for i := range values {
+			values[i] = r.summary.QuantileValues().At(i).Value()
+		}
+		return values
+	}},
+}...)
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
+
+import (
+	"encoding/hex"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type spanRow struct {
+	resourceScope
+	span ptrace.Span
+}
+
+func spanRows(td ptrace.Traces) []spanRow {
+	rows := make([]spanRow, 0, td.SpanCount())
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				rows = append(rows, spanRow{
+					resourceScope: resourceScope{resource: rs.Resource(), scope: ss.Scope()},
+					span:          ss.Spans().At(k),
+				})
+			}
+		}
+	}
+	return rows
+}
+
+var spanColumns = append(resourceColumns(func(r spanRow) resourceScope { return r.resourceScope }), []column[spanRow]{
+	{field("trace_id", stringType), func(r spanRow) any { return traceID(r.span.TraceID()) }},
+	{field("span_id", stringType), func(r spanRow) any { return spanID(r.span.SpanID()) }},
+	{field("parent_span_id", stringType), func(r spanRow) any { return spanID(r.span.ParentSpanID()) }},
+	{field("trace_state", stringType), func(r spanRow) any { return optionalString(r.span.TraceState().AsRaw()) }},
+	{field("name", stringType), func(r spanRow) any { return r.span.Name() }},
+	{field("kind", stringType), func(r spanRow) any { return r.span.Kind().String() }},
+	{field("start_time", timestampType), func(r spanRow) any { return timestamp(r.span.StartTimestamp()) }},
+	{field("end_time", timestampType), func(r spanRow) any { return timestamp(r.span.EndTimestamp()) }},
+	{field("duration_ns", int64Type), func(r spanRow) any {
+		return int64(r.span.EndTimestamp()) - int64(r.span.StartTimestamp())
+	}},
+	{field("status_code", stringType), func(r spanRow) any { return r.span.Status().Code().String() }},
+	{field("status_message", stringType), func(r spanRow) any { return optionalString(r.span.Status().Message()) }},
+	{field("attributes", stringType), func(r spanRow) any { return attributesJSON(r.span.Attributes()) }},
+	{field("dropped_attributes_count", int64Type), func(r spanRow) any { return int64(r.span.DroppedAttributesCount()) }},
+	{field("events", stringType), func(r spanRow) any { return eventsJSON(r.span.Events()) }},
+	{field("dropped_events_count", int64Type), func(r spanRow) any { return int64(r.span.DroppedEventsCount()) }},
+	{field("links", stringType), func(r spanRow) any { return linksJSON(r.span.Links()) }},
+	{field("dropped_links_count", int64Type), func(r spanRow) any { return int64(r.span.DroppedLinksCount()) }},
+}...)
+
+func eventsJSON(events ptrace.SpanEventSlice) any {
+	out := make([]map[string]any, events.Len())
+	for i := range out {
+		event := events.At(i)
+		out[i] = map[string]any{
+			"time_unix_nano":           uint64(event.Timestamp()),
+			"name":                     event.Name(),
+			"attributes":               event.Attributes().AsRaw(),
+			"dropped_attributes_count": event.DroppedAttributesCount(),
+		}
+	}
+	return toJSON(out)
+}
+
+func linksJSON(links ptrace.SpanLinkSlice) any {
+	out := make([]map[string]any, links.Len())
+	for i := range out {
+		link := links.At(i)
+		tid, sid := link.TraceID(), link.SpanID()
+		out[i] = map[string]any{
+			"trace_id":                 hex.EncodeToString(tid[:]),
+			"span_id":                  hex.EncodeToString(sid[:]),
+			"trace_state":              link.TraceState().AsRaw(),
+			"attributes":               link.Attributes().AsRaw(),
+			"dropped_attributes_count": link.DroppedAttributesCount(),
+		}
+	}
+	return toJSON(out)
+}
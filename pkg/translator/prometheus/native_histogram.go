@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// BucketSpan is a span of consecutive buckets of a Prometheus native histogram.
+// Offset is the gap to the previous span, or the index of the first bucket for the first span.
+type BucketSpan struct {
+	Offset int32
+	Length uint32
+}
+
+// ConvertNativeHistogramBuckets copies the sparse buckets of a Prometheus native histogram into the
+// dense buckets of an OpenTelemetry exponential histogram, filling the gaps between spans with empty buckets.
+// Prometheus bucket i covers (base^(i-1), base^i] while OTLP bucket i covers (base^i, base^(i+1)],
+// hence the offset is shifted by one.
+func ConvertNativeHistogramBuckets(spans []BucketSpan, counts []uint64, dest pmetric.ExponentialHistogramDataPointBuckets) {
+	if len(spans) == 0 {
+		return
+	}
+	dest.SetOffset(spans[0].Offset - 1)
+
+	bucketCounts := dest.BucketCounts()
+	bucketCounts.EnsureCapacity(len(counts))
+	idx := 0
+	for i, span := range spans {
+		if i > 0 {
+			for j := int32(0); j < span.Offset; j++ {
+				bucketCounts.Append(0)
+			}
+		}
+		for j := uint32(0); j < span.Length && idx < len(counts); j++ {
+			bucketCounts.Append(counts[idx])
+			idx++
+		}
+	}
+}
+
+// DeltaBucketCounts returns the absolute counts of the delta encoded buckets of an integer native histogram.
+func DeltaBucketCounts(deltas []int64) []uint64 {
+	counts := make([]uint64, len(deltas))
+	var count int64
+	for i, delta := range deltas {
+		count += delta
+		counts[i] = uint64(count)
+	}
+	return counts
+}
+
+// FloatBucketCounts returns the counts of the buckets of a float native histogram.
+func FloatBucketCounts(buckets []float64) []uint64 {
+	counts := make([]uint64, len(buckets))
+	for i, count := range buckets {
+		counts[i] = uint64(count)
+	}
+	return counts
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestConvertNativeHistogramBuckets(t *testing.T) {
+	tests := []struct {
+		name           string
+		spans          []BucketSpan
+		counts         []uint64
+		expectedOffset int32
+		expectedCounts []uint64
+	}{
+		{
+			name: "no spans",
+		},
+		{
+			name:           "single span",
+			spans:          []BucketSpan{{Offset: 2, Length: 3}},
+			counts:         []uint64{1, 2, 3},
+			expectedOffset: 1,
+			expectedCounts: []uint64{1, 2, 3},
+		},
+		{
+			name:           "gap between spans",
+			spans:          []BucketSpan{{Offset: -1, Length: 2}, {Offset: 2, Length: 1}},
+			counts:         []uint64{1, 2, 3},
+			expectedOffset: -2,
+			expectedCounts: []uint64{1, 2, 0, 0, 3},
+		},
+		{
+			name:           "fewer counts than buckets",
+			spans:          []BucketSpan{{Offset: 0, Length: 3}},
+			counts:         []uint64{4},
+			expectedOffset: -1,
+			expectedCounts: []uint64{4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := pmetric.NewExponentialHistogramDataPointBuckets()
+			ConvertNativeHistogramBuckets(tt.spans, tt.counts, buckets)
+			assert.Equal(t, tt.expectedOffset, buckets.Offset())
+			if tt.expectedCounts == nil {
+				assert.Equal(t, 0, buckets.BucketCounts().Len())
+				return
+			}
+			assert.Equal(t, tt.expectedCounts, buckets.BucketCounts().AsRaw())
+		})
+	}
+}
+
+func TestDeltaBucketCounts(t *testing.T) {
+	assert.Equal(t, []uint64{1, 2, 1, 1}, DeltaBucketCounts([]int64{1, 1, -1, 0}))
+	assert.Empty(t, DeltaBucketCounts(nil))
+}
+
+func TestFloatBucketCounts(t *testing.T) {
+	assert.Equal(t, []uint64{1, 2, 0}, FloatBucketCounts([]float64{1, 2.5, 0}))
+	assert.Empty(t, FloatBucketCounts(nil))
+}
@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+)
+
+const targetInfoHelp = "Target metadata"
+
+// MetadataV2 describes the metric a remote write 2.0 series belongs to.
+type MetadataV2 struct {
+	Type writev2.MetricType
+	Help string
+	Unit string
+}
+
+// TimeSeriesV2 is a series converted for remote write 2.0. Its labels and metadata are interned
+// in the symbols of the request it is sent with, see ToWriteRequestV2.
+type TimeSeriesV2 struct {
+	prompb.TimeSeries
+	Metadata MetadataV2
+	// CreatedTimestamp is the start time in milliseconds of cumulative series, 0 if unknown.
+	CreatedTimestamp int64
+}
+
+// FromMetricsV2 converts pmetric.Metrics to prometheus remote write 2.0 series. Unlike FromMetrics,
+// series carry the type, description and unit of their metric, and the start time of cumulative
+// series is exported as their created timestamp instead of a separate _created series.
+func FromMetricsV2(md pmetric.Metrics, settings Settings) (tsMap map[string]*TimeSeriesV2, errs error) {
+	tsMap = make(map[string]*TimeSeriesV2)
+	settings.ExportCreatedMetric = false
+
+	resourceMetricsSlice := md.ResourceMetrics()
+	for i := 0; i < resourceMetricsSlice.Len(); i++ {
+		resourceMetrics := resourceMetricsSlice.At(i)
+		resource := resourceMetrics.Resource()
+		scopeMetricsSlice := resourceMetrics.ScopeMetrics()
+		// keep track of the most recent timestamp in the ResourceMetrics for
+		// use with the "target" info metric
+		var mostRecentTimestamp pcommon.Timestamp
+		for j := 0; j < scopeMetricsSlice.Len(); j++ {
+			metricSlice := scopeMetricsSlice.At(j).Metrics()
+
+			for k := 0; k < metricSlice.Len(); k++ {
+				metric := metricSlice.At(k)
+				mostRecentTimestamp = maxTimestamp(mostRecentTimestamp, mostRecentTimestampInMetric(metric))
+
+				if !isValidAggregationTemporality(metric) {
+					errs = multierr.Append(errs, fmt.Errorf("invalid temporality and type combination for metric %q", metric.Name()))
+					continue
+				}
+
+				metadata := metadataV2(metric)
+				// Each data point is converted on its own, so that the series it produces get its start time.
+				addDataPoint := func(startTimestamp pcommon.Timestamp, convert func(map[string]*prompb.TimeSeries) error) error {
+					converted := make(map[string]*prompb.TimeSeries)
+					err := convert(converted)
+					addSeriesV2(tsMap, converted, metadata, startTimestamp)
+					return err
+				}
+
+				// handle individual metric based on type
+				//exhaustive:enforce
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					dataPoints := metric.Gauge().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						pt := dataPoints.At(x)
+						_ = addDataPoint(0, func(series map[string]*prompb.TimeSeries) error {
+							addSingleGaugeNumberDataPoint(pt, resource, metric, settings, series)
+							return nil
+						})
+					}
+				case pmetric.MetricTypeSum:
+					dataPoints := metric.Sum().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						pt := dataPoints.At(x)
+						var startTimestamp pcommon.Timestamp
+						if metric.Sum().IsMonotonic() {
+							startTimestamp = pt.StartTimestamp()
+						}
+						_ = addDataPoint(startTimestamp, func(series map[string]*prompb.TimeSeries) error {
+							addSingleSumNumberDataPoint(pt, resource, metric, settings, series)
+							return nil
+						})
+					}
+				case pmetric.MetricTypeHistogram:
+					dataPoints := metric.Histogram().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						pt := dataPoints.At(x)
+						_ = addDataPoint(pt.StartTimestamp(), func(series map[string]*prompb.TimeSeries) error {
+							addSingleHistogramDataPoint(pt, resource, metric, settings, series)
+							return nil
+						})
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dataPoints := metric.ExponentialHistogram().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					name := prometheustranslator.BuildCompliantName(metric, settings.Namespace, settings.AddMetricSuffixes)
+					for x := 0; x < dataPoints.Len(); x++ {
+						pt := dataPoints.At(x)
+						errs = multierr.Append(errs, addDataPoint(pt.StartTimestamp(), func(series map[string]*prompb.TimeSeries) error {
+							return addSingleExponentialHistogramDataPoint(name, pt, resource, settings, series)
+						}))
+					}
+				case pmetric.MetricTypeSummary:
+					dataPoints := metric.Summary().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						pt := dataPoints.At(x)
+						_ = addDataPoint(pt.StartTimestamp(), func(series map[string]*prompb.TimeSeries) error {
+							addSingleSummaryDataPoint(pt, resource, metric, settings, series)
+							return nil
+						})
+					}
+				default:
+					errs = multierr.Append(errs, errors.New("unsupported metric type"))
+				}
+			}
+		}
+
+		targetInfo := make(map[string]*prompb.TimeSeries)
+		addResourceTargetInfo(resource, settings, mostRecentTimestamp, targetInfo)
+		addSeriesV2(tsMap, targetInfo, MetadataV2{Type: writev2.MetricTypeGauge, Help: targetInfoHelp}, 0)
+	}
+
+	return
+}
+
+// metadataV2 returns the remote write 2.0 metadata of the series of metric.
+func metadataV2(metric pmetric.Metric) MetadataV2 {
+	metadata := MetadataV2{
+		Help: metric.Description(),
+		Unit: metric.Unit(),
+	}
+	//exhaustive:enforce
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		metadata.Type = writev2.MetricTypeGauge
+	case pmetric.MetricTypeSum:
+		metadata.Type = writev2.MetricTypeGauge
+		if metric.Sum().IsMonotonic() {
+			metadata.Type = writev2.MetricTypeCounter
+		}
+	case pmetric.MetricTypeHistogram, pmetric.MetricTypeExponentialHistogram:
+		metadata.Type = writev2.MetricTypeHistogram
+	case pmetric.MetricTypeSummary:
+		metadata.Type = writev2.MetricTypeSummary
+	case pmetric.MetricTypeEmpty:
+		metadata.Type = writev2.MetricTypeUnspecified
+	}
+	return metadata
+}
+
+// addSeriesV2 merges the series converted from a data point into tsMap.
+func addSeriesV2(tsMap map[string]*TimeSeriesV2, converted map[string]*prompb.TimeSeries, metadata MetadataV2, startTimestamp pcommon.Timestamp) {
+	var createdTimestamp int64
+	if startTimestamp != 0 {
+		createdTimestamp = convertTimeStamp(startTimestamp)
+	}
+	for sig, ts := range converted {
+		existing, ok := tsMap[sig]
+		if !ok {
+			tsMap[sig] = &TimeSeriesV2{
+				TimeSeries:       *ts,
+				Metadata:         metadata,
+				CreatedTimestamp: createdTimestamp,
+			}
+			continue
+		}
+		existing.Samples = append(existing.Samples, ts.Samples...)
+		existing.Histograms = append(existing.Histograms, ts.Histograms...)
+		existing.Exemplars = append(existing.Exemplars, ts.Exemplars...)
+		if createdTimestamp > existing.CreatedTimestamp {
+			existing.CreatedTimestamp = createdTimestamp
+		}
+	}
+}
+
+// ToWriteRequestV2 interns the labels and metadata of the series in the symbols of a remote write 2.0 request.
+func ToWriteRequestV2(series []*TimeSeriesV2) *writev2.Request {
+	symbols := writev2.NewSymbolTable()
+	request := &writev2.Request{
+		Timeseries: make([]writev2.TimeSeries, 0, len(series)),
+	}
+	for _, ts := range series {
+		converted := writev2.TimeSeries{
+			LabelsRefs: symbols.SymbolizeLabels(ts.Labels, nil),
+			Samples:    make([]writev2.Sample, 0, len(ts.Samples)),
+			Histograms: ts.Histograms,
+			Metadata: writev2.Metadata{
+				Type:    ts.Metadata.Type,
+				HelpRef: symbols.Symbolize(ts.Metadata.Help),
+				UnitRef: symbols.Symbolize(ts.Metadata.Unit),
+			},
+			CreatedTimestamp: ts.CreatedTimestamp,
+		}
+		for _, sample := range ts.Samples {
+			converted.Samples = append(converted.Samples, writev2.Sample{Value: sample.Value, Timestamp: sample.Timestamp})
+		}
+		for _, exemplar := range ts.Exemplars {
+			converted.Exemplars = append(converted.Exemplars, writev2.Exemplar{
+				LabelsRefs: symbols.SymbolizeLabels(exemplar.Labels, nil),
+				Value:      exemplar.Value,
+				Timestamp:  exemplar.Timestamp,
+			})
+		}
+		request.Timeseries = append(request.Timeseries, converted)
+	}
+	request.Symbols = symbols.Symbols()
+	return request
+}
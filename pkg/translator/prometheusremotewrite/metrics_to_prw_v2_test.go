@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+)
+
+func TestFromMetricsV2(t *testing.T) {
+	start := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	ts := start.Add(time.Minute)
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "api")
+	rm.Resource().Attributes().PutStr("host.name", "host-1")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	counter := metrics.AppendEmpty()
+	counter.SetName("http.requests")
+	counter.SetDescription("Number of HTTP requests")
+	counter.SetUnit("1")
+	counter.SetEmptySum().SetIsMonotonic(true)
+	counter.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := counter.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	dp.SetIntValue(42)
+	exemplar := dp.Exemplars().AppendEmpty()
+	exemplar.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	exemplar.SetDoubleValue(1)
+	exemplar.SetTraceID(pcommon.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10})
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("memory.usage")
+	gauge.SetUnit("By")
+	gdp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	gdp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	gdp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	gdp.SetDoubleValue(1024)
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	hdp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	hdp.SetCount(2)
+	hdp.SetSum(3)
+	hdp.ExplicitBounds().FromRaw([]float64{1})
+	hdp.BucketCounts().FromRaw([]uint64{1, 1})
+
+	tsMap, err := FromMetricsV2(md, Settings{ExportCreatedMetric: true})
+	require.NoError(t, err)
+
+	byName := make(map[string][]*TimeSeriesV2)
+	for _, series := range tsMap {
+		for _, l := range series.Labels {
+			if l.Name == nameStr {
+				byName[l.Value] = append(byName[l.Value], series)
+			}
+		}
+	}
+	assert.NotContains(t, byName, "http_requests_created", "created timestamps replace _created series")
+
+	require.Len(t, byName["http_requests"], 1)
+	requests := byName["http_requests"][0]
+	assert.Equal(t, MetadataV2{Type: writev2.MetricTypeCounter, Help: "Number of HTTP requests", Unit: "1"}, requests.Metadata)
+	assert.Equal(t, start.UnixMilli(), requests.CreatedTimestamp)
+	assert.Equal(t, []prompb.Sample{{Value: 42, Timestamp: ts.UnixMilli()}}, requests.Samples)
+	assert.Len(t, requests.Exemplars, 1)
+
+	require.Len(t, byName["memory_usage"], 1)
+	memory := byName["memory_usage"][0]
+	assert.Equal(t, MetadataV2{Type: writev2.MetricTypeGauge, Unit: "By"}, memory.Metadata)
+	assert.Zero(t, memory.CreatedTimestamp, "gauges have no created timestamp")
+
+	for _, name := range []string{"latency_bucket", "latency_sum", "latency_count"} {
+		for _, series := range byName[name] {
+			assert.Equal(t, writev2.MetricTypeHistogram, series.Metadata.Type)
+			assert.Equal(t, start.UnixMilli(), series.CreatedTimestamp)
+		}
+	}
+	assert.Len(t, byName["latency_bucket"], 2)
+
+	require.Len(t, byName["target_info"], 1)
+	assert.Equal(t, writev2.MetricTypeGauge, byName["target_info"][0].Metadata.Type)
+}
+
+func TestToWriteRequestV2(t *testing.T) {
+	series := []*TimeSeriesV2{
+		{
+			TimeSeries: prompb.TimeSeries{
+				Labels:    getPromLabels(nameStr, "http_requests", "job", "api"),
+				Samples:   []prompb.Sample{getSample(42, 1000)},
+				Exemplars: []prompb.Exemplar{getExemplar(1, 900)},
+			},
+			Metadata:         MetadataV2{Type: writev2.MetricTypeCounter, Help: "Number of HTTP requests"},
+			CreatedTimestamp: 500,
+		},
+		{
+			TimeSeries: prompb.TimeSeries{
+				Labels:  getPromLabels(nameStr, "memory_usage", "job", "api"),
+				Samples: []prompb.Sample{getSample(1024, 1000)},
+			},
+			Metadata: MetadataV2{Type: writev2.MetricTypeGauge, Unit: "By"},
+		},
+	}
+
+	request := ToWriteRequestV2(series)
+
+	assert.Equal(t, []string{"", nameStr, "http_requests", "job", "api", "Number of HTTP requests", traceIDKey, traceIDValue1, "memory_usage", "By"}, request.Symbols)
+	require.Len(t, request.Timeseries, 2)
+	assert.Equal(t, writev2.TimeSeries{
+		LabelsRefs:       []uint32{1, 2, 3, 4},
+		Samples:          []writev2.Sample{{Value: 42, Timestamp: 1000}},
+		Exemplars:        []writev2.Exemplar{{LabelsRefs: []uint32{6, 7}, Value: 1, Timestamp: 900}},
+		Metadata:         writev2.Metadata{Type: writev2.MetricTypeCounter, HelpRef: 5},
+		CreatedTimestamp: 500,
+	}, request.Timeseries[0])
+	assert.Equal(t, []uint32{1, 8, 3, 4}, request.Timeseries[1].LabelsRefs)
+	assert.Equal(t, writev2.Metadata{Type: writev2.MetricTypeGauge, UnitRef: 9}, request.Timeseries[1].Metadata)
+}
@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package writev2 // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/prometheus/prometheus/prompb"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the io.prometheus.write.v2 messages.
+const (
+	requestSymbols    protowire.Number = 4
+	requestTimeseries protowire.Number = 5
+
+	timeSeriesLabelsRefs       protowire.Number = 1
+	timeSeriesSamples          protowire.Number = 2
+	timeSeriesHistograms       protowire.Number = 3
+	timeSeriesExemplars        protowire.Number = 4
+	timeSeriesMetadata         protowire.Number = 5
+	timeSeriesCreatedTimestamp protowire.Number = 6
+
+	sampleValue     protowire.Number = 1
+	sampleTimestamp protowire.Number = 2
+
+	exemplarLabelsRefs protowire.Number = 1
+	exemplarValue      protowire.Number = 2
+	exemplarTimestamp  protowire.Number = 3
+
+	metadataType    protowire.Number = 1
+	metadataHelpRef protowire.Number = 3
+	metadataUnitRef protowire.Number = 4
+)
+
+// Marshal returns the protobuf encoding of the request.
+func (r *Request) Marshal() ([]byte, error) {
+	var b []byte
+	for _, s := range r.Symbols {
+		b = protowire.AppendTag(b, requestSymbols, protowire.BytesType)
+		b = protowire.AppendString(b, s)
+	}
+	for i := range r.Timeseries {
+		ts, err := r.Timeseries[i].marshal()
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, requestTimeseries, protowire.BytesType)
+		b = protowire.AppendBytes(b, ts)
+	}
+	return b, nil
+}
+
+func (ts *TimeSeries) marshal() ([]byte, error) {
+	var b []byte
+	b = appendPackedUint32(b, timeSeriesLabelsRefs, ts.LabelsRefs)
+	for _, s := range ts.Samples {
+		b = protowire.AppendTag(b, timeSeriesSamples, protowire.BytesType)
+		b = protowire.AppendBytes(b, s.marshal())
+	}
+	for i := range ts.Histograms {
+		h, err := ts.Histograms[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, timeSeriesHistograms, protowire.BytesType)
+		b = protowire.AppendBytes(b, h)
+	}
+	for _, e := range ts.Exemplars {
+		b = protowire.AppendTag(b, timeSeriesExemplars, protowire.BytesType)
+		b = protowire.AppendBytes(b, e.marshal())
+	}
+	if m := ts.Metadata.marshal(); len(m) > 0 {
+		b = protowire.AppendTag(b, timeSeriesMetadata, protowire.BytesType)
+		b = protowire.AppendBytes(b, m)
+	}
+	b = appendInt64(b, timeSeriesCreatedTimestamp, ts.CreatedTimestamp)
+	return b, nil
+}
+
+func (s Sample) marshal() []byte {
+	var b []byte
+	b = appendDouble(b, sampleValue, s.Value)
+	return appendInt64(b, sampleTimestamp, s.Timestamp)
+}
+
+func (e Exemplar) marshal() []byte {
+	var b []byte
+	b = appendPackedUint32(b, exemplarLabelsRefs, e.LabelsRefs)
+	b = appendDouble(b, exemplarValue, e.Value)
+	return appendInt64(b, exemplarTimestamp, e.Timestamp)
+}
+
+func (m Metadata) marshal() []byte {
+	var b []byte
+	if m.Type != MetricTypeUnspecified {
+		b = protowire.AppendTag(b, metadataType, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(m.Type))
+	}
+	b = appendUint32(b, metadataHelpRef, m.HelpRef)
+	return appendUint32(b, metadataUnitRef, m.UnitRef)
+}
+
+// Unmarshal decodes the protobuf encoding of a request into r.
+func (r *Request) Unmarshal(b []byte) error {
+	*r = Request{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == requestSymbols && typ == protowire.BytesType:
+			s, n := protowire.ConsumeString(b)
+			r.Symbols = append(r.Symbols, s)
+			return n, nil
+		case num == requestTimeseries && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			var ts TimeSeries
+			if err := ts.unmarshal(v); err != nil {
+				return 0, err
+			}
+			r.Timeseries = append(r.Timeseries, ts)
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+}
+
+func (ts *TimeSeries) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case timeSeriesLabelsRefs:
+			var n int
+			ts.LabelsRefs, n = consumeUint32s(ts.LabelsRefs, typ, b)
+			return n, nil
+		case timeSeriesSamples:
+			return consumeMessage(typ, b, func(v []byte) error {
+				var s Sample
+				err := consumeFields(v, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+					switch {
+					case num == sampleValue && typ == protowire.Fixed64Type:
+						v, n := protowire.ConsumeFixed64(b)
+						s.Value = math.Float64frombits(v)
+						return n, nil
+					case num == sampleTimestamp && typ == protowire.VarintType:
+						v, n := protowire.ConsumeVarint(b)
+						s.Timestamp = int64(v)
+						return n, nil
+					}
+					return protowire.ConsumeFieldValue(num, typ, b), nil
+				})
+				ts.Samples = append(ts.Samples, s)
+				return err
+			})
+		case timeSeriesHistograms:
+			return consumeMessage(typ, b, func(v []byte) error {
+				var h prompb.Histogram
+				if err := h.Unmarshal(v); err != nil {
+					return err
+				}
+				ts.Histograms = append(ts.Histograms, h)
+				return nil
+			})
+		case timeSeriesExemplars:
+			return consumeMessage(typ, b, func(v []byte) error {
+				var e Exemplar
+				err := consumeFields(v, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+					switch {
+					case num == exemplarLabelsRefs:
+						var n int
+						e.LabelsRefs, n = consumeUint32s(e.LabelsRefs, typ, b)
+						return n, nil
+					case num == exemplarValue && typ == protowire.Fixed64Type:
+						v, n := protowire.ConsumeFixed64(b)
+						e.Value = math.Float64frombits(v)
+						return n, nil
+					case num == exemplarTimestamp && typ == protowire.VarintType:
+						v, n := protowire.ConsumeVarint(b)
+						e.Timestamp = int64(v)
+						return n, nil
+					}
+					return protowire.ConsumeFieldValue(num, typ, b), nil
+				})
+				ts.Exemplars = append(ts.Exemplars, e)
+				return err
+			})
+		case timeSeriesMetadata:
+			return consumeMessage(typ, b, func(v []byte) error {
+				return consumeFields(v, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+					if typ != protowire.VarintType {
+						return protowire.ConsumeFieldValue(num, typ, b), nil
+					}
+					v, n := protowire.ConsumeVarint(b)
+					switch num {
+					case metadataType:
+						ts.Metadata.Type = MetricType(v)
+					case metadataHelpRef:
+						ts.Metadata.HelpRef = uint32(v)
+					case metadataUnitRef:
+						ts.Metadata.UnitRef = uint32(v)
+					}
+					return n, nil
+				})
+			})
+		case timeSeriesCreatedTimestamp:
+			if typ == protowire.VarintType {
+				v, n := protowire.ConsumeVarint(b)
+				ts.CreatedTimestamp = int64(v)
+				return n, nil
+			}
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+}
+
+// consumeFields calls consume with the tag and the remaining bytes of every field of the message b.
+// consume returns the length of the value of the field, negative if it is malformed.
+func consumeFields(b []byte, consume func(protowire.Number, protowire.Type, []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return fmt.Errorf("invalid field tag: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+		n, err := consume(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return fmt.Errorf("invalid value of field %d: %w", num, protowire.ParseError(n))
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func consumeMessage(typ protowire.Type, b []byte, unmarshal func([]byte) error) (int, error) {
+	if typ != protowire.BytesType {
+		return protowire.ConsumeFieldValue(0, typ, b), nil
+	}
+	v, n := protowire.ConsumeBytes(b)
+	if n < 0 {
+		return n, nil
+	}
+	return n, unmarshal(v)
+}
+
+// consumeUint32s decodes a repeated uint32 field, either packed or not.
+func consumeUint32s(values []uint32, typ protowire.Type, b []byte) ([]uint32, int) {
+	switch typ {
+	case protowire.VarintType:
+		v, n := protowire.ConsumeVarint(b)
+		return append(values, uint32(v)), n
+	case protowire.BytesType:
+		packed, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return values, n
+		}
+		for len(packed) > 0 {
+			v, m := protowire.ConsumeVarint(packed)
+			if m < 0 {
+				return values, m
+			}
+			values = append(values, uint32(v))
+			packed = packed[m:]
+		}
+		return values, n
+	}
+	return values, protowire.ConsumeFieldValue(0, typ, b)
+}
+
+func appendPackedUint32(b []byte, num protowire.Number, values []uint32) []byte {
+	if len(values) == 0 {
+		return b
+	}
+	var packed []byte
+	for _, v := range values {
+		packed = protowire.AppendVarint(packed, uint64(v))
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, packed)
+}
+
+func appendDouble(b []byte, num protowire.Number, v float64) []byte {
+	// Unlike other zero values, negative zero must be encoded to be preserved.
+	if math.Float64bits(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, math.Float64bits(v))
+}
+
+func appendInt64(b []byte, num protowire.Number, v int64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(v))
+}
+
+func appendUint32(b []byte, num protowire.Number, v uint32) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(v))
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package writev2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestMarshalRoundTrip(t *testing.T) {
+	symbols := NewSymbolTable()
+	request := &Request{
+		Timeseries: []TimeSeries{
+			{
+				LabelsRefs: symbols.SymbolizeLabels([]prompb.Label{{Name: "__name__", Value: "http_requests_total"}, {Name: "job", Value: "api"}}, nil),
+				Samples: []Sample{
+					{Value: 1.5, Timestamp: 1000},
+					{Value: math.Copysign(0, -1), Timestamp: 2000},
+				},
+				Exemplars: []Exemplar{
+					{LabelsRefs: symbols.SymbolizeLabels([]prompb.Label{{Name: "trace_id", Value: "0102"}}, nil), Value: 1, Timestamp: 900},
+				},
+				Metadata: Metadata{
+					Type:    MetricTypeCounter,
+					HelpRef: symbols.Symbolize("Number of requests"),
+					UnitRef: symbols.Symbolize("1"),
+				},
+				CreatedTimestamp: 500,
+			},
+			{
+				LabelsRefs: symbols.SymbolizeLabels([]prompb.Label{{Name: "__name__", Value: "latency"}, {Name: "job", Value: "api"}}, nil),
+				Histograms: []prompb.Histogram{
+					{
+						Count:          &prompb.Histogram_CountInt{CountInt: 3},
+						Sum:            12,
+						Schema:         1,
+						ZeroThreshold:  0.001,
+						ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+						PositiveSpans:  []prompb.BucketSpan{{Offset: 2, Length: 2}},
+						PositiveDeltas: []int64{1, 0},
+						Timestamp:      1000,
+					},
+				},
+				Metadata: Metadata{Type: MetricTypeHistogram},
+			},
+		},
+	}
+	request.Symbols = symbols.Symbols()
+
+	data, err := request.Marshal()
+	require.NoError(t, err)
+
+	var got Request
+	require.NoError(t, got.Unmarshal(data))
+	assert.Equal(t, *request, got)
+	assert.True(t, math.Signbit(got.Timeseries[0].Samples[1].Value))
+
+	labels, err := DesymbolizeLabels(got.Timeseries[0].LabelsRefs, got.Symbols)
+	require.NoError(t, err)
+	assert.Equal(t, []prompb.Label{{Name: "__name__", Value: "http_requests_total"}, {Name: "job", Value: "api"}}, labels)
+
+	assert.Error(t, got.Unmarshal(data[:len(data)-1]))
+}
+
+func TestSymbolsTable(t *testing.T) {
+	symbols := NewSymbolTable()
+	assert.Equal(t, uint32(0), symbols.Symbolize(""))
+	assert.Equal(t, uint32(1), symbols.Symbolize("job"))
+	assert.Equal(t, uint32(2), symbols.Symbolize("api"))
+	assert.Equal(t, uint32(1), symbols.Symbolize("job"))
+	assert.Equal(t, []uint32{1, 2, 1, 1}, symbols.SymbolizeLabels([]prompb.Label{{Name: "job", Value: "api"}, {Name: "job", Value: "job"}}, nil))
+	assert.Equal(t, []string{"", "job", "api"}, symbols.Symbols())
+
+	symbols.Reset()
+	assert.Equal(t, []string{""}, symbols.Symbols())
+	assert.Equal(t, uint32(1), symbols.Symbolize("api"))
+}
+
+func TestDesymbolizeLabels(t *testing.T) {
+	symbols := []string{"", "job", "api"}
+
+	_, err := DesymbolizeLabels([]uint32{1}, symbols)
+	assert.ErrorContains(t, err, "odd number of label references")
+
+	_, err = DesymbolizeLabels([]uint32{1, 3}, symbols)
+	assert.ErrorContains(t, err, "symbol reference 3 out of range")
+}
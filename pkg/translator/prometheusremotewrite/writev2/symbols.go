@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package writev2 // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+
+import (
+	"fmt"
+
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// SymbolsTable interns the strings of a request.
+type SymbolsTable struct {
+	symbols []string
+	refs    map[string]uint32
+}
+
+// NewSymbolTable returns a table holding only the empty string, which is always the first symbol.
+func NewSymbolTable() *SymbolsTable {
+	t := &SymbolsTable{}
+	t.Reset()
+	return t
+}
+
+// Symbolize returns the reference of str, adding it to the table if needed.
+func (t *SymbolsTable) Symbolize(str string) uint32 {
+	if ref, ok := t.refs[str]; ok {
+		return ref
+	}
+	ref := uint32(len(t.symbols))
+	t.symbols = append(t.symbols, str)
+	t.refs[str] = ref
+	return ref
+}
+
+// SymbolizeLabels appends the references of the name and value of each label to buf.
+func (t *SymbolsTable) SymbolizeLabels(labels []prompb.Label, buf []uint32) []uint32 {
+	for _, l := range labels {
+		buf = append(buf, t.Symbolize(l.Name), t.Symbolize(l.Value))
+	}
+	return buf
+}
+
+// Symbols returns the symbols of the table, in the order of their references.
+func (t *SymbolsTable) Symbols() []string {
+	return t.symbols
+}
+
+// Reset removes every symbol but the empty string, so that the table can be used for another request.
+func (t *SymbolsTable) Reset() {
+	t.symbols = []string{""}
+	t.refs = map[string]uint32{"": 0}
+}
+
+// DesymbolizeLabels returns the labels referenced by refs.
+func DesymbolizeLabels(refs []uint32, symbols []string) ([]prompb.Label, error) {
+	if len(refs)%2 != 0 {
+		return nil, fmt.Errorf("odd number of label references: %d", len(refs))
+	}
+	labels := make([]prompb.Label, 0, len(refs)/2)
+	for i := 0; i < len(refs); i += 2 {
+		name, err := Symbol(refs[i], symbols)
+		if err != nil {
+			return nil, err
+		}
+		value, err := Symbol(refs[i+1], symbols)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, prompb.Label{Name: name, Value: value})
+	}
+	return labels, nil
+}
+
+// Symbol returns the symbol referenced by ref.
+func Symbol(ref uint32, symbols []string) (string, error) {
+	if int(ref) >= len(symbols) {
+		return "", fmt.Errorf("symbol reference %d out of range, %d symbols", ref, len(symbols))
+	}
+	return symbols[ref], nil
+}
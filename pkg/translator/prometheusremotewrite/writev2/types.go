@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package writev2 contains the messages of the Prometheus Remote-Write 2.0 protocol
+// (io.prometheus.write.v2), see https://prometheus.io/docs/specs/remote_write_spec_2_0/.
+//
+// Native histograms share their wire format with remote write 1.0, so series reuse prompb.Histogram.
+package writev2 // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+
+import (
+	"github.com/prometheus/prometheus/prompb"
+)
+
+const (
+	// ContentType is the content type of remote write 2.0 requests.
+	ContentType = "application/x-protobuf;proto=io.prometheus.write.v2.Request"
+	// ContentTypeV1 is the content type of remote write 1.0 requests.
+	ContentTypeV1 = "application/x-protobuf;proto=prometheus.WriteRequest"
+	// Version is the value of the X-Prometheus-Remote-Write-Version header of remote write 2.0 requests.
+	Version = "2.0.0"
+)
+
+// Response headers reporting how much data a remote write 2.0 receiver has written.
+const (
+	WrittenSamplesHeader    = "X-Prometheus-Remote-Write-Samples-Written"
+	WrittenHistogramsHeader = "X-Prometheus-Remote-Write-Histograms-Written"
+	WrittenExemplarsHeader  = "X-Prometheus-Remote-Write-Exemplars-Written"
+)
+
+// MetricType is the type of the metric a series belongs to.
+type MetricType int32
+
+const (
+	MetricTypeUnspecified    MetricType = 0
+	MetricTypeCounter        MetricType = 1
+	MetricTypeGauge          MetricType = 2
+	MetricTypeHistogram      MetricType = 3
+	MetricTypeGaugeHistogram MetricType = 4
+	MetricTypeSummary        MetricType = 5
+	MetricTypeInfo           MetricType = 6
+	MetricTypeStateset       MetricType = 7
+)
+
+// Request is a remote write 2.0 request. Label names and values, help texts and units are
+// interned in Symbols and referenced by their index. The first symbol is always the empty string.
+type Request struct {
+	Symbols    []string
+	Timeseries []TimeSeries
+}
+
+// TimeSeries is a series with its samples or native histograms, exemplars and metadata.
+// LabelsRefs holds pairs of references to the name and value of each label.
+type TimeSeries struct {
+	LabelsRefs []uint32
+	Samples    []Sample
+	Histograms []prompb.Histogram
+	Exemplars  []Exemplar
+	Metadata   Metadata
+	// CreatedTimestamp is the time in milliseconds the cumulative series started at, 0 if unknown.
+	CreatedTimestamp int64
+}
+
+// Sample is a value of a series at a timestamp in milliseconds.
+type Sample struct {
+	Value     float64
+	Timestamp int64
+}
+
+// Exemplar is a sample with its own labels, such as a trace ID.
+type Exemplar struct {
+	LabelsRefs []uint32
+	Value      float64
+	Timestamp  int64
+}
+
+// Metadata describes the metric a series belongs to.
+type Metadata struct {
+	Type    MetricType
+	HelpRef uint32
+	UnitRef uint32
+}
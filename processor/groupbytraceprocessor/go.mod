@@ -3,11 +3,13 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/processor/group
 go 1.20
 
 require (
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.87.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal v0.87.0
 	github.com/stretchr/testify v1.8.4
 	go.opencensus.io v0.24.0
 	go.opentelemetry.io/collector/component v0.87.0
 	go.opentelemetry.io/collector/consumer v0.87.0
+	go.opentelemetry.io/collector/extension v0.87.0
 	go.opentelemetry.io/collector/pdata v1.0.0-rcv0016
 	go.opentelemetry.io/collector/processor v0.87.0
 	go.uber.org/multierr v1.11.0
@@ -45,6 +47,8 @@ require (
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal => ../../pkg/batchpersignal
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage => ../../extension/storage
+
 retract (
 	v0.76.2
 	v0.76.1
@@ -83,14 +83,48 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
+
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+
+	if pst, ok := sp.st.(persistentStorage); ok {
+		return sp.resumePending(pst)
+	}
+	return nil
+}
+
+// resumePending schedules the traces that were left in a persistent storage by a previous run of the processor.
+// Each trace is taken out of the storage and consumed again, so that it's placed in the in-memory ring buffer
+// and waits for the full duration before being released.
+func (sp *groupByTraceProcessor) resumePending(st persistentStorage) error {
+	var errs error
+	for _, traceID := range st.pending() {
+		rss, err := st.delete(traceID)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("couldn't resume trace %q from the storage: %w", traceID, err))
+			continue
+		}
+		if rss == nil {
+			continue
+		}
+
+		td := ptrace.NewTraces()
+		for _, rs := range rss {
+			rs.MoveTo(td.ResourceSpans().AppendEmpty())
+		}
+
+		sp.logger.Debug("resuming trace from the storage", zap.Stringer("traceID", traceID))
+		errs = multierr.Append(errs, sp.eventMachine.consume(td))
+	}
+	return errs
 }
 
 // Shutdown is invoked during service shutdown.
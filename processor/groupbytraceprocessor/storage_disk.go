@@ -0,0 +1,287 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	// diskStorageTraceKeyPrefix is the prefix for the keys holding the serialized spans for a trace
+	diskStorageTraceKeyPrefix = "trace_"
+
+	// diskStorageIndexKey is the key holding the IDs of all traces in the storage, used to resume
+	// the pending traces after a restart
+	diskStorageIndexKey = "trace_ids"
+)
+
+var (
+	errStorageExtensionNotFound = errors.New("storage extension not found")
+	errNotAStorageExtension     = errors.New("extension is not a storage extension")
+	errDiskStorageNotStarted    = errors.New("disk storage has not been started")
+	errInvalidStorageIndex      = errors.New("invalid trace index in the storage")
+)
+
+// diskStorage is a storage that keeps only the trace IDs in memory, serializing the spans into
+// a client obtained from a storage extension, such as the file_storage extension.
+// The index of trace IDs is persisted periodically and on shutdown, so that the traces still
+// waiting to be released can be resumed once the processor is started again.
+type diskStorage struct {
+	sync.Mutex
+	logger      *zap.Logger
+	storageID   component.ID
+	componentID component.ID
+	client      storage.Client
+
+	// traceIDs holds the IDs of the traces currently in the storage
+	traceIDs map[pcommon.TraceID]struct{}
+
+	// resumed holds the IDs of the traces found in the storage when it was started
+	resumed []pcommon.TraceID
+
+	marshaler   ptrace.ProtoMarshaler
+	unmarshaler ptrace.ProtoUnmarshaler
+
+	stopped                   bool
+	stoppedLock               sync.RWMutex
+	metricsCollectionInterval time.Duration
+}
+
+var _ persistentStorage = (*diskStorage)(nil)
+
+func newDiskStorage(logger *zap.Logger, storageID component.ID, componentID component.ID) *diskStorage {
+	return &diskStorage{
+		logger:                    logger,
+		storageID:                 storageID,
+		componentID:               componentID,
+		traceIDs:                  make(map[pcommon.TraceID]struct{}),
+		metricsCollectionInterval: time.Second,
+	}
+}
+
+func (st *diskStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	st.Lock()
+	defer st.Unlock()
+
+	if st.client == nil {
+		return errDiskStorageNotStarted
+	}
+
+	existing, found, err := st.load(traceID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		existing = ptrace.NewTraces()
+	}
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rss.At(i).CopyTo(existing.ResourceSpans().AppendEmpty())
+	}
+
+	buf, err := st.marshaler.MarshalTraces(existing)
+	if err != nil {
+		return fmt.Errorf("couldn't serialize trace %q: %w", traceID, err)
+	}
+	if err = st.client.Set(context.Background(), diskStorageTraceKey(traceID), buf); err != nil {
+		return err
+	}
+
+	st.traceIDs[traceID] = struct{}{}
+	return nil
+}
+
+func (st *diskStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	if st.client == nil {
+		return nil, errDiskStorageNotStarted
+	}
+
+	td, found, err := st.load(traceID)
+	if err != nil || !found {
+		return nil, err
+	}
+	return resourceSpansOf(td), nil
+}
+
+// delete will return the trace as it was in the storage before it got removed.
+func (st *diskStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	if st.client == nil {
+		return nil, errDiskStorageNotStarted
+	}
+
+	td, found, err := st.load(traceID)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	if err = st.client.Delete(context.Background(), diskStorageTraceKey(traceID)); err != nil {
+		return nil, err
+	}
+	delete(st.traceIDs, traceID)
+
+	return resourceSpansOf(td), nil
+}
+
+func (st *diskStorage) start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[st.storageID]
+	if !ok {
+		return fmt.Errorf("%w: %q", errStorageExtensionNotFound, st.storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("%w: %q", errNotAStorageExtension, st.storageID)
+	}
+
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, st.componentID, "")
+	if err != nil {
+		return err
+	}
+
+	index, err := client.Get(ctx, diskStorageIndexKey)
+	if err != nil {
+		return multierr.Append(err, client.Close(ctx))
+	}
+	resumed, err := decodeTraceIDs(index)
+	if err != nil {
+		return multierr.Append(err, client.Close(ctx))
+	}
+
+	st.Lock()
+	st.client = client
+	st.resumed = resumed
+	for _, traceID := range resumed {
+		st.traceIDs[traceID] = struct{}{}
+	}
+	st.Unlock()
+
+	st.logger.Debug("disk storage started", zap.Int("pending-traces", len(resumed)))
+
+	go st.periodicMetrics()
+	return nil
+}
+
+func (st *diskStorage) pending() []pcommon.TraceID {
+	st.Lock()
+	defer st.Unlock()
+	return st.resumed
+}
+
+func (st *diskStorage) shutdown() error {
+	st.stoppedLock.Lock()
+	st.stopped = true
+	st.stoppedLock.Unlock()
+
+	st.Lock()
+	defer st.Unlock()
+
+	if st.client == nil {
+		return nil
+	}
+
+	err := st.persistIndex()
+	err = multierr.Append(err, st.client.Close(context.Background()))
+	st.client = nil
+	return err
+}
+
+func (st *diskStorage) periodicMetrics() {
+	st.Lock()
+	numTraces := len(st.traceIDs)
+	if err := st.persistIndex(); err != nil {
+		st.logger.Warn("failed to persist the trace index", zap.Error(err))
+	}
+	st.Unlock()
+
+	stats.Record(context.Background(), mNumTracesInMemory.M(int64(numTraces)))
+
+	st.stoppedLock.RLock()
+	stopped := st.stopped
+	st.stoppedLock.RUnlock()
+	if stopped {
+		return
+	}
+
+	time.AfterFunc(st.metricsCollectionInterval, func() {
+		st.periodicMetrics()
+	})
+}
+
+func (st *diskStorage) count() int {
+	st.Lock()
+	defer st.Unlock()
+	return len(st.traceIDs)
+}
+
+// persistIndex writes the IDs of the traces currently in the storage. Callers must hold the lock.
+func (st *diskStorage) persistIndex() error {
+	if st.client == nil {
+		return nil
+	}
+	buf := make([]byte, 0, len(st.traceIDs)*len(pcommon.TraceID{}))
+	for traceID := range st.traceIDs {
+		buf = append(buf, traceID[:]...)
+	}
+	return st.client.Set(context.Background(), diskStorageIndexKey, buf)
+}
+
+// load retrieves the trace from the client, reporting whether it could be found. Callers must hold the lock.
+func (st *diskStorage) load(traceID pcommon.TraceID) (ptrace.Traces, bool, error) {
+	buf, err := st.client.Get(context.Background(), diskStorageTraceKey(traceID))
+	if err != nil || buf == nil {
+		return ptrace.Traces{}, false, err
+	}
+
+	td, err := st.unmarshaler.UnmarshalTraces(buf)
+	if err != nil {
+		return ptrace.Traces{}, false, fmt.Errorf("couldn't deserialize trace %q: %w", traceID, err)
+	}
+	return td, true, nil
+}
+
+func diskStorageTraceKey(traceID pcommon.TraceID) string {
+	return diskStorageTraceKeyPrefix + traceID.String()
+}
+
+func decodeTraceIDs(buf []byte) ([]pcommon.TraceID, error) {
+	size := len(pcommon.TraceID{})
+	if len(buf)%size != 0 {
+		return nil, errInvalidStorageIndex
+	}
+
+	traceIDs := make([]pcommon.TraceID, 0, len(buf)/size)
+	for i := 0; i < len(buf); i += size {
+		var traceID pcommon.TraceID
+		copy(traceID[:], buf[i:i+size])
+		traceIDs = append(traceIDs, traceID)
+	}
+	return traceIDs, nil
+}
+
+func resourceSpansOf(td ptrace.Traces) []ptrace.ResourceSpans {
+	rss := td.ResourceSpans()
+	result := make([]ptrace.ResourceSpans, rss.Len())
+	for i := 0; i < rss.Len(); i++ {
+		result[i] = rss.At(i)
+	}
+	return result
+}
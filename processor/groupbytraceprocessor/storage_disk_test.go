@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestDiskCreateAndGetTrace(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("test"))
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1, 2, 3, 4}),
+		pcommon.TraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	// test
+	for _, traceID := range traceIDs {
+		assert.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+
+	// verify
+	assert.Equal(t, 2, st.count())
+	for _, traceID := range traceIDs {
+		retrieved, err := st.get(traceID)
+		require.NoError(t, err)
+		require.Len(t, retrieved, 1)
+		assert.Equal(t, traceID, retrieved[0].ScopeSpans().At(0).Spans().At(0).TraceID())
+	}
+}
+
+func TestDiskDeleteTrace(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("test"))
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	assert.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, traceID, deleted[0].ScopeSpans().At(0).Spans().At(0).TraceID())
+	assert.Equal(t, 0, st.count())
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+
+	deleted, err = st.delete(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+}
+
+func TestDiskAppendSpans(t *testing.T) {
+	// prepare
+	st := newStartedDiskStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("test"))
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	assert.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	secondTrace := simpleTracesWithID(traceID)
+	secondSpan := secondTrace.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	secondSpan.SetName("second-name")
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, secondTrace))
+
+	// override something in the second span, to make sure we are storing a copy
+	secondSpan.SetName("changed-second-name")
+
+	// verify
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	assert.Equal(t, "second-name", retrieved[1].ScopeSpans().At(0).Spans().At(0).Name())
+	assert.Equal(t, 1, st.count())
+}
+
+func TestDiskStorageNotStarted(t *testing.T) {
+	// prepare
+	st := newDiskStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID("groupbytrace"))
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	// test and verify
+	assert.ErrorIs(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)), errDiskStorageNotStarted)
+	_, err := st.get(traceID)
+	assert.ErrorIs(t, err, errDiskStorageNotStarted)
+	_, err = st.delete(traceID)
+	assert.ErrorIs(t, err, errDiskStorageNotStarted)
+}
+
+func TestDiskStorageInvalidExtension(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		host        component.Host
+		expectedErr error
+	}{
+		{
+			name:        "missing",
+			host:        componenttest.NewNopHost(),
+			expectedErr: errStorageExtensionNotFound,
+		},
+		{
+			name:        "not a storage",
+			host:        storagetest.NewStorageHost().WithExtension(storagetest.NewStorageID("test"), storagetest.NewNonStorageExtension("test")),
+			expectedErr: errNotAStorageExtension,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newDiskStorage(zap.NewNop(), storagetest.NewStorageID("test"), component.NewID("groupbytrace"))
+			assert.ErrorIs(t, st.start(context.Background(), tt.host), tt.expectedErr)
+		})
+	}
+}
+
+func TestDiskStorageResumesAfterRestart(t *testing.T) {
+	// prepare
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("test")
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	config := Config{
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   1,
+		StoreOnDisk:  true,
+		StorageID:    &storageID,
+	}
+
+	ext := storagetest.NewFileBackedStorageExtension("test", storageDir)
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+	st := newDiskStorage(zap.NewNop(), storageID, component.NewID("groupbytrace"))
+	p := newGroupByTraceProcessor(zap.NewNop(), st, &mockProcessor{}, config)
+
+	require.NoError(t, p.Start(context.Background(), host))
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	assert.Eventually(t, func() bool {
+		return st.count() == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Shutdown(context.Background()))
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	// test
+	releasedCh := make(chan ptrace.Traces, 1)
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			releasedCh <- td
+			return nil
+		},
+	}
+	config.WaitDuration = time.Millisecond
+
+	ext = storagetest.NewFileBackedStorageExtension("test", storageDir)
+	host = storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+	st = newDiskStorage(zap.NewNop(), storageID, component.NewID("groupbytrace"))
+	p = newGroupByTraceProcessor(zap.NewNop(), st, next, config)
+	require.NoError(t, p.Start(context.Background(), host))
+	defer func() {
+		assert.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// verify
+	var released ptrace.Traces
+	select {
+	case released = <-releasedCh:
+	case <-time.After(time.Second):
+		t.Fatal("the trace from the previous run hasn't been released")
+	}
+	assert.Equal(t, 1, released.SpanCount())
+	assert.Equal(t, traceID, released.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+}
+
+func newStartedDiskStorage(t *testing.T, host component.Host) *diskStorage {
+	st := newDiskStorage(zap.NewNop(), storagetest.NewStorageID("test"), processortest.NewNopCreateSettings().ID)
+	require.NoError(t, st.start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, st.shutdown())
+	})
+	return st
+}
@@ -3,31 +3,90 @@
 
 package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
 
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+const (
+	// replacementMask masks the matched value with a fixed length of asterisks
+	replacementMask = "mask"
+	// replacementHMAC replaces the matched value with its keyed HMAC-SHA256,
+	// encoded as hex
+	replacementHMAC = "hmac"
+	// replacementToken replaces the matched value with a keyed token of the
+	// same length, keeping digits, letter case and separators in place
+	replacementToken = "token"
+)
+
+var errMissingHashKey = errors.New("hash_key must be set to use the hmac or token replacements")
+
 type Config struct {
 
-	// AllowAllKeys is a flag to allow all span attribute keys. Setting this
+	// AllowAllKeys is a flag to allow all attribute keys. Setting this
 	// to true disables the AllowedKeys list. The list of BlockedValues is
 	// applied regardless. If you just want to block values, set this to true.
 	AllowAllKeys bool `mapstructure:"allow_all_keys"`
 
-	// AllowedKeys is a list of allowed span attribute keys. Span attributes
-	// not on the list are removed. The list fails closed if it's empty. To
-	// allow all keys, you should explicitly set AllowAllKeys
+	// AllowedKeys is a list of allowed attribute keys for spans, log records
+	// and metric data points. Attributes not on the list are removed. The
+	// list fails closed if it's empty. To allow all keys, you should
+	// explicitly set AllowAllKeys
 	AllowedKeys []string `mapstructure:"allowed_keys"`
 
-	// IgnoredKeys is a list of span attribute keys that are not redacted.
-	// Span attributes in this list are allowed to pass through the filter
+	// IgnoredKeys is a list of attribute keys that are not redacted.
+	// Attributes in this list are allowed to pass through the filter
 	// without being changed or removed.
 	IgnoredKeys []string `mapstructure:"ignored_keys"`
 
 	// BlockedValues is a list of regular expressions for blocking values of
-	// allowed span attributes. Values that match are masked
+	// allowed attributes and string log bodies. Values that match are masked
 	BlockedValues []string `mapstructure:"blocked_values"`
 
+	// BlockedValueRules is a list of rules for blocking values of allowed
+	// attributes and string log bodies. Unlike BlockedValues, each rule
+	// chooses how the matched values are replaced. Pseudonymizing
+	// replacements map the same value to the same pseudonym, so values can
+	// still be correlated downstream.
+	BlockedValueRules []BlockedValueRule `mapstructure:"blocked_value_rules"`
+
+	// HashKey is the secret key used to derive pseudonyms for the rules using
+	// the `hmac` or `token` replacements.
+	HashKey configopaque.String `mapstructure:"hash_key"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
-	// the processor adds to the spans when it redacts or masks other
-	// attributes. In some contexts a list of redacted attributes leaks
-	// information, while it is valuable when integrating and testing a new
-	// configuration. Possible values are `debug`, `info`, and `silent`.
+	// the processor adds to the spans, log records and data points when it
+	// redacts or masks other attributes. In some contexts a list of redacted
+	// attributes leaks information, while it is valuable when integrating and
+	// testing a new configuration. Possible values are `debug`, `info`, and `silent`.
 	Summary string `mapstructure:"summary"`
 }
+
+// BlockedValueRule is a regular expression for blocking values, along with
+// how values that match are replaced.
+type BlockedValueRule struct {
+	// Pattern is the regular expression matching the values to block.
+	Pattern string `mapstructure:"pattern"`
+
+	// Replacement controls how the matched values are replaced. Possible
+	// values are `mask` (the default), `hmac` and `token`.
+	Replacement string `mapstructure:"replacement"`
+}
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	for _, rule := range cfg.BlockedValueRules {
+		switch rule.Replacement {
+		case "", replacementMask:
+		case replacementHMAC, replacementToken:
+			if cfg.HashKey == "" {
+				return errMissingHashKey
+			}
+		default:
+			return fmt.Errorf("unknown replacement %q for pattern %q", rule.Replacement, rule.Pattern)
+		}
+	}
+	return nil
+}
@@ -19,8 +19,9 @@ func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		id       component.ID
-		expected component.Config
+		id          component.ID
+		expected    component.Config
+		expectedErr string
 	}{
 		{
 			id: component.NewIDWithName(metadata.Type, ""),
@@ -36,6 +37,26 @@ func TestLoadConfig(t *testing.T) {
 			id:       component.NewIDWithName(metadata.Type, "empty"),
 			expected: createDefaultConfig(),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "pseudonymize"),
+			expected: &Config{
+				AllowAllKeys: true,
+				HashKey:      "my-secret-key",
+				BlockedValueRules: []BlockedValueRule{
+					{Pattern: "[a-z0-9._%+-]+@[a-z0-9.-]+\\.[a-z]{2,}", Replacement: replacementHMAC},
+					{Pattern: "\\b(?:[0-9]{1,3}\\.){3}[0-9]{1,3}\\b", Replacement: replacementToken},
+					{Pattern: "4[0-9]{12}(?:[0-9]{3})?", Replacement: replacementMask},
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_hash_key"),
+			expectedErr: errMissingHashKey.Error(),
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "unknown_replacement"),
+			expectedErr: `unknown replacement "encrypt" for pattern "[a-z0-9._%+-]+@[a-z0-9.-]+\\.[a-z]{2,}"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -50,6 +71,10 @@ func TestLoadConfig(t *testing.T) {
 			require.NoError(t, err)
 			require.NoError(t, component.UnmarshalConfig(sub, cfg))
 
+			if tt.expectedErr != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
 			assert.NoError(t, component.ValidateConfig(cfg))
 			assert.Equal(t, tt.expected, cfg)
 		})
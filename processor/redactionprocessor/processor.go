@@ -11,6 +11,8 @@ import (
 	"strings"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
@@ -18,12 +20,12 @@ import (
 const attrValuesSeparator = ","
 
 type redaction struct {
-	// Attribute keys allowed in a span
+	// Attribute keys allowed in a span, log record or data point
 	allowList map[string]string
-	// Attribute keys ignored in a span
+	// Attribute keys ignored in a span, log record or data point
 	ignoreList map[string]string
-	// Attribute values blocked in a span
-	blockRegexList map[string]*regexp.Regexp
+	// Attribute values blocked in a span, log record or data point
+	blockRules []blockRule
 	// Redaction processor configuration
 	config *Config
 	// Logger
@@ -34,22 +36,22 @@ type redaction struct {
 func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*redaction, error) {
 	allowList := makeAllowList(config)
 	ignoreList := makeIgnoreList(config)
-	blockRegexList, err := makeBlockRegexList(ctx, config)
+	blockRules, err := makeBlockRules(ctx, config)
 	if err != nil {
 		// TODO: Placeholder for an error metric in the next PR
 		return nil, fmt.Errorf("failed to process block list: %w", err)
 	}
 
 	return &redaction{
-		allowList:      allowList,
-		ignoreList:     ignoreList,
-		blockRegexList: blockRegexList,
-		config:         config,
-		logger:         logger,
+		allowList:  allowList,
+		ignoreList: ignoreList,
+		blockRules: blockRules,
+		config:     config,
+		logger:     logger,
 	}, nil
 }
 
-// processTraces implements ProcessMetricsFunc. It processes the incoming data
+// processTraces implements ProcessTracesFunc. It processes the incoming data
 // and returns the data to be sent to the next component
 func (s *redaction) processTraces(ctx context.Context, batch ptrace.Traces) (ptrace.Traces, error) {
 	for i := 0; i < batch.ResourceSpans().Len(); i++ {
@@ -79,7 +81,104 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 	}
 }
 
-// processAttrs redacts the attributes of a resource span or a span
+// processLogs implements ProcessLogsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processLogs(ctx context.Context, logs plog.Logs) (plog.Logs, error) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		rl := logs.ResourceLogs().At(i)
+		s.processResourceLog(ctx, rl)
+	}
+	return logs, nil
+}
+
+// processResourceLog processes the RL and all of its log records
+func (s *redaction) processResourceLog(ctx context.Context, rl plog.ResourceLogs) {
+	// Attributes can be part of a resource log
+	s.processAttrs(ctx, rl.Resource().Attributes())
+
+	for j := 0; j < rl.ScopeLogs().Len(); j++ {
+		ils := rl.ScopeLogs().At(j)
+		for k := 0; k < ils.LogRecords().Len(); k++ {
+			log := ils.LogRecords().At(k)
+
+			// Attributes can also be part of a log record
+			s.processAttrs(ctx, log.Attributes())
+			s.processLogBody(ctx, log.Body(), log.Attributes())
+		}
+	}
+}
+
+// processLogBody masks any blocked values in a string log body. The summary
+// is added to the attributes of the log record, listing the body as `body`
+func (s *redaction) processLogBody(_ context.Context, body pcommon.Value, attributes pcommon.Map) {
+	if body.Type() != pcommon.ValueTypeStr {
+		return
+	}
+
+	maskedValue, masked := s.maskValue(body.Str())
+	if !masked {
+		return
+	}
+
+	body.SetStr(maskedValue)
+	s.addMetaAttrs([]string{logBodyKey}, attributes, maskedValues, maskedValueCount)
+}
+
+// processMetrics implements ProcessMetricsFunc. It processes the incoming data
+// and returns the data to be sent to the next component
+func (s *redaction) processMetrics(ctx context.Context, metrics pmetric.Metrics) (pmetric.Metrics, error) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		s.processResourceMetric(ctx, rm)
+	}
+	return metrics, nil
+}
+
+// processResourceMetric processes the RM and the data points of all of its metrics
+func (s *redaction) processResourceMetric(ctx context.Context, rm pmetric.ResourceMetrics) {
+	// Attributes can be part of a resource metric
+	s.processAttrs(ctx, rm.Resource().Attributes())
+
+	for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+		ils := rm.ScopeMetrics().At(j)
+		for k := 0; k < ils.Metrics().Len(); k++ {
+			metric := ils.Metrics().At(k)
+
+			// Attributes can also be part of the data points of a metric
+			switch metric.Type() {
+			case pmetric.MetricTypeGauge:
+				dps := metric.Gauge().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					s.processAttrs(ctx, dps.At(i).Attributes())
+				}
+			case pmetric.MetricTypeSum:
+				dps := metric.Sum().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					s.processAttrs(ctx, dps.At(i).Attributes())
+				}
+			case pmetric.MetricTypeHistogram:
+				dps := metric.Histogram().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					s.processAttrs(ctx, dps.At(i).Attributes())
+				}
+			case pmetric.MetricTypeExponentialHistogram:
+				dps := metric.ExponentialHistogram().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					s.processAttrs(ctx, dps.At(i).Attributes())
+				}
+			case pmetric.MetricTypeSummary:
+				dps := metric.Summary().DataPoints()
+				for i := 0; i < dps.Len(); i++ {
+					s.processAttrs(ctx, dps.At(i).Attributes())
+				}
+			case pmetric.MetricTypeEmpty:
+			}
+		}
+	}
+}
+
+// processAttrs redacts the attributes of a resource, a span, a log record or
+// a data point
 func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	// TODO: Use the context for recording metrics
 	var toDelete []string
@@ -112,15 +211,9 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 		}
 
 		// Mask any blocked values for the other attributes
-		strVal := value.Str()
-		for _, compiledRE := range s.blockRegexList {
-			match := compiledRE.MatchString(strVal)
-			if match {
-				toBlock = append(toBlock, k)
-
-				maskedValue := compiledRE.ReplaceAllString(strVal, "****")
-				value.SetStr(maskedValue)
-			}
+		if maskedValue, masked := s.maskValue(value.Str()); masked {
+			toBlock = append(toBlock, k)
+			value.SetStr(maskedValue)
 		}
 		return true
 	})
@@ -135,6 +228,19 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	s.addMetaAttrs(ignoring, attributes, "", ignoredKeyCount)
 }
 
+// maskValue replaces the parts of the value matching any of the block rules,
+// reporting whether any of them matched
+func (s *redaction) maskValue(strVal string) (string, bool) {
+	masked := false
+	for _, rule := range s.blockRules {
+		if rule.re.MatchString(strVal) {
+			masked = true
+			strVal = rule.re.ReplaceAllStringFunc(strVal, rule.replace)
+		}
+	}
+	return strVal, masked
+}
+
 // addMetaAttrs adds diagnostic information about redacted or masked attribute keys
 func (s *redaction) addMetaAttrs(redactedAttrs []string, attributes pcommon.Map, valuesAttr, countAttr string) {
 	redactedCount := int64(len(redactedAttrs))
@@ -166,6 +272,8 @@ const (
 	maskedValues     = "redaction.masked.keys"
 	maskedValueCount = "redaction.masked.count"
 	ignoredKeyCount  = "redaction.ignored.count"
+	// logBodyKey is the name used for the body of a log record in the summary
+	logBodyKey = "body"
 )
 
 // makeAllowList sets up a lookup table of allowed span attribute keys
@@ -202,16 +310,46 @@ func makeIgnoreList(c *Config) map[string]string {
 	return ignoreList
 }
 
-// makeBlockRegexList precompiles all the blocked regex patterns
-func makeBlockRegexList(_ context.Context, config *Config) (map[string]*regexp.Regexp, error) {
-	blockRegexList := make(map[string]*regexp.Regexp, len(config.BlockedValues))
+// blockRule is a precompiled blocked regex pattern, along with the function
+// replacing the matched values
+type blockRule struct {
+	re      *regexp.Regexp
+	replace func(string) string
+}
+
+// makeBlockRules precompiles all the blocked regex patterns, starting with
+// the ones from the blocked values list, followed by the blocked value rules
+func makeBlockRules(_ context.Context, config *Config) ([]blockRule, error) {
+	blockRules := make([]blockRule, 0, len(config.BlockedValues)+len(config.BlockedValueRules))
 	for _, pattern := range config.BlockedValues {
 		re, err := regexp.Compile(pattern)
 		if err != nil {
 			// TODO: Placeholder for an error metric in the next PR
 			return nil, fmt.Errorf("error compiling regex in block list: %w", err)
 		}
-		blockRegexList[pattern] = re
+		blockRules = append(blockRules, blockRule{re: re, replace: mask})
+	}
+
+	key := []byte(config.HashKey)
+	for _, rule := range config.BlockedValueRules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			// TODO: Placeholder for an error metric in the next PR
+			return nil, fmt.Errorf("error compiling regex in block list: %w", err)
+		}
+
+		var replace func(string) string
+		switch rule.Replacement {
+		case "", replacementMask:
+			replace = mask
+		case replacementHMAC:
+			replace = newHMACPseudonymizer(key)
+		case replacementToken:
+			replace = newTokenPseudonymizer(key)
+		default:
+			return nil, fmt.Errorf("unknown replacement %q for pattern %q", rule.Replacement, rule.Pattern)
+		}
+		blockRules = append(blockRules, blockRule{re: re, replace: replace})
 	}
-	return blockRegexList, nil
+	return blockRules, nil
 }
@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap/zaptest"
 )
@@ -367,6 +369,195 @@ func TestProcessAttrsAppliedTwice(t *testing.T) {
 	assert.Equal(t, int64(2), val.Int())
 }
 
+// TestRedactLogs validates that the processor redacts and masks the
+// attributes of log records and masks string log bodies
+func TestRedactLogs(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"id", "name"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		Summary:       "debug",
+	}
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("name", "resource 4111111111111111")
+	log := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	log.Body().SetStr("payment with 4111111111111111 accepted")
+	log.Attributes().PutInt("id", 5)
+	log.Attributes().PutStr("name", "placeholder 4111111111111111")
+	log.Attributes().PutStr("credit_card", "4111111111111111")
+
+	// test
+	ctx := context.Background()
+	processor, err := newRedaction(ctx, config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	outLogs, err := processor.processLogs(ctx, logs)
+	require.NoError(t, err)
+
+	// verify
+	resourceAttrs := outLogs.ResourceLogs().At(0).Resource().Attributes()
+	val, _ := resourceAttrs.Get("name")
+	assert.Equal(t, "resource ****", val.Str())
+
+	outLog := outLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "payment with **** accepted", outLog.Body().Str())
+
+	attrs := outLog.Attributes()
+	_, found := attrs.Get("credit_card")
+	assert.False(t, found)
+	val, _ = attrs.Get("name")
+	assert.Equal(t, "placeholder ****", val.Str())
+	val, _ = attrs.Get(redactedKeys)
+	assert.Equal(t, "credit_card", val.Str())
+	val, _ = attrs.Get(maskedValues)
+	assert.Equal(t, "body,name", val.Str())
+	val, _ = attrs.Get(maskedValueCount)
+	assert.Equal(t, int64(2), val.Int())
+}
+
+// TestLogBodyNotString validates that the processor leaves log bodies that
+// aren't strings untouched
+func TestLogBodyNotString(t *testing.T) {
+	config := &Config{
+		AllowAllKeys:  true,
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		Summary:       "debug",
+	}
+	logs := plog.NewLogs()
+	log := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	log.Body().SetInt(4111111111111111)
+
+	// test
+	ctx := context.Background()
+	processor, err := newRedaction(ctx, config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	outLogs, err := processor.processLogs(ctx, logs)
+	require.NoError(t, err)
+
+	// verify
+	outLog := outLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, int64(4111111111111111), outLog.Body().Int())
+	assert.Equal(t, 0, outLog.Attributes().Len())
+}
+
+// TestRedactMetrics validates that the processor redacts and masks the
+// attributes of the data points of every metric type
+func TestRedactMetrics(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"name"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		Summary:       "info",
+	}
+	metrics := pmetric.NewMetrics()
+	ms := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	var dpsAttrs []pcommon.Map
+	dpsAttrs = append(dpsAttrs, ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().Attributes())
+	dpsAttrs = append(dpsAttrs, ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty().Attributes())
+	dpsAttrs = append(dpsAttrs, ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().Attributes())
+	dpsAttrs = append(dpsAttrs, ms.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Attributes())
+	dpsAttrs = append(dpsAttrs, ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().Attributes())
+	for _, attrs := range dpsAttrs {
+		attrs.PutStr("name", "placeholder 4111111111111111")
+		attrs.PutStr("credit_card", "4111111111111111")
+	}
+
+	// test
+	ctx := context.Background()
+	processor, err := newRedaction(ctx, config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	_, err = processor.processMetrics(ctx, metrics)
+	require.NoError(t, err)
+
+	// verify
+	for _, attrs := range dpsAttrs {
+		_, found := attrs.Get("credit_card")
+		assert.False(t, found)
+		val, _ := attrs.Get("name")
+		assert.Equal(t, "placeholder ****", val.Str())
+		val, _ = attrs.Get(redactedKeyCount)
+		assert.Equal(t, int64(1), val.Int())
+		val, _ = attrs.Get(maskedValueCount)
+		assert.Equal(t, int64(1), val.Int())
+	}
+}
+
+// TestPseudonymizeValues validates that the processor replaces the values
+// matching the blocked value rules with consistent pseudonyms
+func TestPseudonymizeValues(t *testing.T) {
+	config := &Config{
+		AllowAllKeys: true,
+		HashKey:      "secret",
+		BlockedValueRules: []BlockedValueRule{
+			{Pattern: "[a-z0-9.]+@[a-z0-9.]+", Replacement: replacementHMAC},
+			{Pattern: "(?:[0-9]{1,3}\\.){3}[0-9]{1,3}", Replacement: replacementToken},
+			{Pattern: "4[0-9]{15}"},
+		},
+		Summary: "debug",
+	}
+	allowed := map[string]pcommon.Value{
+		"id": pcommon.NewValueInt(5),
+	}
+	masked := map[string]pcommon.Value{
+		"email":       pcommon.NewValueStr("contact: john.doe@example.com"),
+		"other_email": pcommon.NewValueStr("john.doe@example.com"),
+		"client_ip":   pcommon.NewValueStr("192.168.0.1"),
+		"card":        pcommon.NewValueStr("4111111111111111"),
+	}
+
+	outTraces := runTest(t, allowed, nil, masked, nil, config)
+
+	attr := outTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	email, _ := attr.Get("email")
+	otherEmail, _ := attr.Get("other_email")
+	assert.Regexp(t, "^contact: [0-9a-f]{64}$", email.Str())
+	assert.Equal(t, "contact: "+otherEmail.Str(), email.Str())
+
+	clientIP, _ := attr.Get("client_ip")
+	assert.Regexp(t, "^[0-9]{3}\\.[0-9]{3}\\.[0-9]\\.[0-9]$", clientIP.Str())
+	assert.NotEqual(t, "192.168.0.1", clientIP.Str())
+
+	card, _ := attr.Get("card")
+	assert.Equal(t, "****", card.Str())
+
+	maskedKeys, _ := attr.Get(maskedValues)
+	assert.Equal(t, "card,client_ip,email,other_email", maskedKeys.Str())
+}
+
+// TestPseudonymizersAreKeyed validates that the pseudonyms are consistent for
+// the same key, and differ across keys
+func TestPseudonymizersAreKeyed(t *testing.T) {
+	for _, newPseudonymizer := range []func([]byte) func(string) string{newHMACPseudonymizer, newTokenPseudonymizer} {
+		pseudonymize := newPseudonymizer([]byte("secret"))
+		assert.Equal(t, pseudonymize("john.doe@example.com"), pseudonymize("john.doe@example.com"))
+		assert.NotEqual(t, pseudonymize("john.doe@example.com"), pseudonymize("jane.doe@example.com"))
+
+		otherPseudonymize := newPseudonymizer([]byte("other secret"))
+		assert.NotEqual(t, pseudonymize("john.doe@example.com"), otherPseudonymize("john.doe@example.com"))
+	}
+}
+
+// TestTokenPseudonymizerKeepsFormat validates that tokens keep the length,
+// character classes and separators of the original value
+func TestTokenPseudonymizerKeepsFormat(t *testing.T) {
+	pseudonymize := newTokenPseudonymizer([]byte("secret"))
+	value := strings.Repeat("John.Doe-42@Example.com/", 10)
+
+	token := pseudonymize(value)
+
+	require.Len(t, token, len(value))
+	for i := range value {
+		switch c := value[i]; {
+		case c >= '0' && c <= '9':
+			assert.True(t, token[i] >= '0' && token[i] <= '9')
+		case c >= 'a' && c <= 'z':
+			assert.True(t, token[i] >= 'a' && token[i] <= 'z')
+		case c >= 'A' && c <= 'Z':
+			assert.True(t, token[i] >= 'A' && token[i] <= 'Z')
+		default:
+			assert.Equal(t, c, token[i])
+		}
+	}
+}
+
 // runTest transforms the test input data and passes it through the processor
 func runTest(
 	t *testing.T,
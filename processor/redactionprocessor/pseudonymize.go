@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+)
+
+// mask replaces the value with a fixed length of asterisks
+func mask(string) string {
+	return "****"
+}
+
+// newHMACPseudonymizer returns a function replacing a value with its
+// HMAC-SHA256, keyed with the given key and encoded as hex
+func newHMACPseudonymizer(key []byte) func(string) string {
+	return func(value string) string {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(value))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+}
+
+// newTokenPseudonymizer returns a function replacing a value with a token
+// derived from its keyed HMAC-SHA256. The token keeps the format of the value:
+// digits are replaced with digits, lowercase and uppercase letters with
+// letters of the same case, and any other character is kept as is. For
+// instance, `john.doe@example.com` becomes something like
+// `wqkd.nri@tlbzvfa.bmx`, and `192.168.0.1` something like `604.913.7.5`.
+func newTokenPseudonymizer(key []byte) func(string) string {
+	return func(value string) string {
+		stream := newKeyStream(key, value)
+		token := []byte(value)
+		for i, c := range token {
+			switch {
+			case c >= '0' && c <= '9':
+				token[i] = '0' + stream.next()%10
+			case c >= 'a' && c <= 'z':
+				token[i] = 'a' + stream.next()%26
+			case c >= 'A' && c <= 'Z':
+				token[i] = 'A' + stream.next()%26
+			}
+		}
+		return string(token)
+	}
+}
+
+// keyStream is a deterministic stream of bytes derived from a key and a value,
+// made of the HMAC-SHA256 of the value prefixed with an increasing counter
+type keyStream struct {
+	mac     hash.Hash
+	value   []byte
+	counter uint64
+	block   []byte
+}
+
+func newKeyStream(key []byte, value string) *keyStream {
+	return &keyStream{
+		mac:   hmac.New(sha256.New, key),
+		value: []byte(value),
+	}
+}
+
+func (ks *keyStream) next() byte {
+	if len(ks.block) == 0 {
+		var counter [8]byte
+		binary.BigEndian.PutUint64(counter[:], ks.counter)
+		ks.counter++
+
+		ks.mac.Reset()
+		ks.mac.Write(counter[:])
+		ks.mac.Write(ks.value)
+		ks.block = ks.mac.Sum(nil)
+	}
+	b := ks.block[0]
+	ks.block = ks.block[1:]
+	return b
+}
@@ -6,6 +6,8 @@ package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry
 import (
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -230,4 +232,26 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// PolicyFile is the path to a YAML file holding the policies under a "policies" key. When set, the
+	// policies from the file replace the ones from PolicyCfgs, and are reloaded whenever the file changes,
+	// without losing the traces kept in memory.
+	PolicyFile string `mapstructure:"policy_file"`
+	// DecisionCache holds the settings of the caches remembering the sampling decisions
+	// of traces that are no longer kept in memory.
+	DecisionCache DecisionCacheConfig `mapstructure:"decision_cache"`
+}
+
+// DecisionCacheConfig holds the settings of the caches remembering the sampling decisions.
+// Spans arriving after a decision was taken for their trace are given the same decision,
+// as long as the trace ID is still in the corresponding cache.
+type DecisionCacheConfig struct {
+	// SampledCacheSize is the maximum number of sampled trace IDs to remember.
+	// Zero disables the cache.
+	SampledCacheSize int `mapstructure:"sampled_cache_size"`
+	// NonSampledCacheSize is the maximum number of not sampled trace IDs to remember.
+	// Zero disables the cache.
+	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+	// StorageID is the ID of a storage extension used to keep the cached decisions
+	// across restarts of the collector. The decisions are saved on shutdown and loaded on start.
+	StorageID *component.ID `mapstructure:"storage"`
 }
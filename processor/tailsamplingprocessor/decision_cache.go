@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+)
+
+const (
+	sampledCacheKey    = "sampled_trace_ids"
+	nonSampledCacheKey = "non_sampled_trace_ids"
+)
+
+var errInvalidCachedTraceIDs = errors.New("invalid trace IDs in the decision cache storage")
+
+// loadDecisionCaches obtains the storage client and fills the decision caches with the
+// trace IDs saved by a previous run of the processor.
+func (tsp *tailSamplingSpanProcessor) loadDecisionCaches(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[*tsp.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension %q not found", tsp.storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension %q found", tsp.storageID)
+	}
+
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, tsp.componentID, "")
+	if err != nil {
+		return err
+	}
+
+	for key, c := range map[string]*cache.LRU{sampledCacheKey: tsp.sampledIDCache, nonSampledCacheKey: tsp.nonSampledIDCache} {
+		buf, err := client.Get(ctx, key)
+		if err != nil {
+			return multierr.Append(err, client.Close(ctx))
+		}
+		ids, err := decodeTraceIDs(buf)
+		if err != nil {
+			return multierr.Append(err, client.Close(ctx))
+		}
+		for _, id := range ids {
+			c.Put(id)
+		}
+	}
+
+	tsp.logger.Debug("Loaded the decision caches",
+		zap.Int("sampled", tsp.sampledIDCache.Len()),
+		zap.Int("notSampled", tsp.nonSampledIDCache.Len()))
+
+	tsp.storageClient = client
+	return nil
+}
+
+// saveDecisionCaches saves the trace IDs of the decision caches and closes the storage client.
+func (tsp *tailSamplingSpanProcessor) saveDecisionCaches(ctx context.Context) error {
+	err := tsp.storageClient.Batch(ctx,
+		storage.SetOperation(sampledCacheKey, encodeTraceIDs(tsp.sampledIDCache.Keys())),
+		storage.SetOperation(nonSampledCacheKey, encodeTraceIDs(tsp.nonSampledIDCache.Keys())),
+	)
+	err = multierr.Append(err, tsp.storageClient.Close(ctx))
+	tsp.storageClient = nil
+	return err
+}
+
+func encodeTraceIDs(ids []pcommon.TraceID) []byte {
+	buf := make([]byte, 0, len(ids)*len(pcommon.TraceID{}))
+	for _, id := range ids {
+		buf = append(buf, id[:]...)
+	}
+	return buf
+}
+
+func decodeTraceIDs(buf []byte) ([]pcommon.TraceID, error) {
+	size := len(pcommon.TraceID{})
+	if len(buf)%size != 0 {
+		return nil, errInvalidCachedTraceIDs
+	}
+
+	ids := make([]pcommon.TraceID, 0, len(buf)/size)
+	for i := 0; i < len(buf); i += size {
+		var id pcommon.TraceID
+		copy(id[:], buf[i:i+size])
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
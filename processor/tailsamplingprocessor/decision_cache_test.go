@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func TestLateSpansAfterTraceDroppedGetCachedDecision(t *testing.T) {
+	const maxSize = 1
+	nextConsumer := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{}
+	tsp := newTestDecisionCacheProcessor(nextConsumer, mpe, maxSize)
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	sampledID := uInt64ToTraceID(1)
+	notSampledID := uInt64ToTraceID(2)
+
+	// the first trace is sampled
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), singleSpanTraces(sampledID, 1)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 1, nextConsumer.SpanCount())
+
+	// the second trace isn't sampled, and drops the first one from memory
+	mpe.NextDecision = sampling.NotSampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), singleSpanTraces(notSampledID, 2)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	_, ok := tsp.idToTrace.Load(sampledID)
+	require.False(t, ok)
+	require.EqualValues(t, 2, mpe.EvaluationCount)
+
+	// a late span for the first trace keeps the original decision, without being evaluated again
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), singleSpanTraces(sampledID, 3)))
+	assert.EqualValues(t, 2, nextConsumer.SpanCount())
+	_, ok = tsp.idToTrace.Load(sampledID)
+	assert.False(t, ok)
+
+	// drop the second trace from memory, its late span is still dropped
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), singleSpanTraces(uInt64ToTraceID(3), 4)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), singleSpanTraces(notSampledID, 5)))
+	assert.EqualValues(t, 2, nextConsumer.SpanCount())
+	_, ok = tsp.idToTrace.Load(notSampledID)
+	assert.False(t, ok)
+	assert.EqualValues(t, 2, mpe.EvaluationCount)
+}
+
+func TestDecisionCachesSurviveRestarts(t *testing.T) {
+	storageDir := t.TempDir()
+	storageID := storagetest.NewStorageID("test")
+	sampledID := uInt64ToTraceID(1)
+	notSampledID := uInt64ToTraceID(2)
+
+	// first run, taking the decisions
+	ext := storagetest.NewFileBackedStorageExtension("test", storageDir)
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+	tsp := newTestDecisionCacheProcessor(new(consumertest.TracesSink), &mockPolicyEvaluator{}, 10)
+	tsp.storageID = &storageID
+	require.NoError(t, tsp.Start(context.Background(), host))
+	tsp.cacheDecision(sampledID, sampling.Sampled)
+	tsp.cacheDecision(notSampledID, sampling.NotSampled)
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	// second run, applying the decisions to late spans
+	nextConsumer := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{}
+	ext = storagetest.NewFileBackedStorageExtension("test", storageDir)
+	host = storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+	tsp = newTestDecisionCacheProcessor(nextConsumer, mpe, 10)
+	tsp.storageID = &storageID
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), singleSpanTraces(sampledID, 1)))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), singleSpanTraces(notSampledID, 2)))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+
+	assert.EqualValues(t, 1, nextConsumer.SpanCount())
+	assert.EqualValues(t, 0, mpe.EvaluationCount)
+}
+
+func TestDecisionCacheStorageNotFound(t *testing.T) {
+	storageID := storagetest.NewStorageID("test")
+	tsp := newTestDecisionCacheProcessor(new(consumertest.TracesSink), &mockPolicyEvaluator{}, 10)
+	tsp.storageID = &storageID
+
+	assert.Error(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestDecodeInvalidTraceIDs(t *testing.T) {
+	_, err := decodeTraceIDs([]byte{1, 2, 3})
+	assert.ErrorIs(t, err, errInvalidCachedTraceIDs)
+
+	ids, err := decodeTraceIDs(encodeTraceIDs([]pcommon.TraceID{{1}, {2}}))
+	require.NoError(t, err)
+	assert.Equal(t, []pcommon.TraceID{{1}, {2}}, ids)
+}
+
+func newTestDecisionCacheProcessor(nextConsumer *consumertest.TracesSink, mpe *mockPolicyEvaluator, maxSize uint64) *tailSamplingSpanProcessor {
+	return &tailSamplingSpanProcessor{
+		ctx:               context.Background(),
+		nextConsumer:      nextConsumer,
+		maxNumTraces:      maxSize,
+		logger:            zap.NewNop(),
+		decisionBatcher:   newSyncIDBatcher(1),
+		policies:          []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:        make(chan pcommon.TraceID, maxSize),
+		policyTicker:      &manualTTicker{},
+		tickerFrequency:   100 * time.Millisecond,
+		numTracesOnMap:    &atomic.Uint64{},
+		sampledIDCache:    cache.NewLRU(10),
+		nonSampledIDCache: cache.NewLRU(10),
+		componentID:       component.NewID("tail_sampling"),
+	}
+}
+
+func singleSpanTraces(traceID pcommon.TraceID, spanIndex uint64) ptrace.Traces {
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID(uInt64ToSpanID(spanIndex))
+	return traces
+}
@@ -48,5 +48,5 @@ func createTracesProcessor(
 	nextConsumer consumer.Traces,
 ) (processor.Traces, error) {
 	tCfg := cfg.(*Config)
-	return newTracesProcessor(ctx, params.TelemetrySettings, nextConsumer, *tCfg)
+	return newTracesProcessorWithID(ctx, params.TelemetrySettings, params.ID, nextConsumer, *tCfg)
 }
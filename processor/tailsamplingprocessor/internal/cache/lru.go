@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache defines a bounded cache of trace IDs, used to remember the
+// sampling decisions taken for traces that are no longer held in memory.
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import (
+	"container/list"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// LRU is a set of trace IDs holding at most a fixed number of items. When the
+// cache is full, adding a new ID evicts the least recently used one. It is safe
+// for concurrent use.
+type LRU struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[pcommon.TraceID]*list.Element
+}
+
+// NewLRU creates a cache holding up to size trace IDs. A cache with a size of
+// zero doesn't hold any ID.
+func NewLRU(size int) *LRU {
+	return &LRU{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[pcommon.TraceID]*list.Element),
+	}
+}
+
+// Contains returns whether the ID is in the cache, marking it as recently used.
+func (c *LRU) Contains(id pcommon.TraceID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[id]
+	if ok {
+		c.ll.MoveToFront(elem)
+	}
+	return ok
+}
+
+// Put adds the ID to the cache, evicting the least recently used ID if the
+// cache is full.
+func (c *LRU) Put(id pcommon.TraceID) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[id]; ok {
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	c.items[id] = c.ll.PushFront(id)
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(pcommon.TraceID))
+	}
+}
+
+// Delete removes the ID from the cache.
+func (c *LRU) Delete(id pcommon.TraceID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[id]; ok {
+		c.ll.Remove(elem)
+		delete(c.items, id)
+	}
+}
+
+// Keys returns the IDs in the cache, from the least to the most recently used.
+// Putting them in a new cache in this order keeps the eviction order.
+func (c *LRU) Keys() []pcommon.TraceID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]pcommon.TraceID, 0, c.ll.Len())
+	for elem := c.ll.Back(); elem != nil; elem = elem.Prev() {
+		keys = append(keys, elem.Value.(pcommon.TraceID))
+	}
+	return keys
+}
+
+// Len returns the number of IDs in the cache.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU(2)
+	id1 := pcommon.TraceID([16]byte{1})
+	id2 := pcommon.TraceID([16]byte{2})
+	id3 := pcommon.TraceID([16]byte{3})
+
+	c.Put(id1)
+	c.Put(id2)
+	// id1 becomes the most recently used
+	assert.True(t, c.Contains(id1))
+	c.Put(id3)
+
+	assert.True(t, c.Contains(id1))
+	assert.False(t, c.Contains(id2))
+	assert.True(t, c.Contains(id3))
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestLRUDelete(t *testing.T) {
+	c := NewLRU(2)
+	id := pcommon.TraceID([16]byte{1})
+
+	c.Put(id)
+	c.Delete(id)
+
+	assert.False(t, c.Contains(id))
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestLRUKeysKeepEvictionOrder(t *testing.T) {
+	c := NewLRU(3)
+	ids := []pcommon.TraceID{{1}, {2}, {3}}
+	for _, id := range ids {
+		c.Put(id)
+	}
+	assert.Equal(t, ids, c.Keys())
+
+	restored := NewLRU(3)
+	for _, id := range c.Keys() {
+		restored.Put(id)
+	}
+	assert.Equal(t, c.Keys(), restored.Keys())
+}
+
+func TestLRUWithoutSize(t *testing.T) {
+	c := NewLRU(0)
+	id := pcommon.TraceID([16]byte{1})
+
+	c.Put(id)
+
+	assert.False(t, c.Contains(id))
+	assert.Equal(t, 0, c.Len())
+}
@@ -24,6 +24,7 @@ var (
 
 	statTraceRemovalAgeSec           = stats.Int64("sampling_trace_removal_age", "Time (in seconds) from arrival of a new trace until its removal from memory", "s")
 	statLateSpanArrivalAfterDecision = stats.Int64("sampling_late_span_age", "Time (in seconds) from the sampling decision was taken and the arrival of a late span", "s")
+	statLateSpanArrivalCount         = stats.Int64("sampling_late_spans", "Count of spans arriving after the sampling decision for their trace was taken", stats.UnitDimensionless)
 
 	statPolicyEvaluationErrorCount = stats.Int64("sampling_policy_evaluation_error", "Count of sampling policy evaluation errors", stats.UnitDimensionless)
 
@@ -73,6 +74,14 @@ func samplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Aggregation: ageDistributionAggregation,
 	}
 
+	countLateSpanArrivalView := &view.View{
+		Name:        processorhelper.BuildCustomMetricName(metadata.Type, statLateSpanArrivalCount.Name()),
+		Measure:     statLateSpanArrivalCount,
+		Description: statLateSpanArrivalCount.Description(),
+		TagKeys:     []tag.Key{tagSampledKey},
+		Aggregation: view.Sum(),
+	}
+
 	countPolicyEvaluationErrorView := &view.View{
 		Name:        processorhelper.BuildCustomMetricName(metadata.Type, statPolicyEvaluationErrorCount.Name()),
 		Measure:     statPolicyEvaluationErrorCount,
@@ -122,6 +131,7 @@ func samplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 
 		traceRemovalAgeView,
 		lateSpanArrivalView,
+		countLateSpanArrivalView,
 
 		countPolicyEvaluationErrorView,
 
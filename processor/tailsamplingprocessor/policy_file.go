@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+var errNoPoliciesInFile = errors.New("no policies found in the policy file")
+
+// policyFile is the content expected from the file set as the policy_file.
+type policyFile struct {
+	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+}
+
+// readPolicyFile reads the policy configurations from the given file.
+func readPolicyFile(path string) ([]PolicyCfg, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- the path is part of the collector configuration
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	if err = yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("invalid policy file %q: %w", path, err)
+	}
+
+	var pf policyFile
+	if err = confmap.NewFromStringMap(raw).Unmarshal(&pf, confmap.WithErrorUnused()); err != nil {
+		return nil, fmt.Errorf("invalid policy file %q: %w", path, err)
+	}
+	if len(pf.PolicyCfgs) == 0 {
+		return nil, fmt.Errorf("%w: %q", errNoPoliciesInFile, path)
+	}
+
+	return pf.PolicyCfgs, nil
+}
+
+// reloadPolicies replaces the policies with the ones from the policy file. Traces for which a decision
+// was already made aren't affected, while the pending ones are evaluated by the new policies.
+// In case of errors, the current policies are kept.
+func (tsp *tailSamplingSpanProcessor) reloadPolicies() error {
+	cfgs, err := readPolicyFile(tsp.policyFile)
+	if err != nil {
+		return err
+	}
+
+	policies, err := buildPolicies(tsp.ctx, tsp.settings, cfgs)
+	if err != nil {
+		return err
+	}
+
+	tsp.policiesLock.Lock()
+	tsp.policies = policies
+	tsp.policiesLock.Unlock()
+
+	tsp.logger.Info("Sampling policies loaded", zap.String("file", tsp.policyFile), zap.Int("policies", len(policies)))
+	return nil
+}
+
+// watchPolicyFile loads the policies from the policy file and reloads them whenever the file changes.
+// The directory of the file is watched rather than the file itself, so that changes are still noticed
+// after the file is replaced, e.g. by editors renaming a new file over it, or when k8s configmaps are
+// updated by swapping the symlink the file resolves through.
+func (tsp *tailSamplingSpanProcessor) watchPolicyFile() error {
+	if err := tsp.reloadPolicies(); err != nil {
+		return err
+	}
+
+	path := filepath.Clean(tsp.policyFile)
+	target, _ := filepath.EvalSymlinks(path)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+	tsp.policyWatcher = watcher
+
+	tsp.policyWatcherWg.Add(1)
+	go func() {
+		defer tsp.policyWatcherWg.Done()
+		for event := range watcher.Events {
+			// the file is reloaded when it's written or created, which includes being the destination
+			// of a rename, or when it resolves to a different file than before
+			current, _ := filepath.EvalSymlinks(path)
+			changed := filepath.Clean(event.Name) == path && event.Op&(fsnotify.Write|fsnotify.Create) != 0
+			if current != "" && current != target {
+				target = current
+				changed = true
+			}
+			if !changed {
+				continue
+			}
+			if err := tsp.reloadPolicies(); err != nil {
+				tsp.logger.Error("Failed to reload the sampling policies, keeping the current ones", zap.String("file", tsp.policyFile), zap.Error(err))
+			}
+		}
+	}()
+
+	// errors from the watcher are only logged, as they don't affect the current policies
+	tsp.policyWatcherWg.Add(1)
+	go func() {
+		defer tsp.policyWatcherWg.Done()
+		for err := range watcher.Errors {
+			tsp.logger.Warn("Error while watching the policy file", zap.String("file", tsp.policyFile), zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+// stopWatchingPolicyFile stops reloading the policies when the policy file changes.
+func (tsp *tailSamplingSpanProcessor) stopWatchingPolicyFile() {
+	if tsp.policyWatcher == nil {
+		return
+	}
+	if err := tsp.policyWatcher.Close(); err != nil {
+		tsp.logger.Warn("Failed to stop watching the policy file", zap.Error(err))
+	}
+	tsp.policyWatcherWg.Wait()
+	tsp.policyWatcher = nil
+}
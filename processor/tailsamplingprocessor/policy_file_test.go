@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+const alwaysSamplePolicyFile = `
+policies:
+  - name: everything
+    type: always_sample
+`
+
+const twoPoliciesFile = `
+policies:
+  - name: errors
+    type: status_code
+    status_code:
+      status_codes: [ERROR]
+  - name: slow
+    type: latency
+    latency:
+      threshold_ms: 5000
+`
+
+func TestReadPolicyFile(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		content       string
+		expectedNames []string
+		expectedErr   error
+	}{
+		{
+			name:          "single policy",
+			content:       alwaysSamplePolicyFile,
+			expectedNames: []string{"everything"},
+		},
+		{
+			name:          "multiple policies",
+			content:       twoPoliciesFile,
+			expectedNames: []string{"errors", "slow"},
+		},
+		{
+			name:        "no policies",
+			content:     "policies: []",
+			expectedErr: errNoPoliciesInFile,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writePolicyFile(t, t.TempDir(), tt.content)
+
+			cfgs, err := readPolicyFile(path)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, cfg := range cfgs {
+				names = append(names, cfg.Name)
+			}
+			assert.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
+
+func TestReadInvalidPolicyFile(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := readPolicyFile(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+
+	_, err = readPolicyFile(writePolicyFile(t, dir, "policies: [\n"))
+	assert.Error(t, err)
+
+	_, err = readPolicyFile(writePolicyFile(t, dir, "unknown_key: true\npolicies: []"))
+	assert.Error(t, err)
+}
+
+func TestPoliciesReloadedWhenFileChanges(t *testing.T) {
+	// prepare
+	path := writePolicyFile(t, t.TempDir(), alwaysSamplePolicyFile)
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    100,
+		PolicyFile:   path,
+	}
+	sp, err := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	tsp := sp.(*tailSamplingSpanProcessor)
+
+	require.NoError(t, sp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, sp.Shutdown(context.Background()))
+	}()
+	assert.Equal(t, []string{"everything"}, policyNames(tsp))
+
+	// test
+	writePolicyFile(t, filepath.Dir(path), twoPoliciesFile)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"errors", "slow"}, policyNames(tsp))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// test
+	writePolicyFile(t, filepath.Dir(path), "policies: [\n")
+
+	// verify: the invalid content is ignored
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, []string{"errors", "slow"}, policyNames(tsp))
+}
+
+func TestPoliciesReloadedWhenFileReplaced(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	path := writePolicyFile(t, dir, alwaysSamplePolicyFile)
+	tsp := startPolicyFileProcessor(t, path)
+	assert.Equal(t, []string{"everything"}, policyNames(tsp))
+
+	// test: the file is replaced by renaming a new one over it, as editors saving atomically do
+	tmp := filepath.Join(dir, "policies.yaml.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte(twoPoliciesFile), 0600))
+	require.NoError(t, os.Rename(tmp, path))
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"errors", "slow"}, policyNames(tsp))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// test: the replaced file is still watched
+	require.NoError(t, os.WriteFile(tmp, []byte(alwaysSamplePolicyFile), 0600))
+	require.NoError(t, os.Rename(tmp, path))
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"everything"}, policyNames(tsp))
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestPoliciesReloadedWhenSymlinkSwapped(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require elevated privileges on windows")
+	}
+
+	// prepare: the layout of a k8s configmap volume, where the file resolves through the ..data symlink
+	dir := t.TempDir()
+	writePolicyFile(t, filepath.Join(dir, "v1"), alwaysSamplePolicyFile)
+	writePolicyFile(t, filepath.Join(dir, "v2"), twoPoliciesFile)
+	require.NoError(t, os.Symlink("v1", filepath.Join(dir, "..data")))
+	path := filepath.Join(dir, "policies.yaml")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "policies.yaml"), path))
+	tsp := startPolicyFileProcessor(t, path)
+	assert.Equal(t, []string{"everything"}, policyNames(tsp))
+
+	// test: the ..data symlink is atomically replaced
+	require.NoError(t, os.Symlink("v2", filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"errors", "slow"}, policyNames(tsp))
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestInvalidPolicyFileFailsStart(t *testing.T) {
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    100,
+		PolicyFile:   filepath.Join(t.TempDir(), "missing.yaml"),
+	}
+	sp, err := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+
+	assert.Error(t, sp.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, sp.Shutdown(context.Background()))
+}
+
+func TestPendingTraceEvaluatedByReloadedPolicies(t *testing.T) {
+	// prepare
+	mpe1 := &mockPolicyEvaluator{NextDecision: sampling.NotSampled}
+	mpe2 := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	tsp := &tailSamplingSpanProcessor{
+		ctx:    context.Background(),
+		logger: zap.NewNop(),
+		policies: []*policy{
+			{name: "policy-1", evaluator: mpe1, ctx: context.TODO()},
+			{name: "policy-2", evaluator: mpe2, ctx: context.TODO()},
+		},
+	}
+
+	// the trace arrived when there was a single policy
+	spanCount := &atomic.Int64{}
+	spanCount.Store(1)
+	trace := &sampling.TraceData{
+		Decisions: []sampling.Decision{sampling.Pending},
+		SpanCount: spanCount,
+	}
+
+	// test
+	decision, matching := tsp.makeDecision(pcommon.TraceID([16]byte{1, 2, 3, 4}), trace, &policyMetrics{})
+
+	// verify
+	assert.Equal(t, sampling.Sampled, decision)
+	assert.Equal(t, "policy-2", matching.name)
+	assert.Len(t, trace.Decisions, 2)
+	assert.Equal(t, 1, mpe1.EvaluationCount)
+	assert.Equal(t, 1, mpe2.EvaluationCount)
+}
+
+// startPolicyFileProcessor starts a processor loading its policies from the given file.
+func startPolicyFileProcessor(t *testing.T, path string) *tailSamplingSpanProcessor {
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    100,
+		PolicyFile:   path,
+	}
+	sp, err := newTracesProcessor(context.Background(), componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, sp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, sp.Shutdown(context.Background()))
+	})
+	return sp.(*tailSamplingSpanProcessor)
+}
+
+func writePolicyFile(t *testing.T, dir string, content string) string {
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	path := filepath.Join(dir, "policies.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func policyNames(tsp *tailSamplingSpanProcessor) []string {
+	tsp.policiesLock.RLock()
+	defer tsp.policiesLock.RUnlock()
+
+	var names []string
+	for _, p := range tsp.policies {
+		names = append(names, p.name)
+	}
+	return names
+}
@@ -12,17 +12,21 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
 
@@ -44,6 +48,8 @@ type tailSamplingSpanProcessor struct {
 	nextConsumer    consumer.Traces
 	maxNumTraces    uint64
 	policies        []*policy
+	policiesLock    sync.RWMutex
+	settings        component.TelemetrySettings
 	logger          *zap.Logger
 	idToTrace       sync.Map
 	policyTicker    timeutils.TTicker
@@ -51,6 +57,19 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+
+	// caches remembering the decisions of traces that are no longer on idToTrace
+	sampledIDCache    *cache.LRU
+	nonSampledIDCache *cache.LRU
+	// storage used to keep the decision caches across restarts
+	componentID   component.ID
+	storageID     *component.ID
+	storageClient storage.Client
+
+	// file holding the policies, which are reloaded whenever it changes
+	policyFile      string
+	policyWatcher   *fsnotify.Watcher
+	policyWatcherWg sync.WaitGroup
 }
 
 // spanAndScope a structure for holding information about span and its instrumentation scope.
@@ -68,34 +87,19 @@ const (
 // newTracesProcessor returns a processor.TracesProcessor that will perform tail sampling according to the given
 // configuration.
 func newTracesProcessor(ctx context.Context, settings component.TelemetrySettings, nextConsumer consumer.Traces, cfg Config) (processor.Traces, error) {
+	return newTracesProcessorWithID(ctx, settings, component.NewID(metadata.Type), nextConsumer, cfg)
+}
+
+// newTracesProcessorWithID returns a processor.TracesProcessor identified by the given ID, which is used
+// to obtain the client of the storage extension holding the decision caches.
+func newTracesProcessorWithID(ctx context.Context, settings component.TelemetrySettings, id component.ID, nextConsumer consumer.Traces, cfg Config) (processor.Traces, error) {
 	if nextConsumer == nil {
 		return nil, component.ErrNilNextConsumer
 	}
 
-	policyNames := map[string]bool{}
-	policies := make([]*policy, len(cfg.PolicyCfgs))
-	for i := range cfg.PolicyCfgs {
-		policyCfg := &cfg.PolicyCfgs[i]
-
-		if policyNames[policyCfg.Name] {
-			return nil, fmt.Errorf("duplicate policy name %q", policyCfg.Name)
-		}
-		policyNames[policyCfg.Name] = true
-
-		policyCtx, err := tag.New(ctx, tag.Upsert(tagPolicyKey, policyCfg.Name), tag.Upsert(tagSourceFormat, sourceFormat))
-		if err != nil {
-			return nil, err
-		}
-		eval, err := getPolicyEvaluator(settings, policyCfg)
-		if err != nil {
-			return nil, err
-		}
-		p := &policy{
-			name:      policyCfg.Name,
-			evaluator: eval,
-			ctx:       policyCtx,
-		}
-		policies[i] = p
+	policies, err := buildPolicies(ctx, settings, cfg.PolicyCfgs)
+	if err != nil {
+		return nil, err
 	}
 
 	// this will start a goroutine in the background, so we run it only if everything went
@@ -110,11 +114,19 @@ func newTracesProcessor(ctx context.Context, settings component.TelemetrySetting
 		ctx:             ctx,
 		nextConsumer:    nextConsumer,
 		maxNumTraces:    cfg.NumTraces,
+		settings:        settings,
 		logger:          settings.Logger,
 		decisionBatcher: inBatcher,
 		policies:        policies,
 		tickerFrequency: time.Second,
 		numTracesOnMap:  &atomic.Uint64{},
+
+		sampledIDCache:    cache.NewLRU(cfg.DecisionCache.SampledCacheSize),
+		nonSampledIDCache: cache.NewLRU(cfg.DecisionCache.NonSampledCacheSize),
+		componentID:       id,
+		storageID:         cfg.DecisionCache.StorageID,
+
+		policyFile: cfg.PolicyFile,
 	}
 
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
@@ -123,6 +135,37 @@ func newTracesProcessor(ctx context.Context, settings component.TelemetrySetting
 	return tsp, nil
 }
 
+// buildPolicies creates the policies for the given configuration, making sure the policy names are unique.
+func buildPolicies(ctx context.Context, settings component.TelemetrySettings, cfgs []PolicyCfg) ([]*policy, error) {
+	policyNames := map[string]bool{}
+	policies := make([]*policy, len(cfgs))
+	for i := range cfgs {
+		policyCfg := &cfgs[i]
+
+		if policyNames[policyCfg.Name] {
+			return nil, fmt.Errorf("duplicate policy name %q", policyCfg.Name)
+		}
+		policyNames[policyCfg.Name] = true
+
+		policyCtx, err := tag.New(ctx, tag.Upsert(tagPolicyKey, policyCfg.Name), tag.Upsert(tagSourceFormat, sourceFormat))
+		if err != nil {
+			return nil, err
+		}
+		eval, err := getPolicyEvaluator(settings, policyCfg)
+		if err != nil {
+			return nil, err
+		}
+		p := &policy{
+			name:      policyCfg.Name,
+			evaluator: eval,
+			ctx:       policyCtx,
+		}
+		policies[i] = p
+	}
+
+	return policies, nil
+}
+
 func getPolicyEvaluator(settings component.TelemetrySettings, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
 	switch cfg.Type {
 	case Composite:
@@ -205,6 +248,8 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.ReceivedBatches = ptrace.NewTraces()
 		trace.Unlock()
 
+		tsp.cacheDecision(id, decision)
+
 		if decision == sampling.Sampled {
 			_ = tsp.nextConsumer.ConsumeTraces(policy.ctx, allSpans)
 		}
@@ -236,8 +281,17 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		sampling.InvertNotSampled: false,
 	}
 
+	// The policies might be replaced while the decision is being made, so we stick to the ones
+	// in place at this moment. Traces that arrived before a reload get their decisions resized.
+	tsp.policiesLock.RLock()
+	policies := tsp.policies
+	tsp.policiesLock.RUnlock()
+	if len(trace.Decisions) != len(policies) {
+		trace.Decisions = make([]sampling.Decision, len(policies))
+	}
+
 	// Check all policies before making a final decision
-	for i, p := range tsp.policies {
+	for i, p := range policies {
 		policyEvaluateStartTime := time.Now()
 		decision, err := p.evaluator.Evaluate(p.ctx, id, trace)
 		stats.Record(
@@ -279,7 +333,7 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		finalDecision = sampling.Sampled
 	}
 
-	for i, p := range tsp.policies {
+	for i, p := range policies {
 		switch trace.Decisions[i] {
 		case sampling.Sampled:
 			// any single policy that decides to sample will cause the decision to be sampled
@@ -358,13 +412,22 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	var newTraceIDs int64
 	for id, spans := range idToSpansAndScope {
 		lenSpans := int64(len(spans))
+		tsp.policiesLock.RLock()
 		lenPolicies := len(tsp.policies)
+		tsp.policiesLock.RUnlock()
 		initialDecisions := make([]sampling.Decision, lenPolicies)
 		for i := 0; i < lenPolicies; i++ {
 			initialDecisions[i] = sampling.Pending
 		}
 		d, loaded := tsp.idToTrace.Load(id)
 		if !loaded {
+			// The trace might have been decided and dropped from the map already,
+			// in which case the spans get the original decision
+			if decision, ok := tsp.cachedDecision(id); ok {
+				tsp.releaseLateSpans(decision, resourceSpans, spans)
+				continue
+			}
+
 			spanCount := &atomic.Int64{}
 			spanCount.Store(lenSpans)
 			d, loaded = tsp.idToTrace.LoadOrStore(id, &sampling.TraceData{
@@ -405,42 +468,97 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 		} else {
 			actualData.Unlock()
 
-			switch finalDecision {
-			case sampling.Sampled:
-				// Forward the spans to the policy destinations
-				traceTd := ptrace.NewTraces()
-				appendToTraces(traceTd, resourceSpans, spans)
-				if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, traceTd); err != nil {
-					tsp.logger.Warn(
-						"Error sending late arrived spans to destination",
-						zap.Error(err))
-				}
-			case sampling.NotSampled:
+			if finalDecision == sampling.NotSampled {
 				stats.Record(tsp.ctx, statLateSpanArrivalAfterDecision.M(int64(time.Since(actualData.DecisionTime)/time.Second)))
-			default:
-				tsp.logger.Warn("Encountered unexpected sampling decision",
-					zap.Int("decision", int(finalDecision)))
 			}
+			tsp.releaseLateSpans(finalDecision, resourceSpans, spans)
 		}
 	}
 
 	stats.Record(tsp.ctx, statNewTraceIDReceivedCount.M(newTraceIDs))
 }
 
+// releaseLateSpans applies the decision taken for a trace to the spans that arrived after it.
+func (tsp *tailSamplingSpanProcessor) releaseLateSpans(decision sampling.Decision, resourceSpans ptrace.ResourceSpans, spans []spanAndScope) {
+	switch decision {
+	case sampling.Sampled:
+		_ = stats.RecordWithTags(tsp.ctx, []tag.Mutator{tag.Upsert(tagSampledKey, "true")}, statLateSpanArrivalCount.M(int64(len(spans))))
+
+		// Forward the spans to the policy destinations
+		traceTd := ptrace.NewTraces()
+		appendToTraces(traceTd, resourceSpans, spans)
+		if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, traceTd); err != nil {
+			tsp.logger.Warn(
+				"Error sending late arrived spans to destination",
+				zap.Error(err))
+		}
+	case sampling.NotSampled:
+		_ = stats.RecordWithTags(tsp.ctx, []tag.Mutator{tag.Upsert(tagSampledKey, "false")}, statLateSpanArrivalCount.M(int64(len(spans))))
+	default:
+		tsp.logger.Warn("Encountered unexpected sampling decision",
+			zap.Int("decision", int(decision)))
+	}
+}
+
+// cacheDecision remembers the final decision for the trace, so that it can be applied to spans
+// arriving after the trace is dropped from memory.
+func (tsp *tailSamplingSpanProcessor) cacheDecision(id pcommon.TraceID, decision sampling.Decision) {
+	if tsp.sampledIDCache == nil || tsp.nonSampledIDCache == nil {
+		return
+	}
+
+	switch decision {
+	case sampling.Sampled:
+		tsp.nonSampledIDCache.Delete(id)
+		tsp.sampledIDCache.Put(id)
+	case sampling.NotSampled:
+		tsp.sampledIDCache.Delete(id)
+		tsp.nonSampledIDCache.Put(id)
+	}
+}
+
+// cachedDecision returns the decision remembered for the trace, if any.
+func (tsp *tailSamplingSpanProcessor) cachedDecision(id pcommon.TraceID) (sampling.Decision, bool) {
+	if tsp.sampledIDCache != nil && tsp.sampledIDCache.Contains(id) {
+		return sampling.Sampled, true
+	}
+	if tsp.nonSampledIDCache != nil && tsp.nonSampledIDCache.Contains(id) {
+		return sampling.NotSampled, true
+	}
+	return sampling.Unspecified, false
+}
+
 func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.storageID != nil {
+		if err := tsp.loadDecisionCaches(ctx, host); err != nil {
+			return err
+		}
+	}
+
+	if tsp.policyFile != "" {
+		if err := tsp.watchPolicyFile(); err != nil {
+			return err
+		}
+	}
+
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
+	tsp.stopWatchingPolicyFile()
+
+	if tsp.storageClient != nil {
+		return tsp.saveDecisionCaches(ctx)
+	}
 	return nil
 }
 
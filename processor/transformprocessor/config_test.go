@@ -92,6 +92,40 @@ func TestLoadConfig(t *testing.T) {
 				LogStatements:    []common.ContextStatements{},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "macros"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Macros: []ottl.Macro{
+					{
+						Name:       "upper_method",
+						Parameters: []string{"target"},
+						Statement:  `set(target["http.method"], ConvertCase(target["http.method"], "upper"))`,
+					},
+					{
+						Name:       "IsHealthCheck",
+						Parameters: []string{"path"},
+						Condition:  `path == "/health" or path == "/ready"`,
+					},
+				},
+				TraceStatements: []common.ContextStatements{
+					{
+						Context: "span",
+						Statements: []string{
+							`upper_method(attributes) where not IsHealthCheck(attributes["http.target"])`,
+						},
+					},
+				},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements:    []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "macro_cycle"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_macro_invocation"),
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_trace"),
 		},
@@ -71,6 +71,13 @@ func WithLogErrorMode(errorMode ottl.ErrorMode) LogParserCollectionOption {
 	}
 }
 
+func WithLogMacros(macros *ottl.Macros) LogParserCollectionOption {
+	return func(lp *LogParserCollection) error {
+		lp.macros = macros
+		return nil
+	}
+}
+
 func NewLogParserCollection(settings component.TelemetrySettings, options ...LogParserCollectionOption) (*LogParserCollection, error) {
 	rp, err := ottlresource.NewParser(ResourceFunctions(), settings)
 	if err != nil {
@@ -95,6 +102,11 @@ func NewLogParserCollection(settings component.TelemetrySettings, options ...Log
 		}
 	}
 
+	if lpc.macros != nil {
+		lpc.applyMacros()
+		ottl.WithMacros[ottllog.TransformContext](lpc.macros)(&lpc.logParser)
+	}
+
 	return lpc, nil
 }
 
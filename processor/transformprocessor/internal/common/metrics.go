@@ -172,6 +172,13 @@ func WithMetricErrorMode(errorMode ottl.ErrorMode) MetricParserCollectionOption
 	}
 }
 
+func WithMetricMacros(macros *ottl.Macros) MetricParserCollectionOption {
+	return func(mp *MetricParserCollection) error {
+		mp.macros = macros
+		return nil
+	}
+}
+
 func NewMetricParserCollection(settings component.TelemetrySettings, options ...MetricParserCollectionOption) (*MetricParserCollection, error) {
 	rp, err := ottlresource.NewParser(ResourceFunctions(), settings)
 	if err != nil {
@@ -196,6 +203,12 @@ func NewMetricParserCollection(settings component.TelemetrySettings, options ...
 		}
 	}
 
+	if mpc.macros != nil {
+		mpc.applyMacros()
+		ottl.WithMacros[ottlmetric.TransformContext](mpc.macros)(&mpc.metricParser)
+		ottl.WithMacros[ottldatapoint.TransformContext](mpc.macros)(&mpc.dataPointParser)
+	}
+
 	return mpc, nil
 }
 
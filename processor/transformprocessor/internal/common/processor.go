@@ -134,6 +134,13 @@ type parserCollection struct {
 	resourceParser ottl.Parser[ottlresource.TransformContext]
 	scopeParser    ottl.Parser[ottlscope.TransformContext]
 	errorMode      ottl.ErrorMode
+	macros         *ottl.Macros
+}
+
+// applyMacros makes the macros of the collection available to its resource and scope parsers.
+func (pc *parserCollection) applyMacros() {
+	ottl.WithMacros[ottlresource.TransformContext](pc.macros)(&pc.resourceParser)
+	ottl.WithMacros[ottlscope.TransformContext](pc.macros)(&pc.scopeParser)
 }
 
 type baseContext interface {
@@ -118,6 +118,13 @@ func WithTraceErrorMode(errorMode ottl.ErrorMode) TraceParserCollectionOption {
 	}
 }
 
+func WithTraceMacros(macros *ottl.Macros) TraceParserCollectionOption {
+	return func(tp *TraceParserCollection) error {
+		tp.macros = macros
+		return nil
+	}
+}
+
 func NewTraceParserCollection(settings component.TelemetrySettings, options ...TraceParserCollectionOption) (*TraceParserCollection, error) {
 	rp, err := ottlresource.NewParser(ResourceFunctions(), settings)
 	if err != nil {
@@ -142,6 +149,12 @@ func NewTraceParserCollection(settings component.TelemetrySettings, options ...T
 		}
 	}
 
+	if tpc.macros != nil {
+		tpc.applyMacros()
+		ottl.WithMacros[ottlspan.TransformContext](tpc.macros)(&tpc.spanParser)
+		ottl.WithMacros[ottlspanevent.TransformContext](tpc.macros)(&tpc.spanEventParser)
+	}
+
 	return tpc, nil
 }
 
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+)
+
+// acknowledger passes messages to the pipeline until it acknowledges them, then marks their offset.
+// The pipeline acknowledges a message when it consumes it without error, which for exporters with
+// a persistent sending queue means the data was written to storage, as long as no processor passes
+// the data on asynchronously, e.g. the batch processor. Messages the pipeline fails to
+// consume are passed again, blocking their partition, so that offsets are marked in order.
+// The marked offsets are committed periodically, after a number of messages if configured, and
+// when the session ends, rather than after every message.
+type acknowledger struct {
+	id     component.ID
+	cfg    CommitOnAck
+	logger *zap.Logger
+
+	mu sync.Mutex
+	// pending is the number of messages marked since the last commit.
+	pending int
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+func newAcknowledger(id component.ID, cfg CommitOnAck, logger *zap.Logger) *acknowledger {
+	if !cfg.Enable {
+		return nil
+	}
+	return &acknowledger{
+		id:     id,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// setup starts committing the offsets marked during the session every commit interval.
+func (a *acknowledger) setup(session sarama.ConsumerGroupSession) {
+	a.stop = make(chan struct{})
+	a.stopped = make(chan struct{})
+	go func(stop <-chan struct{}, stopped chan<- struct{}) {
+		defer close(stopped)
+		ticker := time.NewTicker(a.cfg.CommitInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				a.commit(session)
+			case <-stop:
+				return
+			}
+		}
+	}(a.stop, a.stopped)
+}
+
+// cleanup stops the periodic commits and commits the offsets marked since the last one.
+func (a *acknowledger) cleanup(session sarama.ConsumerGroupSession) {
+	if a.stop != nil {
+		close(a.stop)
+		<-a.stopped
+		a.stop = nil
+	}
+	a.commit(session)
+}
+
+// commit commits the offsets marked since the last commit, if any.
+func (a *acknowledger) commit(session sarama.ConsumerGroupSession) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.pending == 0 {
+		return
+	}
+	session.Commit()
+	a.pending = 0
+}
+
+// consume calls next until it succeeds and marks the offset of message. It returns the last
+// error of next if it is permanent or the maximum elapsed time is reached, and the error of the
+// session context if the session ends first, so that no later message of the partition is marked.
+func (a *acknowledger) consume(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, next func() error) error {
+	statsTags := []tag.Mutator{
+		tag.Upsert(tagInstanceName, a.id.Name()),
+		tag.Upsert(tagTopic, message.Topic),
+		tag.Upsert(tagPartition, strconv.Itoa(int(message.Partition))),
+	}
+	start := time.Now()
+	interval := a.cfg.InitialInterval
+	for {
+		err := next()
+		if err == nil {
+			break
+		}
+		if consumererror.IsPermanent(err) {
+			return err
+		}
+		if a.cfg.MaxElapsedTime > 0 && time.Since(start)+interval > a.cfg.MaxElapsedTime {
+			return err
+		}
+
+		a.logger.Warn("Pipeline did not acknowledge message, retrying",
+			zap.String("topic", message.Topic),
+			zap.Int32("partition", message.Partition),
+			zap.Int64("offset", message.Offset),
+			zap.Duration("interval", interval),
+			zap.Error(err))
+		_ = stats.RecordWithTags(session.Context(), statsTags, statAckRetries.M(1))
+		select {
+		case <-time.After(interval):
+		case <-session.Context().Done():
+			return session.Context().Err()
+		}
+		if interval *= 2; interval > a.cfg.MaxInterval {
+			interval = a.cfg.MaxInterval
+		}
+	}
+
+	session.MarkMessage(message, "")
+	a.mu.Lock()
+	a.pending++
+	if a.cfg.CommitMessages > 0 && a.pending >= a.cfg.CommitMessages {
+		session.Commit()
+		a.pending = 0
+	}
+	a.mu.Unlock()
+	_ = stats.RecordWithTags(session.Context(), statsTags,
+		statAckLatency.M(time.Since(start).Milliseconds()),
+		statCommittedOffset.M(message.Offset))
+	return nil
+}
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+)
+
+// committingSession records the offsets marked and committed during a session.
+type committingSession struct {
+	testConsumerGroupSession
+
+	mu        sync.Mutex
+	marked    []int64
+	committed []int64
+}
+
+func (s *committingSession) MarkMessage(message *sarama.ConsumerMessage, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marked = append(s.marked, message.Offset)
+}
+
+func (s *committingSession) Commit() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.committed = append(s.committed, s.marked...)
+	s.marked = nil
+}
+
+func (s *committingSession) committedOffsets() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.committed
+}
+
+func (s *committingSession) markedOffsets() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.marked
+}
+
+func testAcknowledger() *acknowledger {
+	return newAcknowledger(component.NewIDWithName("kafka", "orders"), CommitOnAck{
+		Enable:          true,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     2 * time.Millisecond,
+		CommitInterval:  time.Hour,
+	}, zap.NewNop())
+}
+
+func TestNewAcknowledger_disabled(t *testing.T) {
+	assert.Nil(t, newAcknowledger(component.NewID("kafka"), CommitOnAck{}, zap.NewNop()))
+}
+
+func TestAcknowledger(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	session := &committingSession{testConsumerGroupSession: testConsumerGroupSession{ctx: context.Background()}}
+	a := testAcknowledger()
+	a.setup(session)
+	attempts := 0
+	err := a.consume(session, &sarama.ConsumerMessage{Topic: "otlp_logs", Offset: 7}, func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("queue is full")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []int64{7}, session.markedOffsets())
+	assert.Empty(t, session.committedOffsets())
+
+	a.cleanup(session)
+	assert.Equal(t, []int64{7}, session.committedOffsets())
+
+	viewData, err := view.RetrieveData(statAckRetries.Name())
+	require.NoError(t, err)
+	require.Len(t, viewData, 1)
+	assert.Equal(t, float64(2), viewData[0].Data.(*view.SumData).Value)
+	assert.Contains(t, viewData[0].Tags, tag.Tag{Key: tagInstanceName, Value: "orders"})
+	assert.Contains(t, viewData[0].Tags, tag.Tag{Key: tagTopic, Value: "otlp_logs"})
+	viewData, err = view.RetrieveData(statCommittedOffset.Name())
+	require.NoError(t, err)
+	require.Len(t, viewData, 1)
+	assert.Equal(t, float64(7), viewData[0].Data.(*view.LastValueData).Value)
+}
+
+func TestAcknowledger_commits(t *testing.T) {
+	t.Run("commit messages", func(t *testing.T) {
+		session := &committingSession{testConsumerGroupSession: testConsumerGroupSession{ctx: context.Background()}}
+		a := testAcknowledger()
+		a.cfg.CommitMessages = 2
+		a.setup(session)
+		for offset := int64(1); offset <= 3; offset++ {
+			require.NoError(t, a.consume(session, &sarama.ConsumerMessage{Offset: offset}, func() error { return nil }))
+		}
+		assert.Equal(t, []int64{1, 2}, session.committedOffsets())
+
+		a.cleanup(session)
+		assert.Equal(t, []int64{1, 2, 3}, session.committedOffsets())
+	})
+
+	t.Run("commit interval", func(t *testing.T) {
+		session := &committingSession{testConsumerGroupSession: testConsumerGroupSession{ctx: context.Background()}}
+		a := testAcknowledger()
+		a.cfg.CommitInterval = 10 * time.Millisecond
+		a.setup(session)
+		defer a.cleanup(session)
+		require.NoError(t, a.consume(session, &sarama.ConsumerMessage{Offset: 1}, func() error { return nil }))
+		assert.Eventually(t, func() bool {
+			return len(session.committedOffsets()) == 1
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, []int64{1}, session.committedOffsets())
+	})
+}
+
+func TestAcknowledger_errors(t *testing.T) {
+	permanent := consumererror.NewPermanent(errors.New("invalid data"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		maxElapsedTime time.Duration
+		err            error
+		expected       error
+		attempts       int
+	}{
+		{
+			name:     "permanent",
+			ctx:      context.Background(),
+			err:      permanent,
+			expected: permanent,
+			attempts: 1,
+		},
+		{
+			name:           "max elapsed time",
+			ctx:            context.Background(),
+			maxElapsedTime: 5 * time.Millisecond,
+			err:            errors.New("queue is full"),
+			attempts:       2,
+		},
+		{
+			name:     "session done",
+			ctx:      ctx,
+			err:      errors.New("queue is full"),
+			expected: context.Canceled,
+			attempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := testAcknowledger()
+			a.cfg.MaxElapsedTime = tt.maxElapsedTime
+			session := &committingSession{testConsumerGroupSession: testConsumerGroupSession{ctx: tt.ctx}}
+			attempts := 0
+			err := a.consume(session, &sarama.ConsumerMessage{Offset: 7}, func() error {
+				attempts++
+				return tt.err
+			})
+			if tt.expected != nil {
+				assert.ErrorIs(t, err, tt.expected)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+			assert.GreaterOrEqual(t, attempts, tt.attempts)
+			assert.Empty(t, session.committedOffsets())
+		})
+	}
+}
+
+func TestLogsConsumerGroupHandler_commit_on_ack(t *testing.T) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{ReceiverCreateSettings: receivertest.NewNopCreateSettings()})
+	require.NoError(t, err)
+	failures := 2
+	var received int
+	next, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		if failures > 0 {
+			failures--
+			return errors.New("queue is full")
+		}
+		received++
+		return nil
+	})
+	require.NoError(t, err)
+	c := logsConsumerGroupHandler{
+		unmarshaler:     newPdataLogsUnmarshaler(&plog.ProtoUnmarshaler{}, defaultEncoding),
+		logger:          zap.NewNop(),
+		ready:           make(chan bool),
+		nextConsumer:    next,
+		obsrecv:         obsrecv,
+		acknowledger:    testAcknowledger(),
+		headerExtractor: &nopHeaderExtractor{},
+	}
+
+	session := &committingSession{testConsumerGroupSession: testConsumerGroupSession{ctx: context.Background()}}
+	require.NoError(t, c.Setup(session))
+	groupClaim := testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		require.NoError(t, c.ConsumeClaim(session, groupClaim))
+		wg.Done()
+	}()
+
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Offset: 1}
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Offset: 2}
+	close(groupClaim.messageChan)
+	wg.Wait()
+	require.NoError(t, c.Cleanup(session))
+
+	assert.Equal(t, 2, received)
+	assert.Equal(t, []int64{1, 2}, session.committedOffsets())
+}
@@ -4,12 +4,14 @@
 package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
 )
 
 type AutoCommit struct {
@@ -32,6 +34,25 @@ type MessageMarking struct {
 	OnError bool `mapstructure:"on_error"`
 }
 
+type CommitOnAck struct {
+	// If true, the offset of a message is only committed once the pipeline acknowledged it,
+	// retrying the messages it fails to consume in order (default disabled).
+	// Requires auto-commit to be disabled, message marking is ignored.
+	Enable bool `mapstructure:"enable"`
+	// How long to wait before passing a message to the pipeline again (default 1s).
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+	// Upper bound of the doubling wait between retries (default 30s).
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+	// How long to retry a message before the partition stops being consumed,
+	// 0 retries until the partition is revoked (default 0).
+	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
+	// How often to commit the offsets of the acknowledged messages (default 1s).
+	CommitInterval time.Duration `mapstructure:"commit_interval"`
+	// Number of acknowledged messages after which their offsets are committed
+	// before the commit interval elapses, 0 disables it (default 0).
+	CommitMessages int `mapstructure:"commit_messages"`
+}
+
 type HeaderExtraction struct {
 	ExtractHeaders bool     `mapstructure:"extract_headers"`
 	Headers        []string `mapstructure:"headers"`
@@ -67,8 +88,14 @@ type Config struct {
 	// Controls the way the messages are marked as consumed
 	MessageMarking MessageMarking `mapstructure:"message_marking"`
 
+	// Controls committing the offsets once the pipeline acknowledged the messages
+	CommitOnAck CommitOnAck `mapstructure:"commit_on_ack"`
+
 	// Extract headers from kafka records
 	HeaderExtraction HeaderExtraction `mapstructure:"header_extraction"`
+
+	// Schema registry resolving the schemas of the avro and protobuf encodings.
+	SchemaRegistry schemaregistry.Config `mapstructure:"schema_registry"`
 }
 
 const (
@@ -80,5 +107,22 @@ var _ component.Config = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.CommitOnAck.Enable {
+		if cfg.AutoCommit.Enable {
+			return errors.New("commit_on_ack requires autocommit.enable to be false")
+		}
+		if cfg.CommitOnAck.InitialInterval <= 0 || cfg.CommitOnAck.MaxInterval < cfg.CommitOnAck.InitialInterval {
+			return errors.New("commit_on_ack.initial_interval must be positive and not greater than commit_on_ack.max_interval")
+		}
+		if cfg.CommitOnAck.CommitInterval <= 0 {
+			return errors.New("commit_on_ack.commit_interval must be positive")
+		}
+		if cfg.CommitOnAck.CommitMessages < 0 {
+			return errors.New("commit_on_ack.commit_messages must not be negative")
+		}
+	}
+	if _, ok := schemaRegistryFormats[cfg.Encoding]; ok {
+		return cfg.SchemaRegistry.ValidateDeserializer()
+	}
 	return nil
 }
@@ -59,6 +59,11 @@ func TestLoadConfig(t *testing.T) {
 					Enable:   true,
 					Interval: 1 * time.Second,
 				},
+				CommitOnAck: CommitOnAck{
+					InitialInterval: 1 * time.Second,
+					MaxInterval:     30 * time.Second,
+					CommitInterval:  1 * time.Second,
+				},
 			},
 		},
 		{
@@ -91,6 +96,11 @@ func TestLoadConfig(t *testing.T) {
 					Enable:   true,
 					Interval: 1 * time.Second,
 				},
+				CommitOnAck: CommitOnAck{
+					InitialInterval: 1 * time.Second,
+					MaxInterval:     30 * time.Second,
+					CommitInterval:  1 * time.Second,
+				},
 			},
 		},
 	}
@@ -109,3 +119,32 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate_schemaRegistry(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Encoding = "avro"
+	assert.EqualError(t, cfg.Validate(), "schema_registry.endpoint is required")
+
+	cfg.SchemaRegistry.Endpoint = "http://localhost:8081"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_commitOnAck(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CommitOnAck.Enable = true
+	assert.EqualError(t, cfg.Validate(), "commit_on_ack requires autocommit.enable to be false")
+
+	cfg.AutoCommit.Enable = false
+	assert.NoError(t, cfg.Validate())
+
+	cfg.CommitOnAck.MaxInterval = time.Millisecond
+	assert.EqualError(t, cfg.Validate(), "commit_on_ack.initial_interval must be positive and not greater than commit_on_ack.max_interval")
+
+	cfg.CommitOnAck.MaxInterval = time.Second
+	cfg.CommitOnAck.CommitInterval = 0
+	assert.EqualError(t, cfg.Validate(), "commit_on_ack.commit_interval must be positive")
+
+	cfg.CommitOnAck.CommitInterval = time.Second
+	cfg.CommitOnAck.CommitMessages = -1
+	assert.EqualError(t, cfg.Validate(), "commit_on_ack.commit_messages must not be negative")
+}
@@ -35,6 +35,10 @@ const (
 	defaultAutoCommitEnable = true
 	// default from sarama.NewConfig()
 	defaultAutoCommitInterval = 1 * time.Second
+
+	defaultCommitOnAckInitialInterval = 1 * time.Second
+	defaultCommitOnAckMaxInterval     = 30 * time.Second
+	defaultCommitOnAckCommitInterval  = 1 * time.Second
 )
 
 // FactoryOption applies changes to kafkaExporterFactory.
@@ -111,6 +115,12 @@ func createDefaultConfig() component.Config {
 			After:   false,
 			OnError: false,
 		},
+		CommitOnAck: CommitOnAck{
+			Enable:          false,
+			InitialInterval: defaultCommitOnAckInitialInterval,
+			MaxInterval:     defaultCommitOnAckMaxInterval,
+			CommitInterval:  defaultCommitOnAckCommitInterval,
+		},
 		HeaderExtraction: HeaderExtraction{
 			ExtractHeaders: false,
 		},
@@ -40,6 +40,7 @@ type kafkaTracesConsumer struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	commitOnAck       CommitOnAck
 	headerExtraction  bool
 	headers           []string
 }
@@ -56,6 +57,7 @@ type kafkaMetricsConsumer struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	commitOnAck       CommitOnAck
 	headerExtraction  bool
 	headers           []string
 }
@@ -72,6 +74,7 @@ type kafkaLogsConsumer struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	commitOnAck       CommitOnAck
 	headerExtraction  bool
 	headers           []string
 }
@@ -120,6 +123,7 @@ func newTracesReceiver(config Config, set receiver.CreateSettings, unmarshalers
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
+		commitOnAck:       config.CommitOnAck,
 		headerExtraction:  config.HeaderExtraction.ExtractHeaders,
 		headers:           config.HeaderExtraction.Headers,
 	}, nil
@@ -144,6 +148,7 @@ func (c *kafkaTracesConsumer) Start(_ context.Context, host component.Host) erro
 		obsrecv:           obsrecv,
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
+		acknowledger:      newAcknowledger(c.settings.ID, c.commitOnAck, c.settings.Logger),
 		headerExtractor:   &nopHeaderExtractor{},
 	}
 	if c.headerExtraction {
@@ -222,6 +227,7 @@ func newMetricsReceiver(config Config, set receiver.CreateSettings, unmarshalers
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
+		commitOnAck:       config.CommitOnAck,
 		headerExtraction:  config.HeaderExtraction.ExtractHeaders,
 		headers:           config.HeaderExtraction.Headers,
 	}, nil
@@ -246,6 +252,7 @@ func (c *kafkaMetricsConsumer) Start(_ context.Context, host component.Host) err
 		obsrecv:           obsrecv,
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
+		acknowledger:      newAcknowledger(c.settings.ID, c.commitOnAck, c.settings.Logger),
 		headerExtractor:   &nopHeaderExtractor{},
 	}
 	if c.headerExtraction {
@@ -301,6 +308,11 @@ func newLogsReceiver(config Config, set receiver.CreateSettings, unmarshalers ma
 	if err != nil {
 		return nil, err
 	}
+	if u, ok := unmarshaler.(*schemaRegistryLogsUnmarshaler); ok {
+		if unmarshaler, err = u.withSchemaRegistry(config.SchemaRegistry); err != nil {
+			return nil, err
+		}
+	}
 	if config.ProtocolVersion != "" {
 		var version sarama.KafkaVersion
 		version, err = sarama.ParseKafkaVersion(config.ProtocolVersion)
@@ -324,6 +336,7 @@ func newLogsReceiver(config Config, set receiver.CreateSettings, unmarshalers ma
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
+		commitOnAck:       config.CommitOnAck,
 		headerExtraction:  config.HeaderExtraction.ExtractHeaders,
 		headers:           config.HeaderExtraction.Headers,
 	}, nil
@@ -376,6 +389,7 @@ func (c *kafkaLogsConsumer) Start(_ context.Context, host component.Host) error
 		obsrecv:           obsrecv,
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
+		acknowledger:      newAcknowledger(c.settings.ID, c.commitOnAck, c.settings.Logger),
 		headerExtractor:   &nopHeaderExtractor{},
 	}
 	if c.headerExtraction {
@@ -427,6 +441,7 @@ type tracesConsumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	acknowledger      *acknowledger
 	headerExtractor   HeaderExtractor
 }
 
@@ -443,6 +458,7 @@ type metricsConsumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	acknowledger      *acknowledger
 	headerExtractor   HeaderExtractor
 }
 
@@ -459,6 +475,7 @@ type logsConsumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	acknowledger      *acknowledger
 	headerExtractor   HeaderExtractor
 }
 
@@ -472,12 +489,18 @@ func (c *tracesConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession)
 	})
 	statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.Name())}
 	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionStart.M(1))
+	if c.acknowledger != nil {
+		c.acknowledger.setup(session)
+	}
 	return nil
 }
 
 func (c *tracesConsumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
 	statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.Name())}
 	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionClose.M(1))
+	if c.acknowledger != nil {
+		c.acknowledger.cleanup(session)
+	}
 	return nil
 }
 
@@ -496,7 +519,7 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 				zap.String("value", string(message.Value)),
 				zap.Time("timestamp", message.Timestamp),
 				zap.String("topic", message.Topic))
-			if !c.messageMarking.After {
+			if c.acknowledger == nil && !c.messageMarking.After {
 				session.MarkMessage(message, "")
 			}
 
@@ -510,7 +533,7 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 			traces, err := c.unmarshaler.Unmarshal(message.Value)
 			if err != nil {
 				c.logger.Error("failed to unmarshal message", zap.Error(err))
-				if c.messageMarking.After && c.messageMarking.OnError {
+				if c.acknowledger == nil && c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
 				}
 				return err
@@ -518,14 +541,24 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 
 			c.headerExtractor.extractHeadersTraces(traces, message)
 			spanCount := traces.SpanCount()
-			err = c.nextConsumer.ConsumeTraces(session.Context(), traces)
+			if c.acknowledger != nil {
+				err = c.acknowledger.consume(session, message, func() error {
+					return c.nextConsumer.ConsumeTraces(session.Context(), traces)
+				})
+			} else {
+				err = c.nextConsumer.ConsumeTraces(session.Context(), traces)
+			}
 			c.obsrecv.EndTracesOp(ctx, c.unmarshaler.Encoding(), spanCount, err)
 			if err != nil {
-				if c.messageMarking.After && c.messageMarking.OnError {
+				if c.acknowledger == nil && c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
 				}
 				return err
 			}
+			if c.acknowledger != nil {
+				// The acknowledger marked the message.
+				continue
+			}
 			if c.messageMarking.After {
 				session.MarkMessage(message, "")
 			}
@@ -548,12 +581,18 @@ func (c *metricsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession)
 	})
 	statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.Name())}
 	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionStart.M(1))
+	if c.acknowledger != nil {
+		c.acknowledger.setup(session)
+	}
 	return nil
 }
 
 func (c *metricsConsumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
 	statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.Name())}
 	_ = stats.RecordWithTags(session.Context(), statsTags, statPartitionClose.M(1))
+	if c.acknowledger != nil {
+		c.acknowledger.cleanup(session)
+	}
 	return nil
 }
 
@@ -572,7 +611,7 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 				zap.String("value", string(message.Value)),
 				zap.Time("timestamp", message.Timestamp),
 				zap.String("topic", message.Topic))
-			if !c.messageMarking.After {
+			if c.acknowledger == nil && !c.messageMarking.After {
 				session.MarkMessage(message, "")
 			}
 
@@ -586,7 +625,7 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 			metrics, err := c.unmarshaler.Unmarshal(message.Value)
 			if err != nil {
 				c.logger.Error("failed to unmarshal message", zap.Error(err))
-				if c.messageMarking.After && c.messageMarking.OnError {
+				if c.acknowledger == nil && c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
 				}
 				return err
@@ -594,14 +633,24 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 			c.headerExtractor.extractHeadersMetrics(metrics, message)
 
 			dataPointCount := metrics.DataPointCount()
-			err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
+			if c.acknowledger != nil {
+				err = c.acknowledger.consume(session, message, func() error {
+					return c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
+				})
+			} else {
+				err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
+			}
 			c.obsrecv.EndMetricsOp(ctx, c.unmarshaler.Encoding(), dataPointCount, err)
 			if err != nil {
-				if c.messageMarking.After && c.messageMarking.OnError {
+				if c.acknowledger == nil && c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
 				}
 				return err
 			}
+			if c.acknowledger != nil {
+				// The acknowledger marked the message.
+				continue
+			}
 			if c.messageMarking.After {
 				session.MarkMessage(message, "")
 			}
@@ -626,6 +675,9 @@ func (c *logsConsumerGroupHandler) Setup(session sarama.ConsumerGroupSession) er
 		session.Context(),
 		[]tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())},
 		statPartitionStart.M(1))
+	if c.acknowledger != nil {
+		c.acknowledger.setup(session)
+	}
 	return nil
 }
 
@@ -634,6 +686,9 @@ func (c *logsConsumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession)
 		session.Context(),
 		[]tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())},
 		statPartitionClose.M(1))
+	if c.acknowledger != nil {
+		c.acknowledger.cleanup(session)
+	}
 	return nil
 }
 
@@ -652,7 +707,7 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 				zap.String("value", string(message.Value)),
 				zap.Time("timestamp", message.Timestamp),
 				zap.String("topic", message.Topic))
-			if !c.messageMarking.After {
+			if c.acknowledger == nil && !c.messageMarking.After {
 				session.MarkMessage(message, "")
 			}
 
@@ -667,21 +722,31 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 			logs, err := c.unmarshaler.Unmarshal(message.Value)
 			if err != nil {
 				c.logger.Error("failed to unmarshal message", zap.Error(err))
-				if c.messageMarking.After && c.messageMarking.OnError {
+				if c.acknowledger == nil && c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
 				}
 				return err
 			}
 			c.headerExtractor.extractHeadersLogs(logs, message)
-			err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
+			if c.acknowledger != nil {
+				err = c.acknowledger.consume(session, message, func() error {
+					return c.nextConsumer.ConsumeLogs(session.Context(), logs)
+				})
+			} else {
+				err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
+			}
 			// TODO
 			c.obsrecv.EndLogsOp(ctx, c.unmarshaler.Encoding(), logs.LogRecordCount(), err)
 			if err != nil {
-				if c.messageMarking.After && c.messageMarking.OnError {
+				if c.acknowledger == nil && c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
 				}
 				return err
 			}
+			if c.acknowledger != nil {
+				// The acknowledger marked the message.
+				continue
+			}
 			if c.messageMarking.After {
 				session.MarkMessage(message, "")
 			}
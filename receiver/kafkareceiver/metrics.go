@@ -11,6 +11,8 @@ import (
 
 var (
 	tagInstanceName, _ = tag.NewKey("name")
+	tagTopic, _        = tag.NewKey("topic")
+	tagPartition, _    = tag.NewKey("partition")
 
 	statMessageCount     = stats.Int64("kafka_receiver_messages", "Number of received messages", stats.UnitDimensionless)
 	statMessageOffset    = stats.Int64("kafka_receiver_current_offset", "Current message offset", stats.UnitDimensionless)
@@ -18,6 +20,10 @@ var (
 
 	statPartitionStart = stats.Int64("kafka_receiver_partition_start", "Number of started partitions", stats.UnitDimensionless)
 	statPartitionClose = stats.Int64("kafka_receiver_partition_close", "Number of finished partitions", stats.UnitDimensionless)
+
+	statAckRetries      = stats.Int64("kafka_receiver_ack_retries", "Number of messages passed again to the pipeline as it did not acknowledge them", stats.UnitDimensionless)
+	statAckLatency      = stats.Int64("kafka_receiver_ack_latency", "Time between passing a message to the pipeline and its acknowledgement", stats.UnitMilliseconds)
+	statCommittedOffset = stats.Int64("kafka_receiver_committed_offset", "Offset of the last acknowledged message, committed with the next commit", stats.UnitDimensionless)
 )
 
 // MetricViews return metric views for Kafka receiver.
@@ -64,11 +70,40 @@ func MetricViews() []*view.View {
 		Aggregation: view.Sum(),
 	}
 
+	ackTagKeys := []tag.Key{tagInstanceName, tagTopic, tagPartition}
+
+	countAckRetries := &view.View{
+		Name:        statAckRetries.Name(),
+		Measure:     statAckRetries,
+		Description: statAckRetries.Description(),
+		TagKeys:     ackTagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	distributionAckLatency := &view.View{
+		Name:        statAckLatency.Name(),
+		Measure:     statAckLatency,
+		Description: statAckLatency.Description(),
+		TagKeys:     ackTagKeys,
+		Aggregation: view.Distribution(10, 50, 100, 250, 500, 1000, 5000, 10000, 30000, 60000),
+	}
+
+	lastValueCommittedOffset := &view.View{
+		Name:        statCommittedOffset.Name(),
+		Measure:     statCommittedOffset,
+		Description: statCommittedOffset.Description(),
+		TagKeys:     ackTagKeys,
+		Aggregation: view.LastValue(),
+	}
+
 	return []*view.View{
 		countMessages,
 		lastValueOffset,
 		lastValueOffsetLag,
 		countPartitionStart,
 		countPartitionClose,
+		countAckRetries,
+		distributionAckLatency,
+		lastValueCommittedOffset,
 	}
 }
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
+)
+
+// schemaRegistryFormats are the formats of the encodings whose schemas are resolved with a schema registry.
+var schemaRegistryFormats = map[string]schemaregistry.Format{
+	"avro":     schemaregistry.FormatAvro,
+	"protobuf": schemaregistry.FormatProtobuf,
+}
+
+// schemaRegistryLogsUnmarshaler unmarshals records serialized with a schema of a schema registry,
+// the record becomes the body of the log record except for the configured attribute fields.
+type schemaRegistryLogsUnmarshaler struct {
+	encoding        string
+	serde           *schemaregistry.Serde
+	attributeFields []string
+}
+
+func newSchemaRegistryLogsUnmarshaler(encoding string) *schemaRegistryLogsUnmarshaler {
+	return &schemaRegistryLogsUnmarshaler{encoding: encoding}
+}
+
+// withSchemaRegistry returns an unmarshaler resolving the schemas with the configured schema registry.
+func (r *schemaRegistryLogsUnmarshaler) withSchemaRegistry(cfg schemaregistry.Config) (LogsUnmarshaler, error) {
+	serde, err := schemaregistry.NewSerde(cfg, schemaRegistryFormats[r.encoding])
+	if err != nil {
+		return nil, err
+	}
+	return &schemaRegistryLogsUnmarshaler{
+		encoding:        r.encoding,
+		serde:           serde,
+		attributeFields: cfg.AttributeFields,
+	}, nil
+}
+
+func (r *schemaRegistryLogsUnmarshaler) Unmarshal(buf []byte) (plog.Logs, error) {
+	if r.serde == nil {
+		return plog.Logs{}, errors.New("schema registry not set")
+	}
+	p := plog.NewLogs()
+	record, err := r.serde.Deserialize(context.Background(), buf)
+	if err != nil {
+		return p, err
+	}
+
+	l := p.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	l.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	if fields, ok := record.(map[string]any); ok {
+		for _, name := range r.attributeFields {
+			value, ok := fields[name]
+			if !ok {
+				continue
+			}
+			if err = l.Attributes().PutEmpty(name).FromRaw(value); err != nil {
+				return p, err
+			}
+			delete(fields, name)
+		}
+	}
+	if err = l.Body().FromRaw(record); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func (r *schemaRegistryLogsUnmarshaler) Encoding() string {
+	return r.encoding
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka/schemaregistry/schemaregistrytest"
+)
+
+const userEventSchema = `{
+  "type": "record",
+  "name": "UserEvent",
+  "fields": [
+    {"name": "user", "type": "string"},
+    {"name": "action", "type": "string"},
+    {"name": "count", "type": "int"}
+  ]
+}`
+
+func TestNewSchemaRegistryLogsUnmarshaler(t *testing.T) {
+	t.Parallel()
+	um := newSchemaRegistryLogsUnmarshaler("avro")
+	assert.Equal(t, "avro", um.Encoding())
+
+	_, err := um.Unmarshal([]byte{0, 0, 0, 0, 1})
+	assert.EqualError(t, err, "schema registry not set")
+}
+
+func TestSchemaRegistryLogsUnmarshaler(t *testing.T) {
+	t.Parallel()
+	registry := schemaregistrytest.NewRegistry(t)
+	registry.RegisterAvro("events-value", userEventSchema)
+	cfg := schemaregistry.Config{
+		Endpoint:        registry.URL,
+		Subject:         "events-value",
+		AttributeFields: []string{"user", "missing"},
+	}
+	serde, err := schemaregistry.NewSerde(cfg, schemaregistry.FormatAvro)
+	require.NoError(t, err)
+	msg, err := serde.Serialize(context.Background(), map[string]any{"user": "alice", "action": "login", "count": int64(2)})
+	require.NoError(t, err)
+
+	um, err := newSchemaRegistryLogsUnmarshaler("avro").withSchemaRegistry(cfg)
+	require.NoError(t, err)
+	logs, err := um.Unmarshal(msg)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, logs.LogRecordCount())
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.NotZero(t, lr.ObservedTimestamp())
+	assert.Equal(t, map[string]any{"user": "alice"}, lr.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"action": "login", "count": int64(2)}, lr.Body().Map().AsRaw())
+
+	_, err = um.Unmarshal([]byte("not registered"))
+	assert.Error(t, err)
+}
+
+func TestCreateLogsReceiver_encoding_avro_error(t *testing.T) {
+	cfg := Config{
+		Encoding: "avro",
+		SchemaRegistry: schemaregistry.Config{
+			Endpoint: "http://localhost:8081",
+			TLS: &configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{
+					CAFile: "/doesnotexist",
+				},
+			},
+		},
+	}
+	_, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), defaultLogsUnmarshalers(), consumertest.NewNop())
+	assert.ErrorContains(t, err, "failed to load TLS config")
+}
@@ -76,10 +76,14 @@ func defaultLogsUnmarshalers() map[string]LogsUnmarshaler {
 	raw := newRawLogsUnmarshaler()
 	text := newTextLogsUnmarshaler()
 	json := newJSONLogsUnmarshaler()
+	avro := newSchemaRegistryLogsUnmarshaler("avro")
+	protobuf := newSchemaRegistryLogsUnmarshaler("protobuf")
 	return map[string]LogsUnmarshaler{
-		otlpPb.Encoding(): otlpPb,
-		raw.Encoding():    raw,
-		text.Encoding():   text,
-		json.Encoding():   json,
+		otlpPb.Encoding():   otlpPb,
+		raw.Encoding():      raw,
+		text.Encoding():     text,
+		json.Encoding():     json,
+		avro.Encoding():     avro,
+		protobuf.Encoding(): protobuf,
 	}
 }
@@ -51,6 +51,8 @@ func TestDefaultLogsUnMarshaler(t *testing.T) {
 		"raw",
 		"text",
 		"json",
+		"avro",
+		"protobuf",
 	}
 	marshalers := defaultLogsUnmarshalers()
 	assert.Equal(t, len(expectedEncodings), len(marshalers))
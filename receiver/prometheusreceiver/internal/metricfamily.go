@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/scrape"
@@ -24,6 +25,10 @@ import (
 const (
 	traceIDKey = "trace_id"
 	spanIDKey  = "span_id"
+
+	// Range of the native histogram schemas that map to exponential histogram scales.
+	minNativeHistogramSchema = -4
+	maxNativeHistogramSchema = 8
 )
 
 type metricFamily struct {
@@ -51,6 +56,9 @@ type metricGroup struct {
 	value        float64
 	complexValue []*dataPoint
 	exemplars    pmetric.ExemplarSlice
+	// hValue and fhValue hold the native histogram of the group, only one of them is set.
+	hValue  *histogram.Histogram
+	fhValue *histogram.FloatHistogram
 }
 
 func newMetricFamily(metricName string, mc scrape.MetricMetadataStore, logger *zap.Logger) *metricFamily {
@@ -156,6 +164,57 @@ func (mg *metricGroup) toDistributionPoint(dest pmetric.HistogramDataPointSlice)
 	mg.setExemplars(point.Exemplars())
 }
 
+func (mg *metricGroup) toExponentialHistogramDataPoint(dest pmetric.ExponentialHistogramDataPointSlice) {
+	if !mg.hasCount {
+		return
+	}
+
+	point := dest.AppendEmpty()
+	pointIsStale := value.IsStaleNaN(mg.sum) ||
+		(mg.hValue != nil && value.IsStaleNaN(mg.hValue.Sum)) ||
+		(mg.fhValue != nil && value.IsStaleNaN(mg.fhValue.Sum))
+
+	switch {
+	case pointIsStale:
+		point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	case mg.fhValue != nil:
+		fh := mg.fhValue
+		point.SetScale(fh.Schema)
+		point.SetCount(uint64(fh.Count))
+		point.SetSum(fh.Sum)
+		// TODO: set the zero threshold once pdata supports it, observations in the
+		// Prometheus zero bucket are reported as zero count until then.
+		point.SetZeroCount(uint64(fh.ZeroCount))
+		prometheus.ConvertNativeHistogramBuckets(bucketSpans(fh.PositiveSpans), prometheus.FloatBucketCounts(fh.PositiveBuckets), point.Positive())
+		prometheus.ConvertNativeHistogramBuckets(bucketSpans(fh.NegativeSpans), prometheus.FloatBucketCounts(fh.NegativeBuckets), point.Negative())
+	case mg.hValue != nil:
+		h := mg.hValue
+		point.SetScale(h.Schema)
+		point.SetCount(h.Count)
+		point.SetSum(h.Sum)
+		point.SetZeroCount(h.ZeroCount)
+		prometheus.ConvertNativeHistogramBuckets(bucketSpans(h.PositiveSpans), prometheus.DeltaBucketCounts(h.PositiveBuckets), point.Positive())
+		prometheus.ConvertNativeHistogramBuckets(bucketSpans(h.NegativeSpans), prometheus.DeltaBucketCounts(h.NegativeBuckets), point.Negative())
+	}
+
+	// The timestamp MUST be in retrieved from milliseconds and converted to nanoseconds.
+	tsNanos := timestampFromMs(mg.ts)
+	// metrics_adjuster adjusts the startTimestamp to the initial scrape timestamp
+	point.SetStartTimestamp(tsNanos)
+	point.SetTimestamp(tsNanos)
+	populateAttributes(pmetric.MetricTypeExponentialHistogram, mg.ls, point.Attributes())
+	mg.setExemplars(point.Exemplars())
+}
+
+// bucketSpans returns the spans of a native histogram as expected by prometheus.ConvertNativeHistogramBuckets.
+func bucketSpans(spans []histogram.Span) []prometheus.BucketSpan {
+	converted := make([]prometheus.BucketSpan, len(spans))
+	for i, span := range spans {
+		converted[i] = prometheus.BucketSpan{Offset: span.Offset, Length: span.Length}
+	}
+	return converted
+}
+
 func (mg *metricGroup) setExemplars(exemplars pmetric.ExemplarSlice) {
 	if mg == nil {
 		return
@@ -302,7 +361,15 @@ func (mf *metricFamily) addSeries(seriesRef uint64, metricName string, ls labels
 		} else {
 			mg.value = v
 		}
-	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeExponentialHistogram:
+	case pmetric.MetricTypeExponentialHistogram:
+		// Native histograms are added with addExponentialHistogramSeries, the only float sample
+		// of an exponential histogram family is the staleness marker of the series.
+		if !value.IsStaleNaN(v) {
+			return fmt.Errorf("unexpected float sample for native histogram metric %v", metricName)
+		}
+		mg.sum = v
+		mg.hasCount = true
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge:
 		fallthrough
 	default:
 		mg.value = v
@@ -311,6 +378,30 @@ func (mf *metricFamily) addSeries(seriesRef uint64, metricName string, ls labels
 	return nil
 }
 
+func (mf *metricFamily) addExponentialHistogramSeries(seriesRef uint64, metricName string, ls labels.Labels, t int64, h *histogram.Histogram, fh *histogram.FloatHistogram) error {
+	var schema int32
+	switch {
+	case fh != nil:
+		schema = fh.Schema
+	case h != nil:
+		schema = h.Schema
+	default:
+		return fmt.Errorf("empty native histogram for metric %v", metricName)
+	}
+	if schema < minNativeHistogramSchema || schema > maxNativeHistogramSchema {
+		return fmt.Errorf("unsupported schema %d for native histogram metric %v", schema, metricName)
+	}
+
+	mg := mf.loadMetricGroupOrCreate(seriesRef, ls, t)
+	if mg.ts != t {
+		return fmt.Errorf("inconsistent timestamps on metric points for metric %v", metricName)
+	}
+	mg.hValue = h
+	mg.fhValue = fh
+	mg.hasCount = true
+	return nil
+}
+
 func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice, trimSuffixes bool) {
 	metric := pmetric.NewMetric()
 	// Trims type and unit suffixes from metric name
@@ -352,7 +443,16 @@ func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice, trimSuffixes b
 		}
 		pointCount = sdpL.Len()
 
-	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeExponentialHistogram:
+	case pmetric.MetricTypeExponentialHistogram:
+		expHistogram := metric.SetEmptyExponentialHistogram()
+		expHistogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		hdpL := expHistogram.DataPoints()
+		for _, mg := range mf.groupOrders {
+			mg.toExponentialHistogramDataPoint(hdpL)
+		}
+		pointCount = hdpL.Len()
+
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge:
 		fallthrough
 	default: // Everything else should be set to a Gauge.
 		gauge := metric.SetEmptyGauge()
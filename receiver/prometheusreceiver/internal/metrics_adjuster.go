@@ -54,9 +54,10 @@ import (
 type timeseriesInfo struct {
 	mark bool
 
-	number    numberInfo
-	histogram histogramInfo
-	summary   summaryInfo
+	number       numberInfo
+	histogram    histogramInfo
+	expHistogram histogramInfo
+	summary      summaryInfo
 }
 
 type numberInfo struct {
@@ -108,6 +109,9 @@ func (tsm *timeseriesMap) get(metric pmetric.Metric, kv pcommon.Map) (*timeserie
 		// * GaugeHistogram
 		key.aggTemporality = metric.Histogram().AggregationTemporality()
 	}
+	if metric.Type() == pmetric.MetricTypeExponentialHistogram {
+		key.aggTemporality = metric.ExponentialHistogram().AggregationTemporality()
+	}
 
 	tsm.mark = true
 	tsi, ok := tsm.tsiMap[key]
@@ -279,13 +283,16 @@ func (a *initialPointAdjuster) AdjustMetrics(metrics pmetric.Metrics) error {
 				case pmetric.MetricTypeHistogram:
 					a.adjustMetricHistogram(tsm, metric)
 
+				case pmetric.MetricTypeExponentialHistogram:
+					a.adjustMetricExponentialHistogram(tsm, metric)
+
 				case pmetric.MetricTypeSummary:
 					a.adjustMetricSummary(tsm, metric)
 
 				case pmetric.MetricTypeSum:
 					a.adjustMetricSum(tsm, metric)
 
-				case pmetric.MetricTypeEmpty, pmetric.MetricTypeExponentialHistogram:
+				case pmetric.MetricTypeEmpty:
 					fallthrough
 
 				default:
@@ -346,6 +353,46 @@ func (a *initialPointAdjuster) adjustMetricHistogram(tsm *timeseriesMap, current
 	}
 }
 
+func (a *initialPointAdjuster) adjustMetricExponentialHistogram(tsm *timeseriesMap, current pmetric.Metric) {
+	histogram := current.ExponentialHistogram()
+	if histogram.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+		// Only dealing with CumulativeDistributions.
+		return
+	}
+
+	currentPoints := histogram.DataPoints()
+	for i := 0; i < currentPoints.Len(); i++ {
+		currentDist := currentPoints.At(i)
+
+		tsi, found := tsm.get(current, currentDist.Attributes())
+		if !found {
+			// initialize everything.
+			tsi.expHistogram.startTime = currentDist.StartTimestamp()
+			tsi.expHistogram.previousCount = currentDist.Count()
+			tsi.expHistogram.previousSum = currentDist.Sum()
+			continue
+		}
+
+		if currentDist.Flags().NoRecordedValue() {
+			currentDist.SetStartTimestamp(tsi.expHistogram.startTime)
+			continue
+		}
+
+		if currentDist.Count() < tsi.expHistogram.previousCount || currentDist.Sum() < tsi.expHistogram.previousSum {
+			// reset re-initialize everything.
+			tsi.expHistogram.startTime = currentDist.StartTimestamp()
+			tsi.expHistogram.previousCount = currentDist.Count()
+			tsi.expHistogram.previousSum = currentDist.Sum()
+			continue
+		}
+
+		// Update only previous values.
+		tsi.expHistogram.previousCount = currentDist.Count()
+		tsi.expHistogram.previousSum = currentDist.Sum()
+		currentDist.SetStartTimestamp(tsi.expHistogram.startTime)
+	}
+}
+
 func (a *initialPointAdjuster) adjustMetricSum(tsm *timeseriesMap, current pmetric.Metric) {
 	currentPoints := current.Sum().DataPoints()
 	for i := 0; i < currentPoints.Len(); i++ {
@@ -35,19 +35,20 @@ const (
 )
 
 type transaction struct {
-	isNew           bool
-	trimSuffixes    bool
-	ctx             context.Context
-	families        map[scopeID]map[string]*metricFamily
-	mc              scrape.MetricMetadataStore
-	sink            consumer.Metrics
-	externalLabels  labels.Labels
-	nodeResource    pcommon.Resource
-	scopeAttributes map[scopeID]pcommon.Map
-	logger          *zap.Logger
-	buildInfo       component.BuildInfo
-	metricAdjuster  MetricsAdjuster
-	obsrecv         *receiverhelper.ObsReport
+	isNew                  bool
+	trimSuffixes           bool
+	enableNativeHistograms bool
+	ctx                    context.Context
+	families               map[scopeID]map[string]*metricFamily
+	mc                     scrape.MetricMetadataStore
+	sink                   consumer.Metrics
+	externalLabels         labels.Labels
+	nodeResource           pcommon.Resource
+	scopeAttributes        map[scopeID]pcommon.Map
+	logger                 *zap.Logger
+	buildInfo              component.BuildInfo
+	metricAdjuster         MetricsAdjuster
+	obsrecv                *receiverhelper.ObsReport
 	// Used as buffer to calculate series ref hash.
 	bufBytes []byte
 }
@@ -66,20 +67,22 @@ func newTransaction(
 	externalLabels labels.Labels,
 	settings receiver.CreateSettings,
 	obsrecv *receiverhelper.ObsReport,
-	trimSuffixes bool) *transaction {
+	trimSuffixes bool,
+	enableNativeHistograms bool) *transaction {
 	return &transaction{
-		ctx:             ctx,
-		families:        make(map[scopeID]map[string]*metricFamily),
-		isNew:           true,
-		trimSuffixes:    trimSuffixes,
-		sink:            sink,
-		metricAdjuster:  metricAdjuster,
-		externalLabels:  externalLabels,
-		logger:          settings.Logger,
-		buildInfo:       settings.BuildInfo,
-		obsrecv:         obsrecv,
-		bufBytes:        make([]byte, 0, 1024),
-		scopeAttributes: make(map[scopeID]pcommon.Map),
+		ctx:                    ctx,
+		families:               make(map[scopeID]map[string]*metricFamily),
+		isNew:                  true,
+		trimSuffixes:           trimSuffixes,
+		enableNativeHistograms: enableNativeHistograms,
+		sink:                   sink,
+		metricAdjuster:         metricAdjuster,
+		externalLabels:         externalLabels,
+		logger:                 settings.Logger,
+		buildInfo:              settings.BuildInfo,
+		obsrecv:                obsrecv,
+		bufBytes:               make([]byte, 0, 1024),
+		scopeAttributes:        make(map[scopeID]pcommon.Map),
 	}
 }
 
@@ -144,6 +147,16 @@ func (t *transaction) Append(_ storage.SeriesRef, ls labels.Labels, atMs int64,
 	}
 
 	curMF := t.getOrCreateMetricFamily(getScopeID(ls), metricName)
+	if t.enableNativeHistograms && curMF.name == metricName {
+		// Prometheus marks a native histogram series as stale with a float sample named after the histogram.
+		if value.IsStaleNaN(val) && curMF.mtype == pmetric.MetricTypeHistogram && len(curMF.groups) == 0 {
+			curMF.mtype = pmetric.MetricTypeExponentialHistogram
+		}
+	}
+	if curMF.mtype == pmetric.MetricTypeExponentialHistogram && (curMF.name != metricName || !value.IsStaleNaN(val)) {
+		// The histogram is also exposed with classic buckets, the native histogram takes precedence.
+		return 0, nil
+	}
 	err := curMF.addSeries(t.getSeriesRef(ls, curMF.mtype), metricName, ls, atMs, val)
 	if err != nil {
 		t.logger.Warn("failed to add datapoint", zap.Error(err), zap.String("metric_name", metricName), zap.Any("labels", ls))
@@ -203,9 +216,55 @@ func (t *transaction) AppendExemplar(_ storage.SeriesRef, l labels.Labels, e exe
 	return 0, nil
 }
 
-func (t *transaction) AppendHistogram(_ storage.SeriesRef, _ labels.Labels, _ int64, _ *histogram.Histogram, _ *histogram.FloatHistogram) (storage.SeriesRef, error) {
-	//TODO: implement this func
-	return 0, nil
+// AppendHistogram converts native histograms to exponential histograms, it always returns 0 to disable label caching.
+func (t *transaction) AppendHistogram(_ storage.SeriesRef, ls labels.Labels, atMs int64, h *histogram.Histogram, fh *histogram.FloatHistogram) (storage.SeriesRef, error) {
+	if !t.enableNativeHistograms {
+		return 0, nil
+	}
+
+	select {
+	case <-t.ctx.Done():
+		return 0, errTransactionAborted
+	default:
+	}
+
+	if len(t.externalLabels) != 0 {
+		ls = append(ls, t.externalLabels...)
+		sort.Sort(ls)
+	}
+
+	if t.isNew {
+		if err := t.initTransaction(ls); err != nil {
+			return 0, err
+		}
+	}
+
+	if dupLabel, hasDup := ls.HasDuplicateLabelNames(); hasDup {
+		return 0, fmt.Errorf("invalid sample: non-unique label names: %q", dupLabel)
+	}
+
+	metricName := ls.Get(model.MetricNameLabel)
+	if metricName == "" {
+		return 0, errMetricNameNotFound
+	}
+
+	curMF := t.getOrCreateMetricFamily(getScopeID(ls), metricName)
+	if curMF.mtype != pmetric.MetricTypeExponentialHistogram {
+		if len(curMF.groups) != 0 {
+			t.logger.Warn("failed to add native histogram, the metric was already added with another type",
+				zap.String("metric_name", metricName), zap.String("type", curMF.mtype.String()))
+			return 0, nil
+		}
+		// The metadata of native histograms is the same as the one of classic histograms.
+		curMF.mtype = pmetric.MetricTypeExponentialHistogram
+	}
+
+	err := curMF.addExponentialHistogramSeries(t.getSeriesRef(ls, curMF.mtype), metricName, ls, atMs, h, fh)
+	if err != nil {
+		t.logger.Warn("failed to add native histogram", zap.Error(err), zap.String("metric_name", metricName), zap.Any("labels", ls))
+	}
+
+	return 0, nil // never return errors, as that fails the whole scrape
 }
 
 func (t *transaction) getSeriesRef(ls labels.Labels, mtype pmetric.MetricType) uint64 {
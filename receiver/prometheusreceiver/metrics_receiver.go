@@ -261,14 +261,16 @@ func (r *pReceiver) initPrometheusComponents(ctx context.Context, host component
 		useCreatedMetricGate.IsEnabled(),
 		r.cfg.PrometheusConfig.GlobalConfig.ExternalLabels,
 		r.cfg.TrimMetricSuffixes,
+		enableNativeHistogramsGate.IsEnabled(),
 	)
 	if err != nil {
 		return err
 	}
 
 	r.scrapeManager = scrape.NewManager(&scrape.Options{
-		PassMetadataInContext:     true,
-		EnableProtobufNegotiation: r.cfg.EnableProtobufNegotiation,
+		PassMetadataInContext: true,
+		// Native histograms are only exposed in the protobuf format.
+		EnableProtobufNegotiation: r.cfg.EnableProtobufNegotiation || enableNativeHistogramsGate.IsEnabled(),
 		ExtraMetrics:              r.cfg.ReportExtraScrapeMetrics,
 		HTTPClientOptions: []commonconfig.HTTPClientOption{
 			commonconfig.WithUserAgent(r.settings.BuildInfo.Command + "/" + r.settings.BuildInfo.Version),
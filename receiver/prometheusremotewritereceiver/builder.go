@@ -0,0 +1,359 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// createdKey identifies the series a _created series holds the start time of.
+type createdKey struct {
+	target    resourceKey
+	family    string
+	signature string
+}
+
+// metricKey identifies a metric of a resource.
+type metricKey struct {
+	name string
+	typ  pmetric.MetricType
+}
+
+// metricsBuilder groups the data points rebuilt from series by target and metric,
+// keeping the order series were received in.
+type metricsBuilder struct {
+	resources     map[resourceKey]*resourceBuilder
+	resourceOrder []resourceKey
+}
+
+type resourceBuilder struct {
+	metrics     map[metricKey]*metricBuilder
+	metricOrder []metricKey
+}
+
+type metricBuilder struct {
+	name       string
+	metadata   metricMetadata
+	typ        pmetric.MetricType
+	points     map[pointKey]*point
+	pointOrder []pointKey
+}
+
+type pointKey struct {
+	signature string
+	timestamp int64
+}
+
+// bucket is a bucket of a classic histogram or a quantile of a summary.
+type bucket struct {
+	bound float64
+	value float64
+}
+
+// point accumulates the samples of the series a data point is rebuilt from.
+type point struct {
+	attrs     []prompb.Label
+	timestamp int64
+	start     int64
+	stale     bool
+
+	value     float64
+	sum       float64
+	hasSum    bool
+	count     float64
+	hasCount  bool
+	buckets   []bucket
+	histogram *prompb.Histogram
+	exemplars []prompb.Exemplar
+}
+
+func newMetricsBuilder() *metricsBuilder {
+	return &metricsBuilder{
+		resources: make(map[resourceKey]*resourceBuilder),
+	}
+}
+
+// metric returns the metric of target with the given name and type, adding it if needed.
+func (b *metricsBuilder) metric(target resourceKey, name string, metadata metricMetadata, typ pmetric.MetricType) *metricBuilder {
+	rb, ok := b.resources[target]
+	if !ok {
+		rb = &resourceBuilder{metrics: make(map[metricKey]*metricBuilder)}
+		b.resources[target] = rb
+		b.resourceOrder = append(b.resourceOrder, target)
+	}
+	key := metricKey{name: name, typ: typ}
+	mb, ok := rb.metrics[key]
+	if !ok {
+		mb = &metricBuilder{
+			name:     name,
+			metadata: metadata,
+			typ:      typ,
+			points:   make(map[pointKey]*point),
+		}
+		rb.metrics[key] = mb
+		rb.metricOrder = append(rb.metricOrder, key)
+	}
+	return mb
+}
+
+// point returns the data point with the given attributes at timestamp, adding it if needed.
+func (mb *metricBuilder) point(attrs []prompb.Label, signature string, timestamp int64) *point {
+	key := pointKey{signature: signature, timestamp: timestamp}
+	p, ok := mb.points[key]
+	if !ok {
+		p = &point{attrs: attrs, timestamp: timestamp}
+		mb.points[key] = p
+		mb.pointOrder = append(mb.pointOrder, key)
+	}
+	return p
+}
+
+// add records a sample of a series with the given role.
+func (p *point) add(role seriesRole, bound float64, v float64) {
+	if value.IsStaleNaN(v) {
+		p.stale = true
+	}
+	switch role {
+	case roleBucket, roleQuantile:
+		p.buckets = append(p.buckets, bucket{bound: bound, value: v})
+	case roleSum:
+		p.sum = v
+		p.hasSum = true
+	case roleCount:
+		p.count = v
+		p.hasCount = true
+	default:
+		p.value = v
+	}
+}
+
+// build returns the metrics of the builder, with the attributes of their targets and
+// the start times held by _created series.
+func (b *metricsBuilder) build(t *translator, created map[createdKey]int64, now time.Time) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, target := range b.resourceOrder {
+		rb := b.resources[target]
+		rm := md.ResourceMetrics().AppendEmpty()
+		t.setResourceAttributes(target, rm.Resource().Attributes(), now)
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName(receiverName)
+		sm.Scope().SetVersion(t.buildInfo.Version)
+
+		for _, key := range rb.metricOrder {
+			mb := rb.metrics[key]
+			createdFamily := mb.name
+			if mb.typ == pmetric.MetricTypeSum {
+				createdFamily = strings.TrimSuffix(mb.name, totalSuffix)
+			}
+			startTimestamp := func(p *point) int64 {
+				if p.start != 0 {
+					return p.start
+				}
+				return created[createdKey{target: target, family: createdFamily, signature: labelsSignature(p.attrs)}]
+			}
+
+			metric := sm.Metrics().AppendEmpty()
+			metric.SetName(mb.name)
+			metric.SetDescription(mb.metadata.help)
+			metric.SetUnit(mb.metadata.unit)
+			//exhaustive:enforce
+			switch mb.typ {
+			case pmetric.MetricTypeGauge:
+				dataPoints := metric.SetEmptyGauge().DataPoints()
+				for _, pk := range mb.pointOrder {
+					mb.points[pk].toNumberDataPoint(dataPoints.AppendEmpty(), 0)
+				}
+			case pmetric.MetricTypeSum:
+				sum := metric.SetEmptySum()
+				sum.SetIsMonotonic(true)
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				for _, pk := range mb.pointOrder {
+					p := mb.points[pk]
+					p.toNumberDataPoint(sum.DataPoints().AppendEmpty(), startTimestamp(p))
+				}
+			case pmetric.MetricTypeHistogram:
+				histogram := metric.SetEmptyHistogram()
+				histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				for _, pk := range mb.pointOrder {
+					p := mb.points[pk]
+					p.toHistogramDataPoint(histogram.DataPoints(), startTimestamp(p))
+				}
+			case pmetric.MetricTypeExponentialHistogram:
+				histogram := metric.SetEmptyExponentialHistogram()
+				histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				for _, pk := range mb.pointOrder {
+					p := mb.points[pk]
+					p.toExponentialHistogramDataPoint(histogram.DataPoints(), startTimestamp(p))
+				}
+			case pmetric.MetricTypeSummary:
+				summary := metric.SetEmptySummary()
+				for _, pk := range mb.pointOrder {
+					p := mb.points[pk]
+					p.toSummaryDataPoint(summary.DataPoints(), startTimestamp(p))
+				}
+			case pmetric.MetricTypeEmpty:
+			}
+		}
+	}
+	return md
+}
+
+func (p *point) toNumberDataPoint(dp pmetric.NumberDataPoint, start int64) {
+	p.setAttributes(dp.Attributes())
+	dp.SetStartTimestamp(timestampFromMs(start))
+	dp.SetTimestamp(timestampFromMs(p.timestamp))
+	if p.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	} else {
+		dp.SetDoubleValue(p.value)
+	}
+	p.setExemplars(dp.Exemplars())
+}
+
+// toHistogramDataPoint rebuilds a classic histogram from the cumulative counts of its buckets.
+func (p *point) toHistogramDataPoint(dest pmetric.HistogramDataPointSlice, start int64) {
+	if !p.hasCount && len(p.buckets) == 0 {
+		return
+	}
+	dp := dest.AppendEmpty()
+	p.setAttributes(dp.Attributes())
+	dp.SetStartTimestamp(timestampFromMs(start))
+	dp.SetTimestamp(timestampFromMs(p.timestamp))
+	p.setExemplars(dp.Exemplars())
+	if p.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	sort.Slice(p.buckets, func(i, j int) bool {
+		return p.buckets[i].bound < p.buckets[j].bound
+	})
+	count := p.count
+	var cumulative float64
+	var hasInf bool
+	bounds := make([]float64, 0, len(p.buckets))
+	counts := make([]uint64, 0, len(p.buckets)+1)
+	for _, b := range p.buckets {
+		if math.IsInf(b.bound, +1) {
+			hasInf = true
+			if !p.hasCount {
+				count = b.value
+			}
+			continue
+		}
+		bounds = append(bounds, b.bound)
+		counts = append(counts, uint64(math.Max(b.value-cumulative, 0)))
+		cumulative = math.Max(b.value, cumulative)
+	}
+	if !p.hasCount && !hasInf {
+		count = cumulative
+	}
+	if len(p.buckets) > 0 {
+		// The last bucket holds the observations above the largest bound.
+		counts = append(counts, uint64(math.Max(count-cumulative, 0)))
+	}
+
+	dp.SetCount(uint64(count))
+	if p.hasSum {
+		dp.SetSum(p.sum)
+	}
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(counts)
+}
+
+func (p *point) toExponentialHistogramDataPoint(dest pmetric.ExponentialHistogramDataPointSlice, start int64) {
+	h := p.histogram
+	if h == nil {
+		return
+	}
+	// Only the schemas of exponential buckets match OTLP scales.
+	if h.Schema < -4 || h.Schema > 8 {
+		return
+	}
+	dp := dest.AppendEmpty()
+	p.setAttributes(dp.Attributes())
+	dp.SetStartTimestamp(timestampFromMs(start))
+	dp.SetTimestamp(timestampFromMs(p.timestamp))
+	p.setExemplars(dp.Exemplars())
+	if value.IsStaleNaN(h.Sum) {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	dp.SetScale(h.Schema)
+	dp.SetSum(h.Sum)
+	if h.IsFloatHistogram() {
+		dp.SetCount(uint64(h.GetCountFloat()))
+		dp.SetZeroCount(uint64(h.GetZeroCountFloat()))
+		prometheustranslator.ConvertNativeHistogramBuckets(bucketSpans(h.PositiveSpans), prometheustranslator.FloatBucketCounts(h.PositiveCounts), dp.Positive())
+		prometheustranslator.ConvertNativeHistogramBuckets(bucketSpans(h.NegativeSpans), prometheustranslator.FloatBucketCounts(h.NegativeCounts), dp.Negative())
+	} else {
+		dp.SetCount(h.GetCountInt())
+		dp.SetZeroCount(h.GetZeroCountInt())
+		prometheustranslator.ConvertNativeHistogramBuckets(bucketSpans(h.PositiveSpans), prometheustranslator.DeltaBucketCounts(h.PositiveDeltas), dp.Positive())
+		prometheustranslator.ConvertNativeHistogramBuckets(bucketSpans(h.NegativeSpans), prometheustranslator.DeltaBucketCounts(h.NegativeDeltas), dp.Negative())
+	}
+}
+
+func (p *point) toSummaryDataPoint(dest pmetric.SummaryDataPointSlice, start int64) {
+	if !p.hasCount {
+		return
+	}
+	dp := dest.AppendEmpty()
+	p.setAttributes(dp.Attributes())
+	dp.SetStartTimestamp(timestampFromMs(start))
+	dp.SetTimestamp(timestampFromMs(p.timestamp))
+	if p.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	dp.SetCount(uint64(p.count))
+	if p.hasSum {
+		dp.SetSum(p.sum)
+	}
+	sort.Slice(p.buckets, func(i, j int) bool {
+		return p.buckets[i].bound < p.buckets[j].bound
+	})
+	quantiles := dp.QuantileValues()
+	quantiles.EnsureCapacity(len(p.buckets))
+	for _, b := range p.buckets {
+		q := quantiles.AppendEmpty()
+		q.SetQuantile(b.bound)
+		q.SetValue(b.value)
+	}
+}
+
+func (p *point) setAttributes(dest pcommon.Map) {
+	dest.EnsureCapacity(len(p.attrs))
+	for _, l := range p.attrs {
+		dest.PutStr(l.Name, l.Value)
+	}
+}
+
+func (p *point) setExemplars(dest pmetric.ExemplarSlice) {
+	dest.EnsureCapacity(len(p.exemplars))
+	for _, exemplar := range p.exemplars {
+		convertExemplar(exemplar, dest.AppendEmpty())
+	}
+}
+
+// bucketSpans returns the spans of a native histogram as expected by prometheustranslator.ConvertNativeHistogramBuckets.
+func bucketSpans(spans []prompb.BucketSpan) []prometheustranslator.BucketSpan {
+	converted := make([]prometheustranslator.BucketSpan, len(spans))
+	for i, span := range spans {
+		converted[i] = prometheustranslator.BucketSpan{Offset: span.Offset, Length: span.Length}
+	}
+	return converted
+}
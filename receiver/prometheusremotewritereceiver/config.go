@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines configuration for the Prometheus remote write receiver.
+type Config struct {
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// Path is the URL path remote write requests are accepted on.
+	Path string `mapstructure:"path"`
+
+	// MaxRequestBodySize is the maximum size in bytes of the body of a request, both compressed
+	// and decompressed. Larger requests are rejected.
+	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
+
+	// MetadataExpiration is how long the target_info labels of a target and the metadata of a
+	// metric family are kept once no series of the target or family are received anymore.
+	MetadataExpiration time.Duration `mapstructure:"metadata_expiration"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if !strings.HasPrefix(cfg.Path, "/") {
+		return errors.New("path must start with /")
+	}
+	if cfg.MaxRequestBodySize <= 0 {
+		return errors.New("max_request_body_size must be positive")
+	}
+	if cfg.MetadataExpiration <= 0 {
+		return errors.New("metadata_expiration must be positive")
+	}
+	return nil
+}
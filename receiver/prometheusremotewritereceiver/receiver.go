@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+)
+
+const (
+	dataFormat = "prometheus_remote_write"
+
+	protobufMediaType = "application/x-protobuf"
+	protobufMessageV1 = "prometheus.WriteRequest"
+	protobufMessageV2 = "io.prometheus.write.v2.Request"
+	snappyEncoding    = "snappy"
+)
+
+var errUnsupportedContentType = errors.New("unsupported content type")
+
+type metricsReceiver struct {
+	nextConsumer       consumer.Metrics
+	httpServerSettings *confighttp.HTTPServerSettings
+	path               string
+	maxBodySize        int64
+	translator         *translator
+
+	server *http.Server
+	wg     sync.WaitGroup
+
+	obsrecv *receiverhelper.ObsReport
+
+	settings component.TelemetrySettings
+}
+
+func newMetricsReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Metrics) (*metricsReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		Transport:              "http",
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &metricsReceiver{
+		nextConsumer:       nextConsumer,
+		httpServerSettings: &config.HTTPServerSettings,
+		path:               config.Path,
+		maxBodySize:        config.MaxRequestBodySize,
+		translator:         newTranslator(settings.BuildInfo, config.MetadataExpiration),
+		obsrecv:            obsrecv,
+		settings:           settings.TelemetrySettings,
+	}, nil
+}
+
+func (r *metricsReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.httpServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.httpServerSettings.Endpoint, err)
+	}
+
+	router := http.NewServeMux()
+	router.HandleFunc(r.path, r.handleWrite)
+
+	r.wg.Add(1)
+	r.server, err = r.httpServerSettings.ToServer(host, r.settings, router)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+func (r *metricsReceiver) Shutdown(_ context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	if err := r.server.Close(); err != nil {
+		return err
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	message, err := protobufMessage(req.Header.Get("Content-Type"))
+	if err != nil {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		_, _ = fmt.Fprint(w, err.Error())
+		return
+	}
+	if encoding := req.Header.Get("Content-Encoding"); encoding != "" && encoding != snappyEncoding {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		_, _ = fmt.Fprintf(w, "unsupported content encoding %q", encoding)
+		return
+	}
+
+	compressed, err := io.ReadAll(http.MaxBytesReader(w, req.Body, r.maxBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		} else {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		_, _ = fmt.Fprintf(w, "failed to read request body: %s", err.Error())
+		return
+	}
+	// The decoded length is read from the header of the snappy block, so that the size of the
+	// decompressed body is checked before it is allocated.
+	decodedLen, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "failed to decompress request body: %s", err.Error())
+		return
+	}
+	if int64(decodedLen) > r.maxBodySize {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		_, _ = fmt.Fprintf(w, "decompressed request body is larger than %d bytes", r.maxBodySize)
+		return
+	}
+	body, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "failed to decompress request body: %s", err.Error())
+		return
+	}
+
+	var req2 *writev2.Request
+	var seriesSet []series
+	var metadata []prompb.MetricMetadata
+	switch message {
+	case protobufMessageV2:
+		req2 = &writev2.Request{}
+		if err = req2.Unmarshal(body); err == nil {
+			seriesSet, err = seriesFromV2(req2)
+		}
+	default:
+		var req1 prompb.WriteRequest
+		if err = req1.Unmarshal(body); err == nil {
+			seriesSet = seriesFromV1(&req1)
+			metadata = req1.Metadata
+		}
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "failed to decode %s: %s", message, err.Error())
+		return
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(req.Context())
+	md := r.translator.toMetrics(seriesSet, metadata)
+	numPoints := md.DataPointCount()
+	if numPoints > 0 {
+		err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	}
+	r.obsrecv.EndMetricsOp(ctx, dataFormat, numPoints, err)
+	if err != nil {
+		if consumererror.IsPermanent(err) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		r.settings.Logger.Debug("failed to pass metrics to next consumer", zap.Error(err))
+		return
+	}
+
+	if req2 != nil {
+		samples, histograms, exemplars := countWritten(seriesSet)
+		w.Header().Set(writev2.WrittenSamplesHeader, strconv.Itoa(samples))
+		w.Header().Set(writev2.WrittenHistogramsHeader, strconv.Itoa(histograms))
+		w.Header().Set(writev2.WrittenExemplarsHeader, strconv.Itoa(exemplars))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// protobufMessage returns the protobuf message a request with the given content type holds.
+// Requests without a content type are remote write 1.0 requests sent by older clients.
+func protobufMessage(contentType string) (string, error) {
+	if contentType == "" {
+		return protobufMessageV1, nil
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != protobufMediaType {
+		return "", fmt.Errorf("%w %q", errUnsupportedContentType, contentType)
+	}
+	switch proto := params["proto"]; proto {
+	case "", protobufMessageV1:
+		return protobufMessageV1, nil
+	case protobufMessageV2:
+		return protobufMessageV2, nil
+	default:
+		return "", fmt.Errorf("%w %q", errUnsupportedContentType, contentType)
+	}
+}
+
+// countWritten returns the number of samples, native histograms and exemplars of seriesSet,
+// reported to remote write 2.0 clients in the response headers.
+func countWritten(seriesSet []series) (samples, histograms, exemplars int) {
+	for _, s := range seriesSet {
+		samples += len(s.samples)
+		histograms += len(s.histograms)
+		exemplars += len(s.exemplars)
+	}
+	return samples, histograms, exemplars
+}
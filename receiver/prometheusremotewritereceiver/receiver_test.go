@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+)
+
+func startReceiver(t *testing.T, nextConsumer consumer.Metrics) string {
+	return startReceiverWithConfig(t, createDefaultConfig().(*Config), nextConsumer)
+}
+
+func startReceiverWithConfig(t *testing.T, config *Config, nextConsumer consumer.Metrics) string {
+	addr := testutil.GetAvailableLocalAddress(t)
+	config.Endpoint = addr
+
+	receiver, err := NewFactory().CreateMetricsReceiver(context.Background(), receivertest.NewNopCreateSettings(), config, nextConsumer)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, receiver.Shutdown(context.Background())) })
+	return "http://" + addr + defaultPath
+}
+
+func postWriteRequest(t *testing.T, url string, contentType string, body []byte) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(snappy.Encode(nil, body)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Content-Encoding", "snappy")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func TestReceiveWriteRequestV1(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	writeRequest := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "http_requests_total"}, {Name: "job", Value: "api"}, {Name: "code", Value: "200"}},
+			Samples: []prompb.Sample{{Value: 42, Timestamp: 1000}},
+		}},
+	}
+	body, err := writeRequest.Marshal()
+	require.NoError(t, err)
+
+	resp := postWriteRequest(t, url, "application/x-protobuf", body)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get(writev2.WrittenSamplesHeader))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.MetricCount())
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "http_requests_total", metric.Name())
+	assert.Equal(t, pmetric.MetricTypeSum, metric.Type())
+	serviceName, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+	assert.True(t, ok)
+	assert.Equal(t, "api", serviceName.Str())
+}
+
+func TestReceiveWriteRequestV2(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	symbols := writev2.NewSymbolTable()
+	writeRequest := &writev2.Request{
+		Timeseries: []writev2.TimeSeries{{
+			LabelsRefs: symbols.SymbolizeLabels([]prompb.Label{{Name: "__name__", Value: "queue_length"}, {Name: "job", Value: "worker"}}, nil),
+			Samples:    []writev2.Sample{{Value: 3, Timestamp: 1000}, {Value: 5, Timestamp: 2000}},
+			Exemplars:  []writev2.Exemplar{{LabelsRefs: symbols.SymbolizeLabels([]prompb.Label{{Name: "span_id", Value: "0102030405060708"}}, nil), Value: 5, Timestamp: 2000}},
+			Metadata: writev2.Metadata{
+				Type:    writev2.MetricTypeGauge,
+				HelpRef: symbols.Symbolize("Number of queued jobs"),
+			},
+		}},
+	}
+	writeRequest.Symbols = symbols.Symbols()
+	body, err := writeRequest.Marshal()
+	require.NoError(t, err)
+
+	resp := postWriteRequest(t, url, writev2.ContentType, body)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get(writev2.WrittenSamplesHeader))
+	assert.Equal(t, "0", resp.Header.Get(writev2.WrittenHistogramsHeader))
+	assert.Equal(t, "1", resp.Header.Get(writev2.WrittenExemplarsHeader))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	metric := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "queue_length", metric.Name())
+	assert.Equal(t, "Number of queued jobs", metric.Description())
+	require.Equal(t, pmetric.MetricTypeGauge, metric.Type())
+	assert.Equal(t, 2, metric.Gauge().DataPoints().Len())
+	assert.Equal(t, 1, metric.Gauge().DataPoints().At(1).Exemplars().Len())
+}
+
+func TestReceiveErrors(t *testing.T) {
+	body, err := (&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "up"}},
+			Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+		}},
+	}).Marshal()
+	require.NoError(t, err)
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		url := startReceiver(t, consumertest.NewNop())
+		resp := postWriteRequest(t, url, "application/x-protobuf;proto=unknown.Message", body)
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+		resp = postWriteRequest(t, url, "application/json", body)
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		url := startReceiver(t, consumertest.NewNop())
+		resp := postWriteRequest(t, url, writev2.ContentTypeV1, []byte{0xff, 0xff})
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		config := createDefaultConfig().(*Config)
+		config.MaxRequestBodySize = 16
+		url := startReceiverWithConfig(t, config, consumertest.NewNop())
+		resp := postWriteRequest(t, url, writev2.ContentTypeV1, body)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
+	t.Run("decompressed body too large", func(t *testing.T) {
+		largeBody, err := (&prompb.WriteRequest{
+			Timeseries: []prompb.TimeSeries{{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "up"}, {Name: "padding", Value: strings.Repeat("a", 4096)}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+			}},
+		}).Marshal()
+		require.NoError(t, err)
+		config := createDefaultConfig().(*Config)
+		config.MaxRequestBodySize = 1024
+		require.Less(t, len(snappy.Encode(nil, largeBody)), 1024)
+		url := startReceiverWithConfig(t, config, consumertest.NewNop())
+		resp := postWriteRequest(t, url, writev2.ContentTypeV1, largeBody)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
+	t.Run("retryable consumer error", func(t *testing.T) {
+		url := startReceiver(t, consumertest.NewErr(errors.New("queue is full")))
+		resp := postWriteRequest(t, url, writev2.ContentTypeV1, body)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+
+	t.Run("permanent consumer error", func(t *testing.T) {
+		url := startReceiver(t, consumertest.NewErr(consumererror.NewPermanent(errors.New("invalid metrics"))))
+		resp := postWriteRequest(t, url, writev2.ContentTypeV1, body)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestProtobufMessage(t *testing.T) {
+	tests := []struct {
+		contentType string
+		expected    string
+		err         bool
+	}{
+		{contentType: "", expected: protobufMessageV1},
+		{contentType: "application/x-protobuf", expected: protobufMessageV1},
+		{contentType: writev2.ContentTypeV1, expected: protobufMessageV1},
+		{contentType: writev2.ContentType, expected: protobufMessageV2},
+		{contentType: "application/x-protobuf; proto=io.prometheus.write.v2.Request", expected: protobufMessageV2},
+		{contentType: "application/x-protobuf;proto=unknown.Message", err: true},
+		{contentType: "text/plain", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			message, err := protobufMessage(tt.contentType)
+			if tt.err {
+				assert.ErrorIs(t, err, errUnsupportedContentType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, message)
+		})
+	}
+}
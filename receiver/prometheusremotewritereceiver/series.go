@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"fmt"
+
+	"github.com/prometheus/prometheus/prompb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+)
+
+// series is a remote write series, decoded from either version of the protocol.
+type series struct {
+	labels     []prompb.Label
+	samples    []prompb.Sample
+	histograms []prompb.Histogram
+	exemplars  []prompb.Exemplar
+	// metadata is only sent along with the series by remote write 2.0 clients,
+	// remote write 1.0 clients send it per metric family in separate requests.
+	metadata metricMetadata
+	// createdTimestamp is the time in milliseconds a cumulative series started at, 0 if unknown.
+	createdTimestamp int64
+}
+
+// metricMetadata describes the metric family a series belongs to.
+type metricMetadata struct {
+	typ  writev2.MetricType
+	help string
+	unit string
+}
+
+// seriesFromV1 returns the series of a remote write 1.0 request.
+func seriesFromV1(req *prompb.WriteRequest) []series {
+	seriesSet := make([]series, 0, len(req.Timeseries))
+	for _, ts := range req.Timeseries {
+		seriesSet = append(seriesSet, series{
+			labels:     ts.Labels,
+			samples:    ts.Samples,
+			histograms: ts.Histograms,
+			exemplars:  ts.Exemplars,
+		})
+	}
+	return seriesSet
+}
+
+// seriesFromV2 returns the series of a remote write 2.0 request, resolving their symbols.
+func seriesFromV2(req *writev2.Request) ([]series, error) {
+	seriesSet := make([]series, 0, len(req.Timeseries))
+	for i, ts := range req.Timeseries {
+		labels, err := writev2.DesymbolizeLabels(ts.LabelsRefs, req.Symbols)
+		if err != nil {
+			return nil, fmt.Errorf("invalid labels of series %d: %w", i, err)
+		}
+		help, err := writev2.Symbol(ts.Metadata.HelpRef, req.Symbols)
+		if err != nil {
+			return nil, fmt.Errorf("invalid help of series %d: %w", i, err)
+		}
+		unit, err := writev2.Symbol(ts.Metadata.UnitRef, req.Symbols)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unit of series %d: %w", i, err)
+		}
+
+		s := series{
+			labels:     labels,
+			samples:    make([]prompb.Sample, 0, len(ts.Samples)),
+			histograms: ts.Histograms,
+			metadata: metricMetadata{
+				typ:  ts.Metadata.Type,
+				help: help,
+				unit: unit,
+			},
+			createdTimestamp: ts.CreatedTimestamp,
+		}
+		for _, sample := range ts.Samples {
+			s.samples = append(s.samples, prompb.Sample{Value: sample.Value, Timestamp: sample.Timestamp})
+		}
+		for _, exemplar := range ts.Exemplars {
+			exemplarLabels, err := writev2.DesymbolizeLabels(exemplar.LabelsRefs, req.Symbols)
+			if err != nil {
+				return nil, fmt.Errorf("invalid exemplar labels of series %d: %w", i, err)
+			}
+			s.exemplars = append(s.exemplars, prompb.Exemplar{
+				Labels:    exemplarLabels,
+				Value:     exemplar.Value,
+				Timestamp: exemplar.Timestamp,
+			})
+		}
+		seriesSet = append(seriesSet, s)
+	}
+	return seriesSet, nil
+}
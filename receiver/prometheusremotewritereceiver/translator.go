@@ -0,0 +1,466 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"encoding/hex"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+)
+
+const (
+	receiverName   = "otelcol/prometheusremotewritereceiver"
+	targetInfoName = "target_info"
+	traceIDKey     = "trace_id"
+	spanIDKey      = "span_id"
+
+	bucketSuffix  = "_bucket"
+	sumSuffix     = "_sum"
+	countSuffix   = "_count"
+	totalSuffix   = "_total"
+	createdSuffix = "_created"
+)
+
+// seriesRole is the part a series plays in the metric it belongs to.
+type seriesRole int
+
+const (
+	roleValue seriesRole = iota
+	roleBucket
+	roleQuantile
+	roleSum
+	roleCount
+	roleCreated
+	roleNativeHistogram
+)
+
+// resourceKey identifies the target series were scraped from.
+type resourceKey struct {
+	job      string
+	instance string
+}
+
+// translator rebuilds OTLP metrics from remote write series. The remote write protocol flattens
+// histograms and summaries into one series per bucket or quantile, and target attributes into a
+// target_info series that is only sent when it changes, so the translator keeps state across requests.
+// The state of targets and metric families no series were received for during the expiration is
+// removed, so that it doesn't grow without bound as series come and go.
+type translator struct {
+	buildInfo  component.BuildInfo
+	expiration time.Duration
+	now        func() time.Time
+
+	mu sync.Mutex
+	// targetInfo holds the labels of the last target_info series of each target.
+	targetInfo map[resourceKey]*targetInfoEntry
+	// metadata holds the last metadata remote write 1.0 clients sent for each metric family.
+	metadata map[string]*metadataEntry
+	// lastExpiry is when the expired state was last removed.
+	lastExpiry time.Time
+}
+
+type targetInfoEntry struct {
+	labels   []prompb.Label
+	lastSeen time.Time
+}
+
+type metadataEntry struct {
+	metricMetadata
+	lastSeen time.Time
+}
+
+func newTranslator(buildInfo component.BuildInfo, expiration time.Duration) *translator {
+	return &translator{
+		buildInfo:  buildInfo,
+		expiration: expiration,
+		now:        time.Now,
+		targetInfo: make(map[resourceKey]*targetInfoEntry),
+		metadata:   make(map[string]*metadataEntry),
+		lastExpiry: time.Now(),
+	}
+}
+
+// toMetrics converts the series of a request to metrics, grouped in one resource per target.
+func (t *translator) toMetrics(seriesSet []series, metadata []prompb.MetricMetadata) pmetric.Metrics {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	defer t.removeExpired(now)
+
+	for _, m := range metadata {
+		t.metadata[m.MetricFamilyName] = &metadataEntry{
+			metricMetadata: metricMetadata{
+				typ:  writev2.MetricType(m.Type),
+				help: m.Help,
+				unit: m.Unit,
+			},
+			lastSeen: now,
+		}
+	}
+
+	// The families of the request are resolved first, so that the _sum and _count series of a
+	// histogram or summary are recognized regardless of the order of the series.
+	families := make(map[string]metricMetadata)
+	names := make(map[string]bool)
+	for _, s := range seriesSet {
+		name := labelValue(s.labels, model.MetricNameLabel)
+		names[name] = true
+		switch {
+		case name == targetInfoName:
+			t.updateTargetInfo(s, now)
+		case len(s.histograms) > 0:
+		case s.metadata.typ != writev2.MetricTypeUnspecified:
+			families[familyName(name, s.metadata.typ)] = s.metadata
+		case strings.HasSuffix(name, bucketSuffix) && hasLabel(s.labels, model.BucketLabel):
+			family := strings.TrimSuffix(name, bucketSuffix)
+			families[family] = t.cachedMetadata(family, writev2.MetricTypeHistogram, now)
+		case hasLabel(s.labels, model.QuantileLabel):
+			families[name] = t.cachedMetadata(name, writev2.MetricTypeSummary, now)
+		}
+	}
+	lookup := func(family string) (metricMetadata, bool) {
+		if md, ok := families[family]; ok {
+			return md, true
+		}
+		entry, ok := t.metadata[family]
+		if !ok {
+			return metricMetadata{}, false
+		}
+		entry.lastSeen = now
+		return entry.metricMetadata, true
+	}
+
+	b := newMetricsBuilder()
+	created := make(map[createdKey]int64)
+	for _, s := range seriesSet {
+		name := labelValue(s.labels, model.MetricNameLabel)
+		if name == "" || name == targetInfoName {
+			continue
+		}
+		family, md, role := resolveSeries(name, s, lookup, names)
+		target := resourceKey{
+			job:      labelValue(s.labels, model.JobLabel),
+			instance: labelValue(s.labels, model.InstanceLabel),
+		}
+		attrs := pointAttributes(s.labels)
+		signature := labelsSignature(attrs)
+
+		if role == roleCreated {
+			if len(s.samples) > 0 {
+				// _created series hold the start time in seconds of the series of their family.
+				createdSeconds := s.samples[len(s.samples)-1].Value
+				if !math.IsNaN(createdSeconds) {
+					created[createdKey{target: target, family: family, signature: signature}] = int64(createdSeconds * 1000)
+				}
+			}
+			continue
+		}
+
+		m := b.metric(target, family, md, metricType(role, md.typ))
+		var last *point
+		switch role {
+		case roleNativeHistogram:
+			for i := range s.histograms {
+				last = m.point(attrs, signature, s.histograms[i].Timestamp)
+				last.histogram = &s.histograms[i]
+				last.start = s.createdTimestamp
+			}
+		default:
+			bound, ok := parseBound(role, s.labels)
+			if !ok {
+				continue
+			}
+			for _, sample := range s.samples {
+				last = m.point(attrs, signature, sample.Timestamp)
+				last.add(role, bound, sample.Value)
+				if s.createdTimestamp != 0 {
+					last.start = s.createdTimestamp
+				}
+			}
+		}
+		if last != nil {
+			last.exemplars = append(last.exemplars, s.exemplars...)
+		}
+	}
+
+	return b.build(t, created, now)
+}
+
+// cachedMetadata returns the metadata last sent for family, of the given type if its type is unknown.
+func (t *translator) cachedMetadata(family string, typ writev2.MetricType, now time.Time) metricMetadata {
+	var md metricMetadata
+	if entry, ok := t.metadata[family]; ok {
+		entry.lastSeen = now
+		md = entry.metricMetadata
+	}
+	if md.typ == writev2.MetricTypeUnspecified {
+		md.typ = typ
+	}
+	return md
+}
+
+// removeExpired removes the target_info labels and metadata which were not used during the
+// expiration. It only scans the state once per expiration, so it is kept for up to twice as long.
+func (t *translator) removeExpired(now time.Time) {
+	if now.Sub(t.lastExpiry) < t.expiration {
+		return
+	}
+	t.lastExpiry = now
+	for target, entry := range t.targetInfo {
+		if now.Sub(entry.lastSeen) >= t.expiration {
+			delete(t.targetInfo, target)
+		}
+	}
+	for family, entry := range t.metadata {
+		if now.Sub(entry.lastSeen) >= t.expiration {
+			delete(t.metadata, family)
+		}
+	}
+}
+
+// updateTargetInfo records the labels of a target_info series as the resource attributes of its target.
+func (t *translator) updateTargetInfo(s series, now time.Time) {
+	target := resourceKey{
+		job:      labelValue(s.labels, model.JobLabel),
+		instance: labelValue(s.labels, model.InstanceLabel),
+	}
+	if len(s.samples) > 0 && value.IsStaleNaN(s.samples[len(s.samples)-1].Value) {
+		delete(t.targetInfo, target)
+		return
+	}
+	labels := make([]prompb.Label, 0, len(s.labels))
+	for _, l := range s.labels {
+		switch l.Name {
+		case model.MetricNameLabel, model.JobLabel, model.InstanceLabel:
+		default:
+			labels = append(labels, l)
+		}
+	}
+	t.targetInfo[target] = &targetInfoEntry{labels: labels, lastSeen: now}
+}
+
+// setResourceAttributes sets the attributes of the resource of target, following the
+// conventions used by the Prometheus remote write exporter to build job and instance.
+func (t *translator) setResourceAttributes(target resourceKey, attrs pcommon.Map, now time.Time) {
+	if target.job != "" {
+		if namespace, name, ok := strings.Cut(target.job, "/"); ok {
+			attrs.PutStr(conventions.AttributeServiceNamespace, namespace)
+			attrs.PutStr(conventions.AttributeServiceName, name)
+		} else {
+			attrs.PutStr(conventions.AttributeServiceName, target.job)
+		}
+	}
+	if target.instance != "" {
+		attrs.PutStr(conventions.AttributeServiceInstanceID, target.instance)
+	}
+	if entry, ok := t.targetInfo[target]; ok {
+		entry.lastSeen = now
+		for _, l := range entry.labels {
+			attrs.PutStr(l.Name, l.Value)
+		}
+	}
+}
+
+// familyName returns the name of the family of a series with metadata of the given type.
+func familyName(name string, typ writev2.MetricType) string {
+	switch typ {
+	case writev2.MetricTypeHistogram, writev2.MetricTypeGaugeHistogram:
+		for _, suffix := range []string{bucketSuffix, sumSuffix, countSuffix, createdSuffix} {
+			if family, ok := strings.CutSuffix(name, suffix); ok {
+				return family
+			}
+		}
+	case writev2.MetricTypeSummary:
+		for _, suffix := range []string{sumSuffix, countSuffix, createdSuffix} {
+			if family, ok := strings.CutSuffix(name, suffix); ok {
+				return family
+			}
+		}
+	}
+	return name
+}
+
+// resolveSeries returns the name and metadata of the metric a series belongs to, and its role in it.
+func resolveSeries(name string, s series, lookup func(string) (metricMetadata, bool), names map[string]bool) (string, metricMetadata, seriesRole) {
+	if len(s.histograms) > 0 {
+		md, _ := lookup(name)
+		if s.metadata.typ != writev2.MetricTypeUnspecified {
+			md = s.metadata
+		}
+		md.typ = writev2.MetricTypeHistogram
+		return name, md, roleNativeHistogram
+	}
+
+	for _, suffix := range []string{bucketSuffix, sumSuffix, countSuffix, createdSuffix} {
+		family, ok := strings.CutSuffix(name, suffix)
+		if !ok {
+			continue
+		}
+		md, ok := lookup(family)
+		if !ok {
+			if suffix == createdSuffix && names[family+totalSuffix] {
+				return family, md, roleCreated
+			}
+			continue
+		}
+		histogram := md.typ == writev2.MetricTypeHistogram || md.typ == writev2.MetricTypeGaugeHistogram
+		summary := md.typ == writev2.MetricTypeSummary
+		switch {
+		case suffix == bucketSuffix && histogram:
+			return family, md, roleBucket
+		case suffix == sumSuffix && (histogram || summary):
+			return family, md, roleSum
+		case suffix == countSuffix && (histogram || summary):
+			return family, md, roleCount
+		case suffix == createdSuffix && (histogram || summary || md.typ == writev2.MetricTypeCounter):
+			return family, md, roleCreated
+		}
+	}
+
+	md, ok := lookup(name)
+	if !ok {
+		// Counters may be described by the metadata of their family, without the _total suffix.
+		if family, isTotal := strings.CutSuffix(name, totalSuffix); isTotal {
+			if familyMetadata, found := lookup(family); found && familyMetadata.typ == writev2.MetricTypeCounter {
+				md, ok = familyMetadata, true
+			}
+		}
+	}
+	if s.metadata.typ != writev2.MetricTypeUnspecified {
+		md, ok = s.metadata, true
+	}
+	if md.typ == writev2.MetricTypeSummary && hasLabel(s.labels, model.QuantileLabel) {
+		return name, md, roleQuantile
+	}
+	if (!ok || md.typ == writev2.MetricTypeUnspecified) && strings.HasSuffix(name, totalSuffix) {
+		md.typ = writev2.MetricTypeCounter
+	}
+	return name, md, roleValue
+}
+
+// metricType returns the type of the OTLP metric series of the given role are converted to.
+func metricType(role seriesRole, typ writev2.MetricType) pmetric.MetricType {
+	switch role {
+	case roleNativeHistogram:
+		return pmetric.MetricTypeExponentialHistogram
+	case roleBucket:
+		return pmetric.MetricTypeHistogram
+	case roleQuantile:
+		return pmetric.MetricTypeSummary
+	case roleSum, roleCount:
+		if typ == writev2.MetricTypeSummary {
+			return pmetric.MetricTypeSummary
+		}
+		return pmetric.MetricTypeHistogram
+	}
+	if typ == writev2.MetricTypeCounter {
+		return pmetric.MetricTypeSum
+	}
+	return pmetric.MetricTypeGauge
+}
+
+// parseBound returns the upper bound of a bucket series or the quantile of a quantile series.
+func parseBound(role seriesRole, labels []prompb.Label) (float64, bool) {
+	var name string
+	switch role {
+	case roleBucket:
+		name = model.BucketLabel
+	case roleQuantile:
+		name = model.QuantileLabel
+	default:
+		return 0, true
+	}
+	bound, err := strconv.ParseFloat(labelValue(labels, name), 64)
+	return bound, err == nil
+}
+
+// pointAttributes returns the labels of a series that become attributes of its data points,
+// sorted by name.
+func pointAttributes(labels []prompb.Label) []prompb.Label {
+	attrs := make([]prompb.Label, 0, len(labels))
+	for _, l := range labels {
+		switch l.Name {
+		case model.MetricNameLabel, model.JobLabel, model.InstanceLabel, model.BucketLabel, model.QuantileLabel:
+		default:
+			attrs = append(attrs, l)
+		}
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Name < attrs[j].Name
+	})
+	return attrs
+}
+
+// labelsSignature returns a string identifying sorted labels.
+func labelsSignature(labels []prompb.Label) string {
+	var sb strings.Builder
+	for _, l := range labels {
+		sb.WriteString(l.Name)
+		sb.WriteByte(0xff)
+		sb.WriteString(l.Value)
+		sb.WriteByte(0xff)
+	}
+	return sb.String()
+}
+
+func labelValue(labels []prompb.Label, name string) string {
+	for _, l := range labels {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func hasLabel(labels []prompb.Label, name string) bool {
+	for _, l := range labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func timestampFromMs(timeAtMs int64) pcommon.Timestamp {
+	return pcommon.Timestamp(timeAtMs * int64(time.Millisecond))
+}
+
+func convertExemplar(exemplar prompb.Exemplar, dest pmetric.Exemplar) {
+	dest.SetTimestamp(timestampFromMs(exemplar.Timestamp))
+	dest.SetDoubleValue(exemplar.Value)
+	for _, l := range exemplar.Labels {
+		switch l.Name {
+		case traceIDKey:
+			var traceID pcommon.TraceID
+			if id, err := hex.DecodeString(l.Value); err == nil && len(id) == len(traceID) {
+				copy(traceID[:], id)
+				dest.SetTraceID(traceID)
+				continue
+			}
+		case spanIDKey:
+			var spanID pcommon.SpanID
+			if id, err := hex.DecodeString(l.Value); err == nil && len(id) == len(spanID) {
+				copy(spanID[:], id)
+				dest.SetSpanID(spanID)
+				continue
+			}
+		}
+		dest.FilteredAttributes().PutStr(l.Name, l.Value)
+	}
+}
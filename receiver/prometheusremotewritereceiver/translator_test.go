@@ -0,0 +1,265 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+)
+
+func newSeries(name string, v float64, ts int64, labels ...string) series {
+	s := series{
+		labels:  []prompb.Label{{Name: "__name__", Value: name}, {Name: "job", Value: "shop/api"}, {Name: "instance", Value: "api-0:8080"}},
+		samples: []prompb.Sample{{Value: v, Timestamp: ts}},
+	}
+	for i := 0; i < len(labels); i += 2 {
+		s.labels = append(s.labels, prompb.Label{Name: labels[i], Value: labels[i+1]})
+	}
+	return s
+}
+
+// metricsByName returns the metrics of the only resource of md.
+func metricsByName(t *testing.T, md pmetric.Metrics) map[string]pmetric.Metric {
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	byName := make(map[string]pmetric.Metric, metrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		byName[metrics.At(i).Name()] = metrics.At(i)
+	}
+	return byName
+}
+
+func TestTranslatorResource(t *testing.T) {
+	tr := newTranslator(component.BuildInfo{Version: "1.2.3"}, defaultMetadataExpiration)
+
+	targetInfo := newSeries("target_info", 1, 1000, "k8s_pod_name", "api-0")
+	md := tr.toMetrics([]series{targetInfo, newSeries("up", 1, 1000)}, nil)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{
+		"service.namespace":   "shop",
+		"service.name":        "api",
+		"service.instance.id": "api-0:8080",
+		"k8s_pod_name":        "api-0",
+	}, rm.Resource().Attributes().AsRaw())
+	assert.Equal(t, receiverName, rm.ScopeMetrics().At(0).Scope().Name())
+	assert.Equal(t, "1.2.3", rm.ScopeMetrics().At(0).Scope().Version())
+	metrics := metricsByName(t, md)
+	assert.NotContains(t, metrics, "target_info")
+	assert.Contains(t, metrics, "up")
+
+	// target_info is only sent when it changes, later requests keep its attributes.
+	md = tr.toMetrics([]series{newSeries("up", 1, 2000)}, nil)
+	attr, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("k8s_pod_name")
+	assert.True(t, ok)
+	assert.Equal(t, "api-0", attr.Str())
+
+	// Once the target is gone, its attributes are forgotten.
+	staleInfo := newSeries("target_info", math.Float64frombits(value.StaleNaN), 3000)
+	md = tr.toMetrics([]series{staleInfo, newSeries("up", 1, 3000)}, nil)
+	_, ok = md.ResourceMetrics().At(0).Resource().Attributes().Get("k8s_pod_name")
+	assert.False(t, ok)
+}
+
+func TestTranslatorCounterAndGauge(t *testing.T) {
+	tr := newTranslator(component.BuildInfo{}, defaultMetadataExpiration)
+
+	created := newSeries("http_requests_created", 500, 1000, "method", "GET")
+	counter := newSeries("http_requests_total", 42, 1000, "method", "GET")
+	counter.exemplars = []prompb.Exemplar{{
+		Labels:    []prompb.Label{{Name: "trace_id", Value: "0102030405060708090a0b0c0d0e0f10"}, {Name: "user", Value: "alice"}},
+		Value:     1,
+		Timestamp: 900,
+	}}
+	gauge := newSeries("memory_usage_bytes", 1024, 1000)
+	md := tr.toMetrics([]series{created, counter, gauge}, nil)
+
+	metrics := metricsByName(t, md)
+	assert.NotContains(t, metrics, "http_requests_created")
+
+	requests := metrics["http_requests_total"]
+	require.Equal(t, pmetric.MetricTypeSum, requests.Type())
+	assert.True(t, requests.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, requests.Sum().AggregationTemporality())
+	dp := requests.Sum().DataPoints().At(0)
+	assert.Equal(t, 42.0, dp.DoubleValue())
+	assert.Equal(t, timestampFromMs(1000), dp.Timestamp())
+	assert.Equal(t, timestampFromMs(500_000), dp.StartTimestamp())
+	assert.Equal(t, map[string]any{"method": "GET"}, dp.Attributes().AsRaw())
+	require.Equal(t, 1, dp.Exemplars().Len())
+	exemplar := dp.Exemplars().At(0)
+	assert.Equal(t, pcommon.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}, exemplar.TraceID())
+	assert.Equal(t, map[string]any{"user": "alice"}, exemplar.FilteredAttributes().AsRaw())
+
+	memory := metrics["memory_usage_bytes"]
+	require.Equal(t, pmetric.MetricTypeGauge, memory.Type())
+	assert.Equal(t, 1024.0, memory.Gauge().DataPoints().At(0).DoubleValue())
+}
+
+func TestTranslatorHistogram(t *testing.T) {
+	tr := newTranslator(component.BuildInfo{}, defaultMetadataExpiration)
+
+	// _sum and _count may be sent before the buckets of the histogram.
+	md := tr.toMetrics([]series{
+		newSeries("latency_seconds_sum", 3, 1000),
+		newSeries("latency_seconds_count", 4, 1000),
+		newSeries("latency_seconds_bucket", 4, 1000, "le", "+Inf"),
+		newSeries("latency_seconds_bucket", 1, 1000, "le", "0.1"),
+		newSeries("latency_seconds_bucket", 3, 1000, "le", "1"),
+		newSeries("latency_seconds_created", 100, 1000),
+	}, nil)
+
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 1)
+	latency := metrics["latency_seconds"]
+	require.Equal(t, pmetric.MetricTypeHistogram, latency.Type())
+	require.Equal(t, 1, latency.Histogram().DataPoints().Len())
+	dp := latency.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.Equal(t, 3.0, dp.Sum())
+	assert.Equal(t, []float64{0.1, 1}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 2, 1}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, timestampFromMs(100_000), dp.StartTimestamp())
+	assert.Zero(t, dp.Attributes().Len())
+}
+
+func TestTranslatorSummary(t *testing.T) {
+	tr := newTranslator(component.BuildInfo{}, defaultMetadataExpiration)
+
+	md := tr.toMetrics([]series{
+		newSeries("rpc_duration_seconds", 0.9, 1000, "quantile", "0.99"),
+		newSeries("rpc_duration_seconds", 0.5, 1000, "quantile", "0.5"),
+		newSeries("rpc_duration_seconds_sum", 120, 1000),
+		newSeries("rpc_duration_seconds_count", 200, 1000),
+	}, nil)
+
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 1)
+	rpc := metrics["rpc_duration_seconds"]
+	require.Equal(t, pmetric.MetricTypeSummary, rpc.Type())
+	dp := rpc.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(200), dp.Count())
+	assert.Equal(t, 120.0, dp.Sum())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.5, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.5, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 0.99, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 0.9, dp.QuantileValues().At(1).Value())
+}
+
+func TestTranslatorMetadata(t *testing.T) {
+	tr := newTranslator(component.BuildInfo{}, defaultMetadataExpiration)
+
+	// Remote write 1.0 clients send metadata in separate requests.
+	md := tr.toMetrics(nil, []prompb.MetricMetadata{{
+		Type:             prompb.MetricMetadata_COUNTER,
+		MetricFamilyName: "jobs",
+		Help:             "Number of jobs",
+		Unit:             "jobs",
+	}})
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	md = tr.toMetrics([]series{newSeries("jobs", 7, 1000)}, nil)
+	jobs := metricsByName(t, md)["jobs"]
+	assert.Equal(t, pmetric.MetricTypeSum, jobs.Type())
+	assert.Equal(t, "Number of jobs", jobs.Description())
+	assert.Equal(t, "jobs", jobs.Unit())
+
+	// Remote write 2.0 clients send metadata with every series.
+	temperature := newSeries("temperature_total", 21, 1000)
+	temperature.metadata = metricMetadata{typ: writev2.MetricTypeGauge, help: "Temperature", unit: "Cel"}
+	temperature.createdTimestamp = 500
+	md = tr.toMetrics([]series{temperature}, nil)
+	metric := metricsByName(t, md)["temperature_total"]
+	require.Equal(t, pmetric.MetricTypeGauge, metric.Type())
+	assert.Equal(t, "Temperature", metric.Description())
+	assert.Equal(t, "Cel", metric.Unit())
+}
+
+func TestTranslatorExpiration(t *testing.T) {
+	tr := newTranslator(component.BuildInfo{}, time.Minute)
+	now := time.Unix(1000, 0)
+	tr.now = func() time.Time { return now }
+	tr.lastExpiry = now
+
+	targetInfo := newSeries("target_info", 1, 1000, "k8s_pod_name", "api-0")
+	tr.toMetrics([]series{targetInfo}, []prompb.MetricMetadata{
+		{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "jobs", Help: "Number of jobs"},
+		{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "queue_length", Help: "Number of queued jobs"},
+	})
+	require.Len(t, tr.targetInfo, 1)
+	require.Len(t, tr.metadata, 2)
+
+	// The target and the jobs family keep being used, queue_length is not.
+	now = now.Add(30 * time.Second)
+	md := tr.toMetrics([]series{newSeries("jobs_total", 1, 31_000)}, nil)
+	assert.Equal(t, "Number of jobs", metricsByName(t, md)["jobs_total"].Description())
+	now = now.Add(40 * time.Second)
+	md = tr.toMetrics([]series{newSeries("jobs_total", 2, 71_000)}, nil)
+	attr, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("k8s_pod_name")
+	assert.True(t, ok)
+	assert.Equal(t, "api-0", attr.Str())
+	assert.Len(t, tr.targetInfo, 1)
+	assert.Contains(t, tr.metadata, "jobs")
+	assert.NotContains(t, tr.metadata, "queue_length")
+
+	// Once the target stops sending series, its state is removed.
+	now = now.Add(2 * time.Minute)
+	other := newSeries("up", 1, 191_000)
+	other.labels[1].Value = "shop/worker"
+	tr.toMetrics([]series{other}, nil)
+	assert.Empty(t, tr.targetInfo)
+	assert.Empty(t, tr.metadata)
+}
+
+func TestTranslatorStaleMarker(t *testing.T) {
+	tr := newTranslator(component.BuildInfo{}, defaultMetadataExpiration)
+
+	md := tr.toMetrics([]series{newSeries("up", math.Float64frombits(value.StaleNaN), 1000)}, nil)
+	dp := metricsByName(t, md)["up"].Gauge().DataPoints().At(0)
+	assert.True(t, dp.Flags().NoRecordedValue())
+}
+
+func TestTranslatorNativeHistogram(t *testing.T) {
+	tr := newTranslator(component.BuildInfo{}, defaultMetadataExpiration)
+
+	s := newSeries("latency_seconds", 0, 0)
+	s.samples = nil
+	s.histograms = []prompb.Histogram{{
+		Count:          &prompb.Histogram_CountInt{CountInt: 6},
+		Sum:            10,
+		Schema:         1,
+		ZeroThreshold:  0.001,
+		ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+		PositiveSpans:  []prompb.BucketSpan{{Offset: 2, Length: 2}, {Offset: 1, Length: 1}},
+		PositiveDeltas: []int64{1, 1, -1},
+		Timestamp:      1000,
+	}}
+	s.createdTimestamp = 500
+	md := tr.toMetrics([]series{s}, nil)
+
+	metric := metricsByName(t, md)["latency_seconds"]
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, metric.Type())
+	dp := metric.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	assert.Equal(t, 10.0, dp.Sum())
+	assert.Equal(t, int32(1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 2, 0, 1}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, timestampFromMs(500), dp.StartTimestamp())
+	assert.Equal(t, timestampFromMs(1000), dp.Timestamp())
+}
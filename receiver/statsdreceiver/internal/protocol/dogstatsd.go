@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DogStatsD extensions to the StatsD protocol, see
+// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+	containerIDPrefix  = "c:"
+	timestampPrefix    = "T"
+
+	// eventMetricName is the name of the counter events are aggregated into.
+	eventMetricName = "dogstatsd.events"
+
+	attributeContainerID         = "container.id"
+	attributeHostName            = "host.name"
+	attributeEventTitle          = "event.title"
+	attributeEventPriority       = "event.priority"
+	attributeEventAlertType      = "event.alert_type"
+	attributeEventAggregationKey = "event.aggregation_key"
+	attributeEventSourceType     = "event.source_type_name"
+
+	defaultEventPriority  = "normal"
+	defaultEventAlertType = "info"
+
+	// maxServiceCheckStatus is the UNKNOWN service check status, OK, WARNING and CRITICAL are 0 to 2.
+	maxServiceCheckStatus = 3
+)
+
+// parseEvent parses a DogStatsD event into a counter of the events with the same title and metadata,
+// the text of the event is dropped.
+//
+//	_e{<TITLE_LENGTH>,<TEXT_LENGTH>}:<TITLE>|<TEXT>|d:<TIMESTAMP>|h:<HOSTNAME>|p:<PRIORITY>|t:<ALERT_TYPE>|#<TAGS>
+func parseEvent(line string, enableMetricType bool) (statsDMetric, error) {
+	result := statsDMetric{
+		description: statsDMetricDescription{
+			name:       eventMetricName,
+			metricType: CounterType,
+		},
+		asFloat: 1,
+	}
+
+	lengths, rest, ok := strings.Cut(strings.TrimPrefix(line, eventPrefix), "}:")
+	if !ok {
+		return result, fmt.Errorf("invalid event format: %s", line)
+	}
+	titleLenStr, textLenStr, ok := strings.Cut(lengths, ",")
+	if !ok {
+		return result, fmt.Errorf("invalid event format: %s", line)
+	}
+	titleLen, err := strconv.Atoi(titleLenStr)
+	if err != nil || titleLen <= 0 {
+		return result, fmt.Errorf("parse event title length: %s", titleLenStr)
+	}
+	textLen, err := strconv.Atoi(textLenStr)
+	if err != nil || textLen < 0 {
+		return result, fmt.Errorf("parse event text length: %s", textLenStr)
+	}
+	if len(rest) < titleLen+1+textLen || rest[titleLen] != '|' {
+		return result, fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+
+	kvs := []attribute.KeyValue{attribute.String(attributeEventTitle, rest[:titleLen])}
+	priority := defaultEventPriority
+	alertType := defaultEventAlertType
+
+	rest = rest[titleLen+1+textLen:]
+	if rest != "" {
+		if !strings.HasPrefix(rest, "|") {
+			return result, fmt.Errorf("event title and text do not match their lengths: %s", line)
+		}
+		for _, part := range strings.Split(rest[1:], "|") {
+			switch {
+			case strings.HasPrefix(part, "d:"):
+				if result.timestamp, err = parseTimestamp(strings.TrimPrefix(part, "d:")); err != nil {
+					return result, err
+				}
+			case strings.HasPrefix(part, "h:"):
+				kvs = append(kvs, attribute.String(attributeHostName, strings.TrimPrefix(part, "h:")))
+			case strings.HasPrefix(part, "p:"):
+				priority = strings.TrimPrefix(part, "p:")
+			case strings.HasPrefix(part, "t:"):
+				alertType = strings.TrimPrefix(part, "t:")
+			case strings.HasPrefix(part, "k:"):
+				kvs = append(kvs, attribute.String(attributeEventAggregationKey, strings.TrimPrefix(part, "k:")))
+			case strings.HasPrefix(part, "s:"):
+				kvs = append(kvs, attribute.String(attributeEventSourceType, strings.TrimPrefix(part, "s:")))
+			case strings.HasPrefix(part, containerIDPrefix):
+				kvs = append(kvs, attribute.String(attributeContainerID, strings.TrimPrefix(part, containerIDPrefix)))
+			case strings.HasPrefix(part, "#"):
+				if kvs, err = parseTags(strings.TrimPrefix(part, "#"), kvs); err != nil {
+					return result, err
+				}
+			default:
+				return result, fmt.Errorf("unrecognized event part: %s", part)
+			}
+		}
+	}
+	kvs = append(kvs,
+		attribute.String(attributeEventPriority, priority),
+		attribute.String(attributeEventAlertType, alertType),
+	)
+
+	result.setAttributes(kvs, enableMetricType)
+	return result, nil
+}
+
+// parseServiceCheck parses a DogStatsD service check into a gauge named after the check,
+// whose value is the status of the check. The message of the check is dropped.
+//
+//	_sc|<NAME>|<STATUS>|d:<TIMESTAMP>|h:<HOSTNAME>|#<TAGS>|m:<SERVICE_CHECK_MESSAGE>
+func parseServiceCheck(line string, enableMetricType bool) (statsDMetric, error) {
+	result := statsDMetric{}
+
+	parts := strings.Split(strings.TrimPrefix(line, serviceCheckPrefix), "|")
+	if len(parts) < 2 {
+		return result, fmt.Errorf("invalid service check format: %s", line)
+	}
+
+	result.description.name = parts[0]
+	if result.description.name == "" {
+		return result, errEmptyMetricName
+	}
+	result.description.metricType = GaugeType
+
+	status, err := strconv.Atoi(parts[1])
+	if err != nil || status < 0 || status > maxServiceCheckStatus {
+		return result, fmt.Errorf("parse service check status: %s", parts[1])
+	}
+	result.asFloat = float64(status)
+
+	var kvs []attribute.KeyValue
+loop:
+	for _, part := range parts[2:] {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			if result.timestamp, err = parseTimestamp(strings.TrimPrefix(part, "d:")); err != nil {
+				return result, err
+			}
+		case strings.HasPrefix(part, "h:"):
+			kvs = append(kvs, attribute.String(attributeHostName, strings.TrimPrefix(part, "h:")))
+		case strings.HasPrefix(part, containerIDPrefix):
+			kvs = append(kvs, attribute.String(attributeContainerID, strings.TrimPrefix(part, containerIDPrefix)))
+		case strings.HasPrefix(part, "#"):
+			if kvs, err = parseTags(strings.TrimPrefix(part, "#"), kvs); err != nil {
+				return result, err
+			}
+		case strings.HasPrefix(part, "m:"):
+			// The message is the last field and may itself contain "|".
+			// It is free text, keeping it as an attribute would create a time series per message.
+			break loop
+		default:
+			return result, fmt.Errorf("unrecognized service check part: %s", part)
+		}
+	}
+
+	result.setAttributes(kvs, enableMetricType)
+	return result, nil
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func Test_ParseDogStatsDMessageToMetric(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantMetric statsDMetric
+		err        error
+	}{
+		{
+			name:  "event",
+			input: "_e{5,4}:title|text",
+			wantMetric: statsDMetric{
+				description: testDescription(eventMetricName, CounterType,
+					[]string{"event.title", "event.priority", "event.alert_type"},
+					[]string{"title", "normal", "info"}),
+				asFloat: 1,
+			},
+		},
+		{
+			name:  "event with metadata",
+			input: "_e{10,11}:deploy|api|new version|d:1697000000|h:host-1|p:low|t:success|k:deploy-42|s:ci|#env:prod|c:abc123",
+			wantMetric: statsDMetric{
+				description: testDescription(eventMetricName, CounterType,
+					[]string{"event.title", "host.name", "event.priority", "event.alert_type", "event.aggregation_key", "event.source_type_name", "env", "container.id"},
+					[]string{"deploy|api", "host-1", "low", "success", "deploy-42", "ci", "prod", "abc123"}),
+				asFloat:   1,
+				timestamp: time.Unix(1697000000, 0),
+			},
+		},
+		{
+			name:  "event with escaped new lines in text",
+			input: "_e{5,12}:title|line1\\nline2|t:error",
+			wantMetric: statsDMetric{
+				description: testDescription(eventMetricName, CounterType,
+					[]string{"event.title", "event.priority", "event.alert_type"},
+					[]string{"title", "normal", "error"}),
+				asFloat: 1,
+			},
+		},
+		{
+			name:  "event with invalid lengths",
+			input: "_e{5,x}:title|text",
+			err:   errors.New("parse event text length: x"),
+		},
+		{
+			name:  "event shorter than its lengths",
+			input: "_e{5,10}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{5,10}:title|text"),
+		},
+		{
+			name:  "event with unrecognized part",
+			input: "_e{5,4}:title|text|x:y",
+			err:   errors.New("unrecognized event part: x:y"),
+		},
+		{
+			name:  "service check",
+			input: "_sc|db.ping|2|d:1697000000|h:host-1|#env:prod|c:abc123|m:connection refused|retrying",
+			wantMetric: statsDMetric{
+				description: testDescription("db.ping", GaugeType,
+					[]string{"host.name", "env", "container.id"},
+					[]string{"host-1", "prod", "abc123"}),
+				asFloat:   2,
+				timestamp: time.Unix(1697000000, 0),
+			},
+		},
+		{
+			name:  "service check without metadata",
+			input: "_sc|db.ping|0",
+			wantMetric: statsDMetric{
+				description: statsDMetricDescription{name: "db.ping", metricType: GaugeType},
+			},
+		},
+		{
+			name:  "service check with invalid status",
+			input: "_sc|db.ping|4",
+			err:   errors.New("parse service check status: 4"),
+		},
+		{
+			name:  "service check without name",
+			input: "_sc||0",
+			err:   errors.New("empty metric name"),
+		},
+		{
+			name:  "service check with invalid timestamp",
+			input: "_sc|db.ping|0|d:soon",
+			err:   errors.New("parse timestamp: soon"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMessageToMetric(tt.input, false)
+
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantMetric, got)
+			}
+		})
+	}
+}
+
+func TestStatsDParser_AggregateDogStatsD(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	require.NoError(t, p.Initialize(false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	require.NoError(t, p.Aggregate("_e{5,4}:title|text", addr))
+	require.NoError(t, p.Aggregate("_e{5,5}:title|text2", addr))
+	require.NoError(t, p.Aggregate("_sc|db.ping|1|d:600", addr))
+	require.NoError(t, p.Aggregate("_sc|db.ping|0|d:650", addr))
+
+	metrics := p.GetMetrics()[0].Metrics
+	require.Equal(t, 2, metrics.MetricCount())
+	scopeMetrics := metrics.ResourceMetrics().At(0).ScopeMetrics()
+
+	events := scopeMetrics.At(1).Metrics().At(0)
+	assert.Equal(t, eventMetricName, events.Name())
+	assert.Equal(t, int64(2), events.Sum().DataPoints().At(0).IntValue())
+
+	check := scopeMetrics.At(0).Metrics().At(0)
+	assert.Equal(t, "db.ping", check.Name())
+	dp := check.Gauge().DataPoints().At(0)
+	assert.Equal(t, 0.0, dp.DoubleValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(650, 0)), dp.Timestamp())
+}
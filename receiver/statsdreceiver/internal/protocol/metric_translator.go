@@ -30,6 +30,9 @@ func buildCounterMetric(parsedMetric statsDMetric, isMonotonicCounter bool) pmet
 
 	dp := nm.Sum().DataPoints().AppendEmpty()
 	dp.SetIntValue(parsedMetric.counterValue())
+	if !parsedMetric.timestamp.IsZero() {
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(parsedMetric.timestamp))
+	}
 	for i := parsedMetric.description.attrs.Iter(); i.Next(); {
 		dp.Attributes().PutStr(string(i.Attribute().Key), i.Attribute().Value.AsString())
 	}
@@ -40,7 +43,10 @@ func buildCounterMetric(parsedMetric statsDMetric, isMonotonicCounter bool) pmet
 func setTimestampsForCounterMetric(ilm pmetric.ScopeMetrics, startTime, timeNow time.Time) {
 	dp := ilm.Metrics().At(0).Sum().DataPoints().At(0)
 	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(startTime))
-	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	// Keep the client-side timestamp of DogStatsD metrics.
+	if dp.Timestamp() == 0 {
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	}
 }
 
 func buildGaugeMetric(parsedMetric statsDMetric, timeNow time.Time) pmetric.ScopeMetrics {
@@ -50,6 +56,9 @@ func buildGaugeMetric(parsedMetric statsDMetric, timeNow time.Time) pmetric.Scop
 	if parsedMetric.unit != "" {
 		nm.SetUnit(parsedMetric.unit)
 	}
+	if !parsedMetric.timestamp.IsZero() {
+		timeNow = parsedMetric.timestamp
+	}
 	dp := nm.SetEmptyGauge().DataPoints().AppendEmpty()
 	dp.SetDoubleValue(parsedMetric.gaugeValue())
 	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
@@ -60,6 +69,17 @@ func buildGaugeMetric(parsedMetric statsDMetric, timeNow time.Time) pmetric.Scop
 	return ilm
 }
 
+func buildSetMetric(desc statsDMetricDescription, set setMetric, timeNow time.Time, ilm pmetric.ScopeMetrics) {
+	nm := ilm.Metrics().AppendEmpty()
+	nm.SetName(desc.name)
+	dp := nm.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetIntValue(int64(len(set)))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	for i := desc.attrs.Iter(); i.Next(); {
+		dp.Attributes().PutStr(string(i.Attribute().Key), i.Attribute().Value.AsString())
+	}
+}
+
 func buildSummaryMetric(desc statsDMetricDescription, summary summaryMetric, startTime, timeNow time.Time, percentiles []float64, ilm pmetric.ScopeMetrics) {
 	nm := ilm.Metrics().AppendEmpty()
 	nm.SetName(desc.name)
@@ -38,6 +38,7 @@ const (
 	HistogramType    MetricType = "h"
 	TimingType       MetricType = "ms"
 	DistributionType MetricType = "d"
+	SetType          MetricType = "s"
 
 	CounterTypeName      TypeName = "counter"
 	GaugeTypeName        TypeName = "gauge"
@@ -45,6 +46,7 @@ const (
 	TimingTypeName       TypeName = "timing"
 	TimingAltTypeName    TypeName = "timer"
 	DistributionTypeName TypeName = "distribution"
+	SetTypeName          TypeName = "set"
 
 	GaugeObserver     ObserverType = "gauge"
 	SummaryObserver   ObserverType = "summary"
@@ -92,6 +94,7 @@ type instruments struct {
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
 	summaries              map[statsDMetricDescription]summaryMetric
 	histograms             map[statsDMetricDescription]histogramMetric
+	sets                   map[statsDMetricDescription]setMetric
 	timersAndDistributions []pmetric.ScopeMetrics
 }
 
@@ -102,6 +105,7 @@ func newInstruments(addr net.Addr) *instruments {
 		counters:   make(map[statsDMetricDescription]pmetric.ScopeMetrics),
 		summaries:  make(map[statsDMetricDescription]summaryMetric),
 		histograms: make(map[statsDMetricDescription]histogramMetric),
+		sets:       make(map[statsDMetricDescription]setMetric),
 	}
 }
 
@@ -121,12 +125,19 @@ type histogramMetric struct {
 	agg *histogramStructure
 }
 
+// setMetric holds the unique values a set received during the interval.
+type setMetric map[string]struct{}
+
 type statsDMetric struct {
 	description statsDMetricDescription
 	asFloat     float64
 	addition    bool
 	unit        string
 	sampleRate  float64
+	// setValue is the raw value of a set, sets count unique values which need not be numbers.
+	setValue string
+	// timestamp is the DogStatsD client-side timestamp of the metric, zero if the client did not send one.
+	timestamp time.Time
 }
 
 type statsDMetricDescription struct {
@@ -147,6 +158,8 @@ func (t MetricType) FullName() TypeName {
 		return HistogramTypeName
 	case DistributionType:
 		return DistributionTypeName
+	case SetType:
+		return SetTypeName
 	}
 	return TypeName(fmt.Sprintf("unknown(%s)", t))
 }
@@ -172,7 +185,7 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 		case TimingTypeName, TimingAltTypeName:
 			p.timerEvents.method = eachMap.ObserverType
 			p.timerEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
-		case CounterTypeName, GaugeTypeName:
+		case CounterTypeName, GaugeTypeName, SetTypeName:
 		}
 	}
 	return nil
@@ -238,6 +251,13 @@ func (p *StatsDParser) GetMetrics() []BatchMetrics {
 			)
 		}
 
+		for desc, set := range instrument.sets {
+			ilm := rm.ScopeMetrics().AppendEmpty()
+			p.setVersionAndNameScope(ilm.Scope())
+
+			buildSetMetric(desc, set, now, ilm)
+		}
+
 		batchMetrics = append(batchMetrics, batch)
 	}
 	p.resetState(now)
@@ -263,7 +283,7 @@ func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 		return p.histogramEvents
 	case TimingType:
 		return p.timerEvents
-	case CounterType, GaugeType:
+	case CounterType, GaugeType, SetType:
 	}
 	return defaultObserverCategory
 }
@@ -291,6 +311,7 @@ func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
 			if parsedMetric.addition {
 				point := instrument.gauges[parsedMetric.description].Metrics().At(0).Gauge().DataPoints().At(0)
 				point.SetDoubleValue(point.DoubleValue() + parsedMetric.gaugeValue())
+				updateTimestamp(point.Timestamp, point.SetTimestamp, parsedMetric.timestamp)
 			} else {
 				instrument.gauges[parsedMetric.description] = buildGaugeMetric(parsedMetric, timeNowFunc())
 			}
@@ -303,8 +324,17 @@ func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
 		} else {
 			point := instrument.counters[parsedMetric.description].Metrics().At(0).Sum().DataPoints().At(0)
 			point.SetIntValue(point.IntValue() + parsedMetric.counterValue())
+			updateTimestamp(point.Timestamp, point.SetTimestamp, parsedMetric.timestamp)
 		}
 
+	case SetType:
+		set, ok := instrument.sets[parsedMetric.description]
+		if !ok {
+			set = make(setMetric)
+			instrument.sets[parsedMetric.description] = set
+		}
+		set[parsedMetric.setValue] = struct{}{}
+
 	case TimingType, HistogramType, DistributionType:
 		category := p.observerCategoryFor(parsedMetric.description.metricType)
 		switch category.method {
@@ -350,6 +380,13 @@ func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
 }
 
 func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, error) {
+	switch {
+	case strings.HasPrefix(line, eventPrefix):
+		return parseEvent(line, enableMetricType)
+	case strings.HasPrefix(line, serviceCheckPrefix):
+		return parseServiceCheck(line, enableMetricType)
+	}
+
 	result := statsDMetric{}
 
 	parts := strings.Split(line, "|")
@@ -370,17 +407,17 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 	if valueStr == "" {
 		return result, errEmptyMetricValue
 	}
-	if strings.HasPrefix(valueStr, "-") || strings.HasPrefix(valueStr, "+") {
-		result.addition = true
-	}
 
 	inType := MetricType(parts[1])
 	switch inType {
-	case CounterType, GaugeType, HistogramType, TimingType, DistributionType:
+	case CounterType, GaugeType, HistogramType, TimingType, DistributionType, SetType:
 		result.description.metricType = inType
 	default:
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
 	}
+	if inType != SetType && (strings.HasPrefix(valueStr, "-") || strings.HasPrefix(valueStr, "+")) {
+		result.addition = true
+	}
 
 	additionalParts := parts[2:]
 
@@ -398,47 +435,89 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			// handle an empty tag set
-			// where the tags part was still sent (some clients do this)
-			if len(tagsStr) == 0 {
-				continue
+			var err error
+			if kvs, err = parseTags(strings.TrimPrefix(part, "#"), kvs); err != nil {
+				return result, err
 			}
-
-			tagSets := strings.Split(tagsStr, ",")
-
-			for _, tagSet := range tagSets {
-				tagParts := strings.SplitN(tagSet, ":", 2)
-				if len(tagParts) != 2 {
-					return result, fmt.Errorf("invalid tag format: %s", tagParts)
-				}
-				k := tagParts[0]
-				v := tagParts[1]
-				kvs = append(kvs, attribute.String(k, v))
+		case strings.HasPrefix(part, containerIDPrefix):
+			kvs = append(kvs, attribute.String(attributeContainerID, strings.TrimPrefix(part, containerIDPrefix)))
+		case strings.HasPrefix(part, timestampPrefix):
+			var err error
+			if result.timestamp, err = parseTimestamp(strings.TrimPrefix(part, timestampPrefix)); err != nil {
+				return result, err
 			}
 		default:
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
 	}
-	var err error
-	result.asFloat, err = strconv.ParseFloat(valueStr, 64)
-	if err != nil {
-		return result, fmt.Errorf("parse metric value string: %s", valueStr)
+	if inType == SetType {
+		result.setValue = valueStr
+	} else {
+		var err error
+		result.asFloat, err = strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return result, fmt.Errorf("parse metric value string: %s", valueStr)
+		}
+	}
+
+	result.setAttributes(kvs, enableMetricType)
+	return result, nil
+}
+
+// parseTags appends the tags of a "#" message part to kvs.
+func parseTags(tagsStr string, kvs []attribute.KeyValue) ([]attribute.KeyValue, error) {
+	// handle an empty tag set
+	// where the tags part was still sent (some clients do this)
+	if len(tagsStr) == 0 {
+		return kvs, nil
 	}
 
+	tagSets := strings.Split(tagsStr, ",")
+
+	for _, tagSet := range tagSets {
+		tagParts := strings.SplitN(tagSet, ":", 2)
+		if len(tagParts) != 2 {
+			return kvs, fmt.Errorf("invalid tag format: %s", tagParts)
+		}
+		k := tagParts[0]
+		v := tagParts[1]
+		kvs = append(kvs, attribute.String(k, v))
+	}
+	return kvs, nil
+}
+
+// parseTimestamp parses a DogStatsD timestamp, in seconds since the Unix epoch.
+func parseTimestamp(timestampStr string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil || seconds <= 0 {
+		return time.Time{}, fmt.Errorf("parse timestamp: %s", timestampStr)
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+// setAttributes sets the attributes of the metric description from the tags of the message.
+func (s *statsDMetric) setAttributes(kvs []attribute.KeyValue, enableMetricType bool) {
 	// add metric_type dimension for all metrics
 	if enableMetricType {
-		metricType := string(result.description.metricType.FullName())
+		metricType := string(s.description.metricType.FullName())
 
 		kvs = append(kvs, attribute.String(tagMetricType, metricType))
 	}
 
 	if len(kvs) != 0 {
-		result.description.attrs = attribute.NewSet(kvs...)
+		s.description.attrs = attribute.NewSet(kvs...)
 	}
+}
 
-	return result, nil
+// updateTimestamp sets the timestamp of a point to the client-side timestamp of a metric
+// aggregated into it, if the metric has one and it is later than the current timestamp.
+func updateTimestamp(get func() pcommon.Timestamp, set func(pcommon.Timestamp), timestamp time.Time) {
+	if timestamp.IsZero() {
+		return
+	}
+	if ts := pcommon.NewTimestampFromTime(timestamp); ts > get() {
+		set(ts)
+	}
 }
 
 type netAddr struct {
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/otel/attribute"
 
@@ -231,6 +232,29 @@ func Test_ParseMessageToMetric(t *testing.T) {
 				false,
 				"h", 0, nil, nil),
 		},
+		{
+			name:  "set",
+			input: "test.metric:-user.1|s|#key:value",
+			wantMetric: func() statsDMetric {
+				m := testStatsDMetric("test.metric", 0, false, "s", 0, []string{"key"}, []string{"value"})
+				m.setValue = "-user.1"
+				return m
+			}(),
+		},
+		{
+			name:  "counter with container id and timestamp",
+			input: "test.metric:42|c|#key:value|c:abc123|T1697000000",
+			wantMetric: func() statsDMetric {
+				m := testStatsDMetric("test.metric", 42, false, "c", 0, []string{"key", "container.id"}, []string{"value", "abc123"})
+				m.timestamp = time.Unix(1697000000, 0)
+				return m
+			}(),
+		},
+		{
+			name:  "invalid timestamp",
+			input: "test.metric:42|c|T-5",
+			err:   errors.New("parse timestamp: -5"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1410,3 +1434,58 @@ func TestStatsDParser_AggregateTimerWithHistogram(t *testing.T) {
 		})
 	}
 }
+
+func TestStatsDParser_AggregateSet(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	require.NoError(t, p.Initialize(false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	for _, line := range []string{
+		"users:alice|s|#page:home",
+		"users:bob|s|#page:home",
+		"users:alice|s|#page:home",
+		"users:alice|s|#page:cart",
+	} {
+		require.NoError(t, p.Aggregate(line, addr))
+	}
+
+	metrics := p.GetMetrics()[0].Metrics
+	require.Equal(t, 2, metrics.MetricCount())
+	uniqueByPage := map[string]int64{}
+	scopeMetrics := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	for i := 0; i < scopeMetrics.Len(); i++ {
+		metric := scopeMetrics.At(i).Metrics().At(0)
+		assert.Equal(t, "users", metric.Name())
+		dp := metric.Gauge().DataPoints().At(0)
+		page, _ := dp.Attributes().Get("page")
+		uniqueByPage[page.Str()] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"home": 2, "cart": 1}, uniqueByPage)
+}
+
+func TestStatsDParser_AggregateWithTimestamp(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	require.NoError(t, p.Initialize(false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	require.NoError(t, p.Aggregate("requests:1|c|T650", addr))
+	require.NoError(t, p.Aggregate("requests:1|c|T600", addr))
+	require.NoError(t, p.Aggregate("temperature:21|g|T700", addr))
+
+	metrics := p.GetMetrics()[0].Metrics
+	scopeMetrics := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	require.Equal(t, 2, scopeMetrics.Len())
+
+	gauge := scopeMetrics.At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(700, 0)), gauge.Timestamp())
+
+	counter := scopeMetrics.At(1).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(2), counter.IntValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(650, 0)), counter.Timestamp())
+}
@@ -24,6 +24,8 @@ const (
 	TCP Transport = iota
 	// UDP Transport
 	UDP
+	// Unixgram Transport, Host is the path of the socket and Port is ignored.
+	Unixgram
 )
 
 // NewStatsD creates a new StatsD instance to support the need for testing
@@ -69,6 +71,11 @@ func (s *StatsD) connect(transport Transport) error {
 		if err != nil {
 			return err
 		}
+	case Unixgram:
+		s.Conn, err = net.Dial("unixgram", s.Host)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown transport: %d", transport)
 	}
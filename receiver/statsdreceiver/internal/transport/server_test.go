@@ -5,6 +5,8 @@ package transport
 
 import (
 	"net"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"sync"
@@ -115,3 +117,65 @@ func Test_Server_ListenAndServe(t *testing.T) {
 		})
 	}
 }
+
+func Test_UnixgramServer_ListenAndServe(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unixgram sockets are not supported on Windows")
+	}
+	socketPath := filepath.Join(t.TempDir(), "statsd.sock")
+
+	srv, err := NewUnixgramServer(socketPath)
+	require.NoError(t, err)
+	require.NotNil(t, srv)
+
+	mc := new(consumertest.MetricsSink)
+	p := &protocol.StatsDParser{}
+	mr := NewMockReporter(1)
+	transferChan := make(chan Metric, 10)
+
+	wgListenAndServe := sync.WaitGroup{}
+	wgListenAndServe.Add(1)
+	go func() {
+		defer wgListenAndServe.Done()
+		assert.Error(t, srv.ListenAndServe(p, mc, mr, transferChan))
+	}()
+
+	runtime.Gosched()
+
+	gc, err := client.NewStatsD(client.Unixgram, socketPath, 0)
+	require.NoError(t, err)
+	err = gc.SendMetric(client.Metric{
+		Name:  "test.metric",
+		Value: "42",
+		Type:  "c",
+	})
+	assert.NoError(t, err)
+	err = gc.Disconnect()
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return len(transferChan) > 0
+	}, 10*time.Second, 500*time.Millisecond)
+
+	err = srv.Close()
+	assert.NoError(t, err)
+	wgListenAndServe.Wait()
+
+	require.Equal(t, 1, len(transferChan))
+	metric := <-transferChan
+	assert.Equal(t, "test.metric:42|c", metric.Raw)
+	assert.NotNil(t, metric.Addr)
+	assert.NoFileExists(t, socketPath)
+}
+
+func Test_NewUnixgramServer_KeepsOtherFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unixgram sockets are not supported on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "statsd.sock")
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+
+	_, err := NewUnixgramServer(path)
+	assert.Error(t, err)
+	assert.FileExists(t, path)
+}
@@ -53,7 +53,7 @@ func (u *udpServer) ListenAndServe(
 		if n > 0 {
 			bufCopy := make([]byte, n)
 			copy(bufCopy, buf)
-			u.handlePacket(bufCopy, addr, transferChan)
+			handlePacket(bufCopy, addr, transferChan)
 		}
 		if err != nil {
 			u.reporter.OnDebugf("UDP Transport (%s) - ReadFrom error: %v",
@@ -74,7 +74,8 @@ func (u *udpServer) Close() error {
 	return u.packetConn.Close()
 }
 
-func (u *udpServer) handlePacket(
+// handlePacket sends each line of a datagram to transferChan.
+func handlePacket(
 	data []byte,
 	addr net.Addr,
 	transferChan chan<- Metric,
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
+)
+
+type unixgramServer struct {
+	packetConn net.PacketConn
+	path       string
+	reporter   Reporter
+}
+
+var _ Server = (*unixgramServer)(nil)
+
+// NewUnixgramServer creates a transport.Server using Unix datagram sockets as its transport.
+// A socket file left behind at path by a previous run is replaced.
+func NewUnixgramServer(path string) (Server, error) {
+	if err := removeSocket(path); err != nil {
+		return nil, err
+	}
+	packetConn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		return nil, err
+	}
+
+	u := unixgramServer{
+		packetConn: packetConn,
+		path:       path,
+	}
+	return &u, nil
+}
+
+func (u *unixgramServer) ListenAndServe(
+	parser protocol.Parser,
+	nextConsumer consumer.Metrics,
+	reporter Reporter,
+	transferChan chan<- Metric,
+) error {
+	if parser == nil || nextConsumer == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	u.reporter = reporter
+
+	buf := make([]byte, 65527) // same limit as the UDP transport, clients rarely send larger datagrams
+	for {
+		n, addr, err := u.packetConn.ReadFrom(buf)
+		if n > 0 {
+			// Clients usually do not bind their end of the socket, aggregate their metrics under the
+			// address of the server.
+			if addr == nil {
+				addr = u.packetConn.LocalAddr()
+			}
+			bufCopy := make([]byte, n)
+			copy(bufCopy, buf)
+			handlePacket(bufCopy, addr, transferChan)
+		}
+		if err != nil {
+			u.reporter.OnDebugf("Unixgram Transport (%s) - ReadFrom error: %v",
+				u.path,
+				err)
+			var netErr net.Error
+			if errors.As(err, &netErr) {
+				if netErr.Timeout() {
+					continue
+				}
+			}
+			return err
+		}
+	}
+}
+
+func (u *unixgramServer) Close() error {
+	err := u.packetConn.Close()
+	if removeErr := removeSocket(u.path); err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// removeSocket removes the socket file at path, if any. It refuses to remove any other kind of file.
+func removeSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	return os.Remove(path)
+}
@@ -285,3 +285,116 @@ func (dp *FileDataProvider) GenerateLogs() (plog.Logs, bool) {
 	dp.dataItemsGenerated.Add(uint64(dp.ItemsPerBatch))
 	return dp.logs, false
 }
+
+// CardinalityOptions defines the time series generated by a high cardinality data provider.
+type CardinalityOptions struct {
+	// UniqueSeries is the number of unique time series the data items are spread over.
+	UniqueSeries int
+
+	// ChurnInterval is the interval at which all the time series are replaced by new ones,
+	// zero means the time series never change.
+	ChurnInterval time.Duration
+}
+
+// highCardinalityDataProvider is an implementation of the DataProvider for use in performance tests
+// of components keeping state per time series. The data items are spread over a controlled number of
+// time series, told apart by their "series_id" attribute, which are renamed at every churn interval.
+type highCardinalityDataProvider struct {
+	options            LoadOptions
+	cardinality        CardinalityOptions
+	startTime          time.Time
+	itemSequence       atomic.Uint64
+	dataItemsGenerated *atomic.Uint64
+}
+
+// NewHighCardinalityDataProvider creates an instance of highCardinalityDataProvider generating spans and
+// cumulative sums spread over the time series specified in the supplied CardinalityOptions.
+func NewHighCardinalityDataProvider(options LoadOptions, cardinality CardinalityOptions) DataProvider {
+	if cardinality.UniqueSeries < 1 {
+		cardinality.UniqueSeries = 1
+	}
+	return &highCardinalityDataProvider{
+		options:     options,
+		cardinality: cardinality,
+		startTime:   time.Now(),
+	}
+}
+
+func (dp *highCardinalityDataProvider) SetLoadGeneratorCounters(dataItemsGenerated *atomic.Uint64) {
+	dp.dataItemsGenerated = dataItemsGenerated
+}
+
+// series returns the time series of the next data item, the time the series appeared and how many
+// data items were generated for it before. Consecutive data items are generated for consecutive series.
+func (dp *highCardinalityDataProvider) series(now time.Time) (string, time.Time, uint64) {
+	seq := dp.itemSequence.Add(1) - 1
+	unique := uint64(dp.cardinality.UniqueSeries)
+	id := "series_" + strconv.FormatUint(seq%unique, 10)
+	startTime := dp.startTime
+	if dp.cardinality.ChurnInterval > 0 {
+		churns := now.Sub(dp.startTime) / dp.cardinality.ChurnInterval
+		id += "_" + strconv.FormatInt(int64(churns), 10)
+		startTime = dp.startTime.Add(churns * dp.cardinality.ChurnInterval)
+	}
+	return id, startTime, seq / unique
+}
+
+func (dp *highCardinalityDataProvider) GenerateTraces() (ptrace.Traces, bool) {
+	traceData := ptrace.NewTraces()
+	rs := traceData.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "load-generator")
+	for k, v := range dp.options.Attributes {
+		rs.Resource().Attributes().PutStr(k, v)
+	}
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	spans.EnsureCapacity(dp.options.ItemsPerBatch)
+
+	now := time.Now()
+	for i := 0; i < dp.options.ItemsPerBatch; i++ {
+		series, _, _ := dp.series(now)
+		spanID := dp.dataItemsGenerated.Add(1)
+
+		span := spans.AppendEmpty()
+		span.SetTraceID(idutils.UInt64ToTraceID(0, spanID))
+		span.SetSpanID(idutils.UInt64ToSpanID(spanID))
+		span.SetName("load-generator-span")
+		span.SetKind(ptrace.SpanKindServer)
+		span.Attributes().PutStr("series_id", series)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(now.Add(-time.Millisecond)))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(now))
+	}
+	return traceData, false
+}
+
+func (dp *highCardinalityDataProvider) GenerateMetrics() (pmetric.Metrics, bool) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	for k, v := range dp.options.Attributes {
+		rm.Resource().Attributes().PutStr(k, v)
+	}
+	metric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("load_generator.requests")
+	metric.SetUnit("1")
+	sum := metric.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dps := sum.DataPoints()
+	dps.EnsureCapacity(dp.options.ItemsPerBatch)
+
+	now := time.Now()
+	for i := 0; i < dp.options.ItemsPerBatch; i++ {
+		series, startTime, count := dp.series(now)
+		dp.dataItemsGenerated.Add(1)
+
+		dataPoint := dps.AppendEmpty()
+		dataPoint.Attributes().PutStr("series_id", series)
+		dataPoint.SetStartTimestamp(pcommon.NewTimestampFromTime(startTime))
+		dataPoint.SetTimestamp(pcommon.NewTimestampFromTime(now))
+		dataPoint.SetIntValue(int64(count + 1))
+	}
+	return md, false
+}
+
+func (dp *highCardinalityDataProvider) GenerateLogs() (plog.Logs, bool) {
+	return plog.NewLogs(), true
+}
@@ -6,7 +6,9 @@ package testbed
 import (
 	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
@@ -26,3 +28,47 @@ func TestGoldenDataProvider(t *testing.T) {
 	}
 	require.Equal(t, len(dp.(*goldenDataProvider).metricsGenerated), len(ms))
 }
+
+func TestHighCardinalityDataProvider(t *testing.T) {
+	dp := NewHighCardinalityDataProvider(LoadOptions{ItemsPerBatch: 4}, CardinalityOptions{UniqueSeries: 3})
+	generated := &atomic.Uint64{}
+	dp.SetLoadGeneratorCounters(generated)
+
+	var series []string
+	var values []int64
+	for i := 0; i < 2; i++ {
+		md, done := dp.GenerateMetrics()
+		require.False(t, done)
+		sum := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum()
+		assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.AggregationTemporality())
+		for j := 0; j < sum.DataPoints().Len(); j++ {
+			id, ok := sum.DataPoints().At(j).Attributes().Get("series_id")
+			require.True(t, ok)
+			series = append(series, id.Str())
+			values = append(values, sum.DataPoints().At(j).IntValue())
+		}
+	}
+	assert.Equal(t, []string{"series_0", "series_1", "series_2", "series_0", "series_1", "series_2", "series_0", "series_1"}, series)
+	assert.Equal(t, []int64{1, 1, 1, 2, 2, 2, 3, 3}, values)
+	assert.Equal(t, uint64(8), generated.Load())
+
+	td, done := dp.GenerateTraces()
+	require.False(t, done)
+	require.Equal(t, 4, td.SpanCount())
+	id, ok := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("series_id")
+	require.True(t, ok)
+	assert.Equal(t, "series_2", id.Str())
+}
+
+func TestHighCardinalityDataProvider_churn(t *testing.T) {
+	dp := NewHighCardinalityDataProvider(LoadOptions{ItemsPerBatch: 1}, CardinalityOptions{UniqueSeries: 2, ChurnInterval: time.Minute}).(*highCardinalityDataProvider)
+	dp.startTime = time.Now().Add(-150 * time.Second)
+
+	id, startTime, count := dp.series(time.Now())
+	assert.Equal(t, "series_0_2", id)
+	assert.Equal(t, dp.startTime.Add(2*time.Minute), startTime)
+	assert.Equal(t, uint64(0), count)
+
+	id, _, _ = dp.series(dp.startTime.Add(30 * time.Second))
+	assert.Equal(t, "series_1_0", id)
+}
@@ -63,6 +63,20 @@ func (v *PerfTestValidator) RecordResults(tc *TestCase) {
 	})
 }
 
+// ResourceUsageValidator implements TestCaseValidator for performance tests of components which do not
+// forward the data items they receive one to one, e.g. connectors or components aggregating time series.
+// Only the resource consumption of the collector is verified, the received data items are not compared
+// to the sent ones.
+type ResourceUsageValidator struct {
+	PerfTestValidator
+}
+
+func (v *ResourceUsageValidator) Validate(tc *TestCase) {
+	if assert.NotZero(tc.t, tc.MockBackend.DataItemsReceived(), "No data items were received.") {
+		log.Printf("Data items received.")
+	}
+}
+
 // CorrectnessTestValidator implements TestCaseValidator for test suites using CorrectnessResults for summarizing results.
 type CorrectnessTestValidator struct {
 	dataProvider         DataProvider
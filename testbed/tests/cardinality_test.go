@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tests
+
+// This file contains Test functions measuring the resource consumption of components
+// keeping state per time series, while the time series are continuously replaced.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/datareceivers"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/testbed"
+)
+
+// seriesChurn spreads the data items over 10k time series which are all replaced every 5 seconds.
+var seriesChurn = testbed.CardinalityOptions{
+	UniqueSeries:  10_000,
+	ChurnInterval: 5 * time.Second,
+}
+
+func TestSpanMetricsConnectorSeriesChurn(t *testing.T) {
+	ScenarioHighCardinality(
+		t,
+		testbed.NewOTLPTraceDataSender(testbed.DefaultHost, testbed.GetAvailablePort(t)),
+		testbed.NewOTLPDataReceiver(testbed.GetAvailablePort(t)),
+		testbed.ResourceSpec{
+			ExpectedMaxCPU: 120,
+			ExpectedMaxRAM: 300,
+		},
+		performanceResultsSummary,
+		seriesChurn,
+		nil,
+		map[string]string{
+			"spanmetrics": `
+  spanmetrics:
+    metrics_flush_interval: 1s
+    dimensions:
+      - name: series_id
+`,
+		},
+	)
+}
+
+func TestCumulativeToDeltaProcessorSeriesChurn(t *testing.T) {
+	tests := []struct {
+		name         string
+		processor    string
+		resourceSpec testbed.ResourceSpec
+	}{
+		{
+			name: "NoStaleness",
+			processor: `
+  cumulativetodelta:
+`,
+			resourceSpec: testbed.ResourceSpec{
+				ExpectedMaxCPU: 90,
+				ExpectedMaxRAM: 250,
+			},
+		},
+		{
+			name: "MaxStaleness",
+			processor: `
+  cumulativetodelta:
+    max_staleness: 5s
+`,
+			resourceSpec: testbed.ResourceSpec{
+				ExpectedMaxCPU: 90,
+				ExpectedMaxRAM: 200,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ScenarioHighCardinality(
+				t,
+				testbed.NewOTLPMetricDataSender(testbed.DefaultHost, testbed.GetAvailablePort(t)),
+				testbed.NewOTLPDataReceiver(testbed.GetAvailablePort(t)),
+				test.resourceSpec,
+				performanceResultsSummary,
+				seriesChurn,
+				map[string]string{"cumulativetodelta": test.processor},
+				nil,
+			)
+		})
+	}
+}
+
+func TestPrometheusExporterSeriesChurn(t *testing.T) {
+	ScenarioHighCardinality(
+		t,
+		testbed.NewOTLPMetricDataSender(testbed.DefaultHost, testbed.GetAvailablePort(t)),
+		datareceivers.NewPrometheusDataReceiver(testbed.GetAvailablePort(t)),
+		testbed.ResourceSpec{
+			ExpectedMaxCPU: 150,
+			ExpectedMaxRAM: 300,
+		},
+		performanceResultsSummary,
+		seriesChurn,
+		nil,
+		nil,
+	)
+}
@@ -123,6 +123,78 @@ service:
 	)
 }
 
+// createConnectorConfigYaml creates a collector config file in which the data
+// received from the sender is sent to the connectors, and the metrics produced
+// by the connectors are exported to the receiver. Map of connector names to
+// their configs. Config is in YAML and must be indented by 2 spaces.
+func createConnectorConfigYaml(
+	t *testing.T,
+	sender testbed.DataSender,
+	receiver testbed.DataReceiver,
+	resultDir string,
+	connectors map[string]string,
+) string {
+
+	connectorsSections := ""
+	connectorsList := ""
+	first := true
+	for name, cfg := range connectors {
+		connectorsSections += cfg + "\n"
+		if !first {
+			connectorsList += ","
+		}
+		connectorsList += name
+		first = false
+	}
+
+	// Set the input pipeline based on DataSender type, the connectors always output metrics.
+	var pipeline string
+	switch sender.(type) {
+	case testbed.TraceDataSender:
+		pipeline = "traces"
+	case testbed.MetricDataSender:
+		pipeline = "metrics"
+	case testbed.LogDataSender:
+		pipeline = "logs"
+	default:
+		t.Error("Invalid DataSender type")
+	}
+
+	format := `
+receivers:%v
+exporters:%v
+connectors:
+  %s
+
+extensions:
+  pprof:
+    save_to_file: %v/cpu.prof
+
+service:
+  extensions: [pprof]
+  pipelines:
+    %s/in:
+      receivers: [%v]
+      exporters: [%s]
+    metrics/out:
+      receivers: [%s]
+      exporters: [%v]
+`
+
+	return fmt.Sprintf(
+		format,
+		sender.GenConfigYAMLStr(),
+		receiver.GenConfigYAMLStr(),
+		connectorsSections,
+		resultDir,
+		pipeline,
+		sender.ProtocolName(),
+		connectorsList,
+		connectorsList,
+		receiver.ProtocolName(),
+	)
+}
+
 // Scenario10kItemsPerSecond runs 10k data items/sec test using specified sender and receiver protocols.
 func Scenario10kItemsPerSecond(
 	t *testing.T,
@@ -179,6 +251,71 @@ func Scenario10kItemsPerSecond(
 	tc.ValidateData()
 }
 
+// ScenarioHighCardinality runs a 10k data items/sec test in which the data items are spread over
+// the time series specified by cardinality. Since the processors and connectors under test aggregate
+// the time series, only the resource consumption of the collector is validated. If connectors are
+// specified they receive the data items and their metrics are exported, otherwise the data items go
+// through the processors.
+func ScenarioHighCardinality(
+	t *testing.T,
+	sender testbed.DataSender,
+	receiver testbed.DataReceiver,
+	resourceSpec testbed.ResourceSpec,
+	resultsSummary testbed.TestResultsSummary,
+	cardinality testbed.CardinalityOptions,
+	processors map[string]string,
+	connectors map[string]string,
+) {
+	resultDir, err := filepath.Abs(path.Join("results", t.Name()))
+	require.NoError(t, err)
+
+	options := testbed.LoadOptions{
+		DataItemsPerSecond: 10_000,
+		ItemsPerBatch:      100,
+		Parallel:           1,
+	}
+	agentProc := testbed.NewChildProcessCollector()
+
+	var configStr string
+	if len(connectors) > 0 {
+		configStr = createConnectorConfigYaml(t, sender, receiver, resultDir, connectors)
+	} else {
+		configStr = createConfigYaml(t, sender, receiver, resultDir, processors, nil)
+	}
+	configCleanup, err := agentProc.PrepareConfig(configStr)
+	require.NoError(t, err)
+	defer configCleanup()
+
+	dataProvider := testbed.NewHighCardinalityDataProvider(options, cardinality)
+	tc := testbed.NewTestCase(
+		t,
+		dataProvider,
+		sender,
+		receiver,
+		agentProc,
+		&testbed.ResourceUsageValidator{},
+		resultsSummary,
+		testbed.WithResourceLimits(resourceSpec),
+	)
+	defer tc.Stop()
+
+	tc.StartBackend()
+	tc.StartAgent()
+
+	tc.StartLoad(options)
+
+	tc.Sleep(tc.Duration)
+
+	tc.StopLoad()
+
+	tc.WaitFor(func() bool { return tc.LoadGenerator.DataItemsSent() > 0 }, "load generator started")
+	tc.WaitFor(func() bool { return tc.MockBackend.DataItemsReceived() > 0 }, "data items received")
+
+	tc.StopAgent()
+
+	tc.ValidateData()
+}
+
 // TestCase for Scenario1kSPSWithAttrs func.
 type TestCase struct {
 	attrCount      int